@@ -1,17 +1,23 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/rusik69/govnocloud2/pkg/client"
 	"github.com/rusik69/govnocloud2/pkg/types"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
+	"sigs.k8s.io/yaml"
 )
 
 // CommandHandler defines the interface for resource command handlers
@@ -69,6 +75,12 @@ var handlers = map[string]CommandHandler{
 	"volumes":    initVolumeHandler(),
 	"namespaces": initNamespaceHandler(),
 	"users":      initUserHandler(),
+	"drift":      initDriftHandler(),
+	"sshkeys":    initSSHKeyHandler(),
+	"images":     initImageHandler(),
+	"sizes":      initSizeHandler(),
+	"schedules":  initScheduleHandler(),
+	"templates":  initVMTemplateHandler(),
 }
 
 // client command
@@ -247,7 +259,11 @@ func initVMHandler() CommandHandler {
 			return err
 		}
 		for _, vm := range vms {
-			fmt.Println(vm)
+			ip := ""
+			if len(vm.Interfaces) > 0 {
+				ip = vm.Interfaces[0].IP
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\t%s\t%s\n", vm.Name, vm.Status, vm.Size, vm.Node, ip, vm.Uptime)
 		}
 		return nil
 	})
@@ -272,14 +288,47 @@ func initVMHandler() CommandHandler {
 		if err := validateResourceName(args[3]); err != nil {
 			return err
 		}
-		return c.CreateVM(args[0], args[1], args[2], args[3])
+		vm := types.VM{
+			Name:      args[0],
+			Image:     args[1],
+			Size:      args[2],
+			Namespace: args[3],
+		}
+		// extra arguments are the unattend.xml file of sysprep images or placement options when they start with an
+		// option name and :, networks when they contain =, registered SSH key names otherwise
+		for _, arg := range args[4:] {
+			if file, ok := strings.CutPrefix(arg, "sysprep:"); ok {
+				unattend, err := os.ReadFile(file)
+				if err != nil {
+					return fmt.Errorf("error reading sysprep file: %w", err)
+				}
+				vm.Sysprep = string(unattend)
+				continue
+			}
+			if ok, err := parseVMPlacementArg(&vm, arg); err != nil {
+				return err
+			} else if ok {
+				continue
+			}
+			if !strings.Contains(arg, "=") {
+				vm.SSHKeyNames = append(vm.SSHKeyNames, arg)
+				continue
+			}
+			network, err := parseVMNetwork(arg)
+			if err != nil {
+				return err
+			}
+			vm.Networks = append(vm.Networks, network)
+		}
+		return c.CreateVMFromSpec(vm)
 	})
 
 	handler.RegisterCommand("delete", func(c *client.Client, args []string) error {
 		if err := validateArgs(args, 2); err != nil {
 			return err
 		}
-		return c.DeleteVM(args[0], args[1])
+		retainDisk := len(args) > 2 && args[2] == "retain-disk"
+		return c.DeleteVMWithDisk(args[0], args[1], retainDisk)
 	})
 
 	handler.RegisterCommand("get", func(c *client.Client, args []string) error {
@@ -293,6 +342,234 @@ func initVMHandler() CommandHandler {
 		return printJSON(vm)
 	})
 
+	handler.RegisterCommand("attach", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		return c.AttachVMVolume(args[0], args[1], args[2])
+	})
+
+	handler.RegisterCommand("detach", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		return c.DetachVMVolume(args[0], args[1], args[2])
+	})
+
+	handler.RegisterCommand("migrate", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		migration, err := c.MigrateVM(args[0], args[1], optionalArg(args, 2))
+		if err != nil {
+			return err
+		}
+		return printJSON(migration)
+	})
+
+	handler.RegisterCommand("migrations", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		migrations, err := c.ListVMMigrations(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(migrations)
+	})
+
+	handler.RegisterCommand("metrics", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		metrics, err := c.GetVMMetrics(args[0], args[1], optionalArg(args, 2))
+		if err != nil {
+			return err
+		}
+		// the full series are for charting, the CLI shows the latest and peak value of each
+		for _, series := range metrics.Series {
+			if len(series.Points) == 0 {
+				continue
+			}
+			peak := series.Points[0].Value
+			for _, point := range series.Points {
+				peak = max(peak, point.Value)
+			}
+			fmt.Printf("%s\t%s\t%.2f\t%.2f\t%s\n", series.Metric, series.Device, series.Points[len(series.Points)-1].Value, peak, series.Unit)
+		}
+		return nil
+	})
+
+	handler.RegisterCommand("clone", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		if err := validateResourceName(args[2]); err != nil {
+			return err
+		}
+		return c.CloneVM(args[0], args[1], args[2])
+	})
+
+	handler.RegisterCommand("image", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		if err := validateResourceName(args[2]); err != nil {
+			return err
+		}
+		image, err := c.ExportVMImage(args[0], args[1], args[2])
+		if err != nil {
+			return err
+		}
+		return printJSON(image)
+	})
+
+	handler.RegisterCommand("export", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		format := optionalArg(args, 3)
+		if format == "" {
+			format = types.VMExportFormatTar
+		}
+		file, err := os.Create(args[2])
+		if err != nil {
+			return fmt.Errorf("error creating %s: %w", args[2], err)
+		}
+		defer file.Close()
+		if err := c.ExportVM(args[0], args[1], format, file); err != nil {
+			os.Remove(args[2])
+			return err
+		}
+		return file.Close()
+	})
+
+	handler.RegisterCommand("import", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		if err := validateResourceName(args[0]); err != nil {
+			return err
+		}
+		file, err := os.Open(args[2])
+		if err != nil {
+			return fmt.Errorf("error opening %s: %w", args[2], err)
+		}
+		defer file.Close()
+		return c.ImportVM(args[0], args[1], file)
+	})
+
+	handler.RegisterCommand("snapshot", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		if err := validateResourceName(args[2]); err != nil {
+			return err
+		}
+		return c.CreateVMSnapshot(args[0], args[1], args[2])
+	})
+
+	handler.RegisterCommand("snapshots", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		snapshots, err := c.ListVMSnapshots(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(snapshots)
+	})
+
+	handler.RegisterCommand("deletesnapshot", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		return c.DeleteVMSnapshot(args[0], args[1], args[2])
+	})
+
+	handler.RegisterCommand("restore", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		restore, err := c.RestoreVMSnapshot(args[0], args[1], args[2])
+		if err != nil {
+			return err
+		}
+		return printJSON(restore)
+	})
+
+	handler.RegisterCommand("console", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		conn, err := c.ConsoleVM(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		return attachConsole(conn)
+	})
+
+	handler.RegisterCommand("ports", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		ports, err := c.GetVMPorts(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(ports)
+	})
+
+	handler.RegisterCommand("resize", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		resize := types.VMResize{Size: args[2]}
+		if len(args) > 3 {
+			cpu, err := strconv.Atoi(args[2])
+			if err != nil {
+				return fmt.Errorf("invalid cpu %s: %w", args[2], err)
+			}
+			ram, err := strconv.Atoi(args[3])
+			if err != nil {
+				return fmt.Errorf("invalid ram %s: %w", args[3], err)
+			}
+			resize = types.VMResize{CPU: cpu, RAM: ram}
+		}
+		result, err := c.ResizeVM(args[0], args[1], resize)
+		if err != nil {
+			return err
+		}
+		return printJSON(result)
+	})
+
+	handler.RegisterCommand("run", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		policy, err := c.SetVMRunPolicy(args[0], args[1], types.VMRunPolicy{RunStrategy: args[2], IdleTimeout: optionalArg(args, 3)})
+		if err != nil {
+			return err
+		}
+		return printJSON(policy)
+	})
+
+	handler.RegisterCommand("expose", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		ports := types.VMPorts{ServiceType: args[2]}
+		for _, arg := range args[3:] {
+			port, err := parseVMPort(arg)
+			if err != nil {
+				return err
+			}
+			ports.Ports = append(ports.Ports, port)
+		}
+		return c.SetVMPorts(args[0], args[1], ports)
+	})
+
 	handler.RegisterCommand("wait", func(c *client.Client, args []string) error {
 		if err := validateArgs(args, 2); err != nil {
 			return err
@@ -382,6 +659,27 @@ func initContainerHandler() CommandHandler {
 		return printJSON(container)
 	})
 
+	handler.RegisterCommand("start", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		return c.StartContainer(args[0], args[1])
+	})
+
+	handler.RegisterCommand("stop", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		return c.StopContainer(args[0], args[1])
+	})
+
+	handler.RegisterCommand("restart", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		return c.RestartContainer(args[0], args[1])
+	})
+
 	return handler
 }
 
@@ -756,65 +1054,758 @@ func initLLMHandler() CommandHandler {
 	return handler
 }
 
-// printHelp displays the help information with all available actions
-func printHelp() {
-	fmt.Println("govnocloud2 client - Command-line interface for managing GovnoCloud resources")
-	fmt.Println()
-	fmt.Println("Usage:")
-	fmt.Println("  govnocloud2 client [resource] [action] [args...]")
-	fmt.Println()
-	fmt.Println("Available Resources and Actions:")
-	fmt.Println()
+// consoleEscape is the key detaching the local terminal from a VM console, Ctrl+]
+const consoleEscape = 0x1d
 
-	fmt.Println("  nodes:")
-	fmt.Println("    list                           - List all nodes")
-	fmt.Println("    get <name>                     - Get node details")
-	fmt.Println("    add <name> <ip> <mac> <cpu> <mem> - Add a new node")
-	fmt.Println("    delete <name>                  - Delete a node")
-	fmt.Println("    restart <name>                 - Restart a node")
-	fmt.Println()
+// attachConsole connects the local terminal to a VM console until the console closes or Ctrl+] is pressed
+func attachConsole(conn *websocket.Conn) error {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		state, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("failed to make terminal raw: %w", err)
+		}
+		defer term.Restore(fd, state)
+	}
+	fmt.Fprint(os.Stderr, "Connected to the console, press Ctrl+] to detach\r\n")
 
-	fmt.Println("  vms:")
-	fmt.Println("    list <namespace>               - List VMs in namespace")
-	fmt.Println("    create <namespace> <name> <cpu> <mem> - Create a new VM")
-	fmt.Println("    get <namespace> <name>         - Get VM details")
-	fmt.Println("    delete <namespace> <name>      - Delete a VM")
-	fmt.Println("    start <namespace> <name>       - Start a VM")
-	fmt.Println("    stop <namespace> <name>        - Stop a VM")
-	fmt.Println("    restart <namespace> <name>     - Restart a VM")
-	fmt.Println("    wait <namespace> <name>        - Wait for VM to be ready")
-	fmt.Println()
+	done := make(chan error, 2)
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				done <- err
+				return
+			}
+			if _, err := os.Stdout.Write(data); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+	go func() {
+		buf := make([]byte, 1024)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if err != nil {
+				done <- err
+				return
+			}
+			if i := bytes.IndexByte(buf[:n], consoleEscape); i >= 0 {
+				if i > 0 {
+					conn.WriteMessage(websocket.BinaryMessage, buf[:i])
+				}
+				done <- nil
+				return
+			}
+			if err := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+	err := <-done
+	if err == nil || websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		return nil
+	}
+	return err
+}
 
-	fmt.Println("  containers:")
-	fmt.Println("    list <namespace>               - List containers in namespace")
-	fmt.Println("    create <namespace> <name> <image> <cpu> <ram> <disk> <port> - Create a container")
-	fmt.Println("    get <namespace> <name>         - Get container details")
-	fmt.Println("    delete <namespace> <name>      - Delete a container")
-	fmt.Println()
+// parseVMPort parses a VM port given as <port>[:<destinationPort>][/<protocol>]
+func parseVMPort(arg string) (types.VMPort, error) {
+	var port types.VMPort
+	spec, protocol, found := strings.Cut(arg, "/")
+	if found {
+		port.Protocol = strings.ToUpper(protocol)
+	}
+	source, destination, found := strings.Cut(spec, ":")
+	var err error
+	if port.SourcePort, err = strconv.Atoi(source); err != nil {
+		return port, fmt.Errorf("invalid port %s: %w", arg, err)
+	}
+	if found {
+		if port.DestinationPort, err = strconv.Atoi(destination); err != nil {
+			return port, fmt.Errorf("invalid port %s: %w", arg, err)
+		}
+	}
+	return port, nil
+}
 
-	fmt.Println("  volumes:")
-	fmt.Println("    list <namespace>               - List volumes in namespace")
-	fmt.Println("    create <namespace> <name> <size> - Create a volume")
-	fmt.Println("    get <namespace> <name>         - Get volume details")
-	fmt.Println("    delete <namespace> <name>      - Delete a volume")
-	fmt.Println()
+// parseVMNetwork parses a VM network given as <name>=<pod|bridge|nad>[,<address/prefix>[,<gateway>[,<dns>...]]],
+// any other type names a NetworkAttachmentDefinition
+func parseVMNetwork(arg string) (types.VMNetwork, error) {
+	name, spec, _ := strings.Cut(arg, "=")
+	fields := strings.Split(spec, ",")
+	network := types.VMNetwork{Name: name, Type: fields[0]}
+	if network.Type == "" {
+		return network, fmt.Errorf("invalid network %s: type is required", arg)
+	}
+	if network.Type != types.VMNetworkPod && network.Type != types.VMNetworkBridge {
+		network.Type, network.NAD = types.VMNetworkNAD, fields[0]
+	}
+	if len(fields) > 1 {
+		network.Addressing, network.Address = types.VMAddressingStatic, fields[1]
+	}
+	if len(fields) > 2 {
+		network.Gateway = fields[2]
+	}
+	if len(fields) > 3 {
+		network.DNS = fields[3:]
+	}
+	return network, nil
+}
 
-	fmt.Println("  namespaces:")
-	fmt.Println("    list                           - List all namespaces")
-	fmt.Println("    create <name>                  - Create a namespace")
-	fmt.Println("    get <name>                     - Get namespace details")
-	fmt.Println("    delete <name>                  - Delete a namespace")
-	fmt.Println()
+// parseVMPlacementArg adds a placement option of vms create to vm and reports whether arg is one: node:<label>=<value>,
+// affinity:<group>, anti-affinity:<group>, affinity-mode:preferred, tolerate:<key>[=<value>][:<effect>],
+// cpu:dedicated or hugepages:<2Mi|1Gi>
+func parseVMPlacementArg(vm *types.VM, arg string) (bool, error) {
+	option, value, found := strings.Cut(arg, ":")
+	if !found {
+		return false, nil
+	}
+	placement := vm.Placement
+	if placement == nil {
+		placement = &types.VMPlacement{}
+	}
+	switch option {
+	case "node":
+		label, labelValue, ok := strings.Cut(value, "=")
+		if !ok {
+			return false, fmt.Errorf("invalid node selector %s, must be node:<label>=<value>", arg)
+		}
+		if placement.NodeSelector == nil {
+			placement.NodeSelector = map[string]string{}
+		}
+		placement.NodeSelector[label] = labelValue
+	case "affinity":
+		placement.AffinityGroups = append(placement.AffinityGroups, value)
+	case "anti-affinity":
+		placement.AntiAffinityGroups = append(placement.AntiAffinityGroups, value)
+	case "affinity-mode":
+		if value != "preferred" && value != "required" {
+			return false, fmt.Errorf("invalid affinity mode %s, must be preferred or required", value)
+		}
+		placement.Preferred = value == "preferred"
+	case "tolerate":
+		taint, effect, _ := strings.Cut(value, ":")
+		key, taintValue, hasValue := strings.Cut(taint, "=")
+		toleration := types.VMToleration{Key: key, Operator: "Exists", Effect: effect}
+		if hasValue {
+			toleration.Operator, toleration.Value = "Equal", taintValue
+		}
+		placement.Tolerations = append(placement.Tolerations, toleration)
+	case "cpu":
+		if value != "dedicated" {
+			return false, fmt.Errorf("invalid cpu placement %s, must be cpu:dedicated", arg)
+		}
+		placement.DedicatedCPU = true
+	case "hugepages":
+		placement.Hugepages = value
+	default:
+		return false, nil
+	}
+	vm.Placement = placement
+	return true, nil
+}
 
-	fmt.Println("  clickhouse:")
-	fmt.Println("    list <namespace>               - List ClickHouse instances in namespace")
-	fmt.Println("    create <namespace> <name> <replicas> - Create a ClickHouse instance")
-	fmt.Println("    get <namespace> <name>         - Get ClickHouse instance details")
-	fmt.Println("    delete <namespace> <name>      - Delete a ClickHouse instance")
-	fmt.Println()
+func initDriftHandler() CommandHandler {
+	handler := NewBaseCommandHandler("drift")
 
-	fmt.Println("  postgres:")
-	fmt.Println("    list <namespace>               - List PostgreSQL instances in namespace")
+	handler.RegisterCommand("list", func(c *client.Client, args []string) error {
+		namespace := ""
+		if len(args) > 0 {
+			namespace = args[0]
+		}
+		report, err := c.GetDrift(namespace)
+		if err != nil {
+			return err
+		}
+		return printJSON(report)
+	})
+
+	handler.RegisterCommand("heal", func(c *client.Client, args []string) error {
+		report, err := c.HealDrift()
+		if err != nil {
+			return err
+		}
+		return printJSON(report)
+	})
+
+	return handler
+}
+
+// imageFormat guesses the format of an image file from its extension, qcow2 unless it is a raw or ISO file
+func imageFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".iso":
+		return types.ImageFormatISO
+	case ".raw", ".img":
+		return types.ImageFormatRaw
+	}
+	return types.ImageFormatQcow2
+}
+
+// optionalArg returns the argument at index i, empty when it is missing
+func optionalArg(args []string, i int) string {
+	if len(args) > i {
+		return args[i]
+	}
+	return ""
+}
+
+// validateSizeKind checks the kind argument of the sizes commands
+func validateSizeKind(kind string) error {
+	if kind != client.SizeKindVM && kind != client.SizeKindPostgres {
+		return fmt.Errorf("invalid size kind %s, must be %s or %s", kind, client.SizeKindVM, client.SizeKindPostgres)
+	}
+	return nil
+}
+
+func initSizeHandler() CommandHandler {
+	handler := NewBaseCommandHandler("sizes")
+
+	handler.RegisterCommand("list", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 1); err != nil {
+			return err
+		}
+		if err := validateSizeKind(args[0]); err != nil {
+			return err
+		}
+		if args[0] == client.SizeKindPostgres {
+			sizes, err := c.ListPostgresSizes()
+			if err != nil {
+				return err
+			}
+			for _, size := range sizes {
+				fmt.Printf("%s\t%d CPU\t%d MiB\tretired=%t\n", size.Name, size.CPU, size.RAM, size.Retired)
+			}
+			return nil
+		}
+		sizes, err := c.ListVMSizes()
+		if err != nil {
+			return err
+		}
+		for _, size := range sizes {
+			fmt.Printf("%s\t%d CPU\t%d MiB\t%d GiB\tretired=%t\n", size.Name, size.CPU, size.RAM, size.Disk, size.Retired)
+		}
+		return nil
+	})
+
+	handler.RegisterCommand("get", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		if err := validateSizeKind(args[0]); err != nil {
+			return err
+		}
+		if args[0] == client.SizeKindPostgres {
+			size, err := c.GetPostgresSize(args[1])
+			if err != nil {
+				return err
+			}
+			return printJSON(size)
+		}
+		size, err := c.GetVMSize(args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(size)
+	})
+
+	handler.RegisterCommand("set", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 4); err != nil {
+			return err
+		}
+		if err := validateSizeKind(args[0]); err != nil {
+			return err
+		}
+		cpu, err := strconv.Atoi(args[2])
+		if err != nil {
+			return fmt.Errorf("invalid cpu %s: %w", args[2], err)
+		}
+		ram, err := strconv.Atoi(args[3])
+		if err != nil {
+			return fmt.Errorf("invalid ram %s: %w", args[3], err)
+		}
+		if args[0] == client.SizeKindPostgres {
+			return c.PutPostgresSize(types.PostgresSize{Name: args[1], CPU: cpu, RAM: ram})
+		}
+		if err := validateArgs(args, 5); err != nil {
+			return err
+		}
+		disk, err := strconv.Atoi(args[4])
+		if err != nil {
+			return fmt.Errorf("invalid disk %s: %w", args[4], err)
+		}
+		return c.PutVMSize(types.VMSize{Name: args[1], CPU: cpu, RAM: ram, Disk: disk})
+	})
+
+	handler.RegisterCommand("retire", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		if err := validateSizeKind(args[0]); err != nil {
+			return err
+		}
+		if args[0] == client.SizeKindPostgres {
+			size, err := c.GetPostgresSize(args[1])
+			if err != nil {
+				return err
+			}
+			size.Retired = true
+			return c.PutPostgresSize(*size)
+		}
+		size, err := c.GetVMSize(args[1])
+		if err != nil {
+			return err
+		}
+		size.Retired = true
+		return c.PutVMSize(*size)
+	})
+
+	handler.RegisterCommand("delete", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		if err := validateSizeKind(args[0]); err != nil {
+			return err
+		}
+		if args[0] == client.SizeKindPostgres {
+			return c.DeletePostgresSize(args[1])
+		}
+		return c.DeleteVMSize(args[1])
+	})
+
+	return handler
+}
+
+func initScheduleHandler() CommandHandler {
+	handler := NewBaseCommandHandler("schedules")
+
+	handler.RegisterCommand("list", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 1); err != nil {
+			return err
+		}
+		schedules, err := c.ListSchedules(args[0])
+		if err != nil {
+			return err
+		}
+		for _, schedule := range schedules {
+			next := "-"
+			if schedule.NextRun != nil {
+				next = schedule.NextRun.Local().Format(time.RFC3339)
+			}
+			fmt.Printf("%s\t%s %s %s\t%s\tnext=%s\tlast=%s\n", schedule.Name, schedule.Action, schedule.Kind,
+				strings.Join(schedule.Targets, ","), schedule.Cron, next, schedule.LastResult)
+		}
+		return nil
+	})
+
+	handler.RegisterCommand("get", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		schedule, err := c.GetSchedule(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(schedule)
+	})
+
+	handler.RegisterCommand("set", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 6); err != nil {
+			return err
+		}
+		return c.PutSchedule(types.Schedule{
+			Namespace: args[0],
+			Name:      args[1],
+			Kind:      args[2],
+			Action:    args[3],
+			Cron:      args[4],
+			Targets:   strings.Split(args[5], ","),
+			Timezone:  optionalArg(args, 6),
+		})
+	})
+
+	handler.RegisterCommand("delete", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		return c.DeleteSchedule(args[0], args[1])
+	})
+
+	return handler
+}
+
+func initVMTemplateHandler() CommandHandler {
+	handler := NewBaseCommandHandler("templates")
+
+	handler.RegisterCommand("list", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 1); err != nil {
+			return err
+		}
+		templates, err := c.ListVMTemplates(args[0])
+		if err != nil {
+			return err
+		}
+		for _, template := range templates {
+			fmt.Printf("%s\t%s\t%s\t%s\n", template.Name, template.VM.Image, template.VM.Size, template.Description)
+		}
+		return nil
+	})
+
+	handler.RegisterCommand("get", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		template, err := c.GetVMTemplate(args[0], args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(template)
+	})
+
+	handler.RegisterCommand("set", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		data, err := os.ReadFile(args[2])
+		if err != nil {
+			return fmt.Errorf("failed to read template: %w", err)
+		}
+		var template types.VMTemplate
+		if err := yaml.Unmarshal(data, &template); err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", args[2], err)
+		}
+		template.Namespace, template.Name = args[0], args[1]
+		return c.PutVMTemplate(template)
+	})
+
+	handler.RegisterCommand("delete", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		return c.DeleteVMTemplate(args[0], args[1])
+	})
+
+	handler.RegisterCommand("create", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		count, err := parseInt(args[2])
+		if err != nil {
+			return err
+		}
+		batch := types.VMBatch{Count: count, NamePattern: optionalArg(args, 3)}
+		if concurrency := optionalArg(args, 4); concurrency != "" {
+			if batch.Concurrency, err = parseInt(concurrency); err != nil {
+				return err
+			}
+		}
+		return createVMBatch(c, args[0], args[1], batch)
+	})
+
+	handler.RegisterCommand("pool", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 3); err != nil {
+			return err
+		}
+		count, err := parseInt(args[2])
+		if err != nil {
+			return err
+		}
+		return createVMBatch(c, args[0], args[1], types.VMBatch{Count: count, NamePattern: optionalArg(args, 3), Pool: true})
+	})
+
+	handler.RegisterCommand("pools", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 1); err != nil {
+			return err
+		}
+		pools, err := c.ListVMPools(args[0])
+		if err != nil {
+			return err
+		}
+		for _, pool := range pools {
+			fmt.Printf("%s\t%s\t%d/%d\n", pool.Name, pool.Template, pool.ReadyReplicas, pool.Replicas)
+		}
+		return nil
+	})
+
+	handler.RegisterCommand("deletepool", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		return c.DeleteVMPool(args[0], args[1])
+	})
+
+	return handler
+}
+
+// createVMBatch creates VMs from a template and prints the result of every VM, failing when any of them failed
+func createVMBatch(c *client.Client, namespace, template string, batch types.VMBatch) error {
+	result, err := c.CreateVMBatch(namespace, template, batch)
+	if err != nil {
+		return err
+	}
+	for _, vm := range result.VMs {
+		fmt.Printf("%s\t%s\t%s\n", vm.Name, vm.Status, vm.Error)
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d of %d VMs failed", result.Failed, len(result.VMs))
+	}
+	return nil
+}
+
+func initImageHandler() CommandHandler {
+	handler := NewBaseCommandHandler("images")
+
+	handler.RegisterCommand("list", func(c *client.Client, args []string) error {
+		images, err := c.ListImages(optionalArg(args, 0))
+		if err != nil {
+			return err
+		}
+		for _, image := range images {
+			namespace := image.Namespace
+			if namespace == "" {
+				namespace = "public"
+			}
+			fmt.Printf("%s\t%s\t%s\t%s\n", image.Name, namespace, image.Phase, image.OSVersion)
+		}
+		return nil
+	})
+
+	handler.RegisterCommand("get", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 1); err != nil {
+			return err
+		}
+		image, err := c.GetImage(args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(image)
+	})
+
+	handler.RegisterCommand("add", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		if err := validateResourceName(args[0]); err != nil {
+			return err
+		}
+		image := types.VMImage{Name: args[0]}
+		rest, err := parseImageArgs(&image, args[2:])
+		if err != nil {
+			return err
+		}
+		image.Namespace, image.Checksum = optionalArg(rest, 0), optionalArg(rest, 1)
+		if strings.HasPrefix(args[1], "http://") || strings.HasPrefix(args[1], "https://") {
+			image.URL, image.Format = args[1], imageFormat(args[1])
+		} else {
+			image.Image = args[1]
+		}
+		created, err := c.CreateImage(image)
+		if err != nil {
+			return err
+		}
+		return printJSON(created)
+	})
+
+	handler.RegisterCommand("upload", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		if err := validateResourceName(args[0]); err != nil {
+			return err
+		}
+		image := types.VMImage{Name: args[0], Format: imageFormat(args[1])}
+		rest, err := parseImageArgs(&image, args[2:])
+		if err != nil {
+			return err
+		}
+		image.Namespace, image.Checksum = optionalArg(rest, 0), optionalArg(rest, 1)
+		uploaded, err := c.UploadImage(image, args[1])
+		if err != nil {
+			return err
+		}
+		return printJSON(uploaded)
+	})
+
+	handler.RegisterCommand("delete", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 1); err != nil {
+			return err
+		}
+		return c.DeleteImage(args[0])
+	})
+
+	return handler
+}
+
+// parseImageArgs sets the OS and device options of images add and upload on image and returns the other arguments:
+// os:<os>, firmware:<bios|uefi|secureboot>, tpm, disk-bus:<virtio|sata>, nic:<virtio|e1000e> and
+// provisioning:<cloud-init|cloudbase-init|sysprep|none>
+func parseImageArgs(image *types.VMImage, args []string) ([]string, error) {
+	var rest []string
+	var devices types.VMDevices
+	for _, arg := range args {
+		option, value, _ := strings.Cut(arg, ":")
+		switch option {
+		case "os":
+			image.OS = value
+		case "firmware":
+			devices.Firmware = value
+		case "tpm":
+			devices.TPM = true
+		case "disk-bus":
+			devices.DiskBus = value
+		case "nic":
+			devices.InterfaceModel = value
+		case "provisioning":
+			devices.Provisioning = value
+		default:
+			rest = append(rest, arg)
+			continue
+		}
+		if value == "" && option != "tpm" {
+			return nil, fmt.Errorf("invalid image option %s, must be %s:<value>", arg, option)
+		}
+	}
+	if devices != (types.VMDevices{}) {
+		image.Devices = &devices
+	}
+	return rest, nil
+}
+
+func initSSHKeyHandler() CommandHandler {
+	handler := NewBaseCommandHandler("sshkeys")
+
+	handler.RegisterCommand("list", func(c *client.Client, args []string) error {
+		keys, err := c.ListSSHKeys()
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			fmt.Printf("%s\t%s\n", key.Name, key.Fingerprint)
+		}
+		return nil
+	})
+
+	handler.RegisterCommand("add", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 2); err != nil {
+			return err
+		}
+		if err := validateResourceName(args[0]); err != nil {
+			return err
+		}
+		// the key is read from a file when the argument is a path
+		publicKey := strings.Join(args[1:], " ")
+		if data, err := os.ReadFile(args[1]); err == nil {
+			publicKey = string(data)
+		}
+		return c.AddSSHKey(args[0], strings.TrimSpace(publicKey))
+	})
+
+	handler.RegisterCommand("get", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 1); err != nil {
+			return err
+		}
+		key, err := c.GetSSHKey(args[0])
+		if err != nil {
+			return err
+		}
+		return printJSON(key)
+	})
+
+	handler.RegisterCommand("delete", func(c *client.Client, args []string) error {
+		if err := validateArgs(args, 1); err != nil {
+			return err
+		}
+		return c.DeleteSSHKey(args[0])
+	})
+
+	return handler
+}
+
+// printHelp displays the help information with all available actions
+func printHelp() {
+	fmt.Println("govnocloud2 client - Command-line interface for managing GovnoCloud resources")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  govnocloud2 client [resource] [action] [args...]")
+	fmt.Println()
+	fmt.Println("Available Resources and Actions:")
+	fmt.Println()
+
+	fmt.Println("  nodes:")
+	fmt.Println("    list                           - List all nodes")
+	fmt.Println("    get <name>                     - Get node details")
+	fmt.Println("    add <name> <ip> <mac> <cpu> <mem> - Add a new node")
+	fmt.Println("    delete <name>                  - Delete a node")
+	fmt.Println("    restart <name>                 - Restart a node")
+	fmt.Println()
+
+	fmt.Println("  vms:")
+	fmt.Println("    list <namespace>               - List VMs in namespace")
+	fmt.Println("    create <name> <image> <size> <namespace> [sshkey...] - Create a new VM authorizing registered SSH keys")
+	fmt.Println("           [<net>=<pod|bridge|nad>[,<address/prefix>[,<gateway>[,<dns>...]]]...] - and attaching it to networks")
+	fmt.Println("           [node:<label>=<value>] [affinity:<group>] [anti-affinity:<group>] [affinity-mode:preferred]")
+	fmt.Println("           [tolerate:<key>[=<value>][:<effect>]] [cpu:dedicated] [hugepages:<2Mi|1Gi>] - and placing it")
+	fmt.Println("           [sysprep:<unattend.xml>] - and answering Windows setup of sysprep images")
+	fmt.Println("    get <namespace> <name>         - Get VM details")
+	fmt.Println("    delete <name> <namespace> [retain-disk] - Delete a VM, optionally keeping its root disk")
+	fmt.Println("    start <namespace> <name>       - Start a VM")
+	fmt.Println("    stop <namespace> <name>        - Stop a VM")
+	fmt.Println("    restart <namespace> <name>     - Restart a VM")
+	fmt.Println("    wait <namespace> <name>        - Wait for VM to be ready")
+	fmt.Println("    attach <name> <namespace> <volume> - Attach a volume to a VM")
+	fmt.Println("    detach <name> <namespace> <volume> - Detach a volume from a VM")
+	fmt.Println("    migrate <name> <namespace> [node] - Live-migrate a running VM, to a node when given")
+	fmt.Println("    migrations <name> <namespace>  - List the migrations of a VM with their progress")
+	fmt.Println("    metrics <name> <namespace> [range] - Show the latest and peak CPU, memory, disk and network usage, 1h by default")
+	fmt.Println("    clone <name> <namespace> <target> - Create the VM target from a copy of a VM")
+	fmt.Println("    image <name> <namespace> <image> - Export the root disk of a VM as an image")
+	fmt.Println("    export <name> <namespace> <file> [tar|qcow2] - Download a stopped VM as an archive or its root disk as qcow2")
+	fmt.Println("    import <name> <namespace> <file> - Create a VM from an export archive")
+	fmt.Println("    snapshot <name> <namespace> <snapshot> - Snapshot a VM and its disks")
+	fmt.Println("    snapshots <name> <namespace>   - List the snapshots of a VM")
+	fmt.Println("    deletesnapshot <name> <namespace> <snapshot> - Delete a snapshot of a VM")
+	fmt.Println("    restore <name> <namespace> <snapshot> - Restore a stopped VM from a snapshot")
+	fmt.Println("    console <name> <namespace>     - Attach the terminal to the VM serial console, Ctrl+] detaches")
+	fmt.Println("    ports <name> <namespace>       - Show VM ports and their endpoints")
+	fmt.Println("    resize <name> <namespace> <size>|<cpu> <ram> - Resize a VM to a size or to custom CPU and RAM in MiB")
+	fmt.Println("    run <name> <namespace> <Always|RerunOnFailure|Manual|Halted> [idle-timeout] - Set the run strategy, stop after being idle e.g. 4h")
+	fmt.Println("    expose <name> <namespace> <NodePort|LoadBalancer> [port[:lbport][/udp]...] - Replace VM ports")
+	fmt.Println()
+
+	fmt.Println("  containers:")
+	fmt.Println("    list <namespace>               - List containers in namespace")
+	fmt.Println("    create <namespace> <name> <image> <cpu> <ram> <disk> <port> - Create a container")
+	fmt.Println("    get <namespace> <name>         - Get container details")
+	fmt.Println("    delete <namespace> <name>      - Delete a container")
+	fmt.Println("    start|stop|restart <name> <namespace> - Start, stop or restart a container")
+	fmt.Println()
+
+	fmt.Println("  volumes:")
+	fmt.Println("    list <namespace>               - List volumes in namespace")
+	fmt.Println("    create <namespace> <name> <size> - Create a volume")
+	fmt.Println("    get <namespace> <name>         - Get volume details")
+	fmt.Println("    delete <namespace> <name>      - Delete a volume")
+	fmt.Println()
+
+	fmt.Println("  namespaces:")
+	fmt.Println("    list                           - List all namespaces")
+	fmt.Println("    create <name>                  - Create a namespace")
+	fmt.Println("    get <name>                     - Get namespace details")
+	fmt.Println("    delete <name>                  - Delete a namespace")
+	fmt.Println()
+
+	fmt.Println("  clickhouse:")
+	fmt.Println("    list <namespace>               - List ClickHouse instances in namespace")
+	fmt.Println("    create <namespace> <name> <replicas> - Create a ClickHouse instance")
+	fmt.Println("    get <namespace> <name>         - Get ClickHouse instance details")
+	fmt.Println("    delete <namespace> <name>      - Delete a ClickHouse instance")
+	fmt.Println()
+
+	fmt.Println("  postgres:")
+	fmt.Println("    list <namespace>               - List PostgreSQL instances in namespace")
 	fmt.Println("    create <namespace> <name> <version> <instances> <routers> - Create a PostgreSQL instance")
 	fmt.Println("    get <namespace> <name>         - Get PostgreSQL instance details")
 	fmt.Println("    delete <namespace> <name>      - Delete a PostgreSQL instance")
@@ -844,6 +1835,51 @@ func printHelp() {
 	fmt.Println("    removenamespace <name> <namespace> - Remove namespace from user")
 	fmt.Println()
 
+	fmt.Println("  images:")
+	fmt.Println("    list [namespace]               - List images, only those usable in a namespace when given")
+	fmt.Println("    get <name>                     - Get image details and import phase")
+	fmt.Println("    add <name> <containerdisk|url> [namespace] [checksum] - Register an image, public without namespace")
+	fmt.Println("    upload <name> <file> [namespace] [checksum] - Upload a qcow2, raw or ISO file as an image")
+	fmt.Println("           [os:<os>] [firmware:<bios|uefi|secureboot>] [tpm] [disk-bus:<virtio|sata>] [nic:<virtio|e1000e>]")
+	fmt.Println("           [provisioning:<cloud-init|cloudbase-init|sysprep|none>] - Device settings of add and upload")
+	fmt.Println("    delete <name>                  - Delete an image with its disk")
+	fmt.Println()
+	fmt.Println("  sizes:")
+	fmt.Println("    list <vms|postgres>            - List VM or postgres sizes")
+	fmt.Println("    get <vms|postgres> <name>      - Get size details")
+	fmt.Println("    set vms <name> <cpu> <ram> <disk> - Define or edit a VM size, RAM in MiB and disk in GiB (admin)")
+	fmt.Println("    set postgres <name> <cpu> <ram> - Define or edit a postgres size, RAM in MiB (admin)")
+	fmt.Println("    retire <vms|postgres> <name>   - Retire a size, VMs and databases using it keep it (admin)")
+	fmt.Println("    delete <vms|postgres> <name>   - Delete a size nothing uses (admin)")
+	fmt.Println()
+	fmt.Println("  schedules:")
+	fmt.Println("    list <namespace>               - List schedules with their next and last runs")
+	fmt.Println("    get <namespace> <name>         - Get schedule details")
+	fmt.Println("    set <namespace> <name> <vm|container> <start|stop|restart> <cron> <target,...|*> [timezone] - Create or replace a schedule")
+	fmt.Println("    delete <namespace> <name>      - Delete a schedule")
+	fmt.Println()
+	fmt.Println("  templates:")
+	fmt.Println("    list <namespace>               - List VM templates")
+	fmt.Println("    get <namespace> <name>         - Get template details")
+	fmt.Println("    set <namespace> <name> <file>  - Create or replace a template from a YAML or JSON file")
+	fmt.Println("    delete <namespace> <name>      - Delete a template, its VMs stay")
+	fmt.Println("    create <namespace> <template> <count> [pattern] [concurrency] - Create VMs named by pattern, {n} is the index")
+	fmt.Println("    pool <namespace> <template> <count> [pool] - Create the VMs as a KubeVirt VirtualMachinePool")
+	fmt.Println("    pools <namespace>              - List VM pools with their ready VMs")
+	fmt.Println("    deletepool <namespace> <pool>  - Delete a pool with its VMs")
+	fmt.Println()
+	fmt.Println("  sshkeys:")
+	fmt.Println("    list                           - List your SSH keys")
+	fmt.Println("    add <name> <keyfile|key>       - Add an SSH public key")
+	fmt.Println("    get <name>                     - Get SSH key details")
+	fmt.Println("    delete <name>                  - Delete an SSH key")
+	fmt.Println()
+
+	fmt.Println("  drift:")
+	fmt.Println("    list [namespace]               - Show the last drift report")
+	fmt.Println("    heal                           - Check for drift now and heal it")
+	fmt.Println()
+
 	fmt.Println("  Other Commands:")
 	fmt.Println("    version                        - Get server version")
 	fmt.Println("    help                           - Show this help message")
@@ -52,7 +52,7 @@ var installCmd = &cobra.Command{
 			cfg.Install.Master.Host,
 			cfg.Install.SSH.User,
 			cfg.Install.SSH.KeyPath,
-			"sshpass wakeonlan dnsmasq",
+			"sshpass wakeonlan dnsmasq qemu-utils",
 		)
 		if err != nil {
 			log.Println(out)
@@ -98,6 +98,7 @@ var installCmd = &cobra.Command{
 			cfg.Install.SSH.Password,
 			cfg.Install.SSH.KeyPath,
 			cfg.Web.Path,
+			cfg.Install.ImagesDir,
 		)
 		if err != nil {
 			panic(err)
@@ -224,6 +225,21 @@ var installCmd = &cobra.Command{
 			panic(err)
 		}
 
+		if cfg.Install.Multus.Enabled {
+			log.Println("Installing Multus")
+			err = k8s.InstallMultus(
+				cfg.Install.Master.Host,
+				workersIPsSplit,
+				cfg.Install.SSH.User,
+				cfg.Install.SSH.KeyPath,
+				cfg.Install.Workers.Interface,
+				cfg.Install.Multus.Bridge,
+			)
+			if err != nil {
+				panic(err)
+			}
+		}
+
 		log.Println("Installing Longhorn")
 		err = k8s.InstallLonghorn(
 			cfg.Install.Master.Host,
@@ -238,6 +254,28 @@ var installCmd = &cobra.Command{
 			panic(err)
 		}
 
+		log.Println("Installing volume snapshots")
+		err = k8s.InstallVolumeSnapshots(
+			cfg.Install.Master.Host,
+			cfg.Install.SSH.User,
+			cfg.Install.SSH.KeyPath,
+			"v8.2.0",
+		)
+		if err != nil {
+			panic(err)
+		}
+
+		log.Println("Installing CDI")
+		err = k8s.InstallCDI(
+			cfg.Install.Master.Host,
+			cfg.Install.SSH.User,
+			cfg.Install.SSH.KeyPath,
+			"v1.61.0",
+		)
+		if err != nil {
+			panic(err)
+		}
+
 		log.Println("Installing Clickhouse")
 		err = k8s.InstallClickhouse(
 			cfg.Install.Master.Host,
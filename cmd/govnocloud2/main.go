@@ -73,6 +73,8 @@ func setupInstallFlags(cmd *cobra.Command) {
 	flags.BoolVarP(&cfg.Install.Nat.Enabled, "nat", "", cfg.Install.Nat.Enabled, "enable nat")
 	flags.StringVarP(&cfg.Install.Nat.ExternalInterface, "nat-external-interface", "", cfg.Install.Nat.ExternalInterface, "external interface")
 	flags.StringVarP(&cfg.Install.Nat.InternalInterface, "nat-internal-interface", "", cfg.Install.Nat.InternalInterface, "internal interface")
+	flags.BoolVarP(&cfg.Install.Multus.Enabled, "multus", "", cfg.Install.Multus.Enabled, "install multus and bridge the worker interface for VM LAN access")
+	flags.StringVarP(&cfg.Install.Multus.Bridge, "multus-bridge", "", cfg.Install.Multus.Bridge, "name of the bridge created on the worker interface")
 	flags.StringVarP(&cfg.Install.Web.Host, "web-host", "", cfg.Install.Web.Host, "web host")
 	flags.StringVarP(&cfg.Install.Web.Port, "web-port", "", cfg.Install.Web.Port, "web port")
 	flags.StringVarP(&cfg.Install.Web.Path, "web-path", "", cfg.Install.Web.Path, "web path")
@@ -91,11 +93,18 @@ func setupServerFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 	flags.StringVarP(&cfg.Server.Host, "host", "", cfg.Server.Host, "listen host")
 	flags.StringVarP(&cfg.Server.Port, "port", "", cfg.Server.Port, "listen port")
+	flags.StringVarP(&cfg.Server.GRPCPort, "grpcport", "", cfg.Server.GRPCPort, "grpc listen port, empty to disable")
 	flags.StringVarP(&cfg.Server.SSHUser, "user", "", cfg.Server.SSHUser, "ssh user")
 	flags.StringVarP(&cfg.Server.SSHPassword, "password", "", cfg.Server.SSHPassword, "ssh password")
 	flags.StringVarP(&cfg.Server.Key, "key", "", cfg.Server.Key, "ssh key")
 	flags.StringVarP(&cfg.Server.MasterHost, "master", "", cfg.Server.MasterHost, "master host")
 	flags.StringVarP(&cfg.Server.RootPassword, "rootpassword", "", cfg.Server.RootPassword, "root password")
+	flags.StringVarP(&cfg.Server.LogLevel, "loglevel", "", cfg.Server.LogLevel, "log level (debug, info, warn, error)")
+	flags.StringVarP(&cfg.Server.ImagesDir, "imagesdir", "", cfg.Server.ImagesDir, "directory uploaded VM images are kept in")
+	flags.StringVarP(&cfg.Server.MaxImageSize, "maximagesize", "", cfg.Server.MaxImageSize, "largest image file uploaded or downloaded")
+	flags.StringVarP(&cfg.Server.GatewayPort, "sshgatewayport", "", cfg.Server.GatewayPort, "ssh gateway listen port, empty to disable")
+	flags.StringVarP(&cfg.Server.GatewayHostKey, "sshgatewayhostkey", "", cfg.Server.GatewayHostKey, "ssh gateway host key, generated when missing")
+	flags.BoolVarP(&cfg.Server.AutoHeal, "autoheal", "", cfg.Server.AutoHeal, "re-apply resources that drifted from their stored spec")
 }
 
 func setupClientFlags(cmd *cobra.Command) {
@@ -2,7 +2,9 @@ package main
 
 import (
 	"log"
+	"log/slog"
 
+	"github.com/rusik69/govnocloud2/pkg/logging"
 	"github.com/rusik69/govnocloud2/pkg/server"
 	"github.com/spf13/cobra"
 )
@@ -13,12 +15,16 @@ var serverCmd = &cobra.Command{
 	Short: "start govnocloud2 server",
 	Long:  `start govnocloud2 server`,
 	Run: func(cmd *cobra.Command, args []string) {
-		log.Println("listenHost: ", cfg.Server.Host)
-		log.Println("listenPort: ", cfg.Server.Port)
-		log.Println("masterHost: ", cfg.Server.MasterHost)
-		log.Println("user: ", cfg.Server.SSHUser)
-		log.Println("password: ", cfg.Server.SSHPassword)
-		log.Println("key: ", cfg.Server.Key)
+		if err := logging.Setup(cfg.Server.LogLevel); err != nil {
+			log.Fatal(err)
+		}
+		slog.Info("server config",
+			"listen_host", cfg.Server.Host,
+			"listen_port", cfg.Server.Port,
+			"master_host", cfg.Server.MasterHost,
+			"user", cfg.Server.SSHUser,
+			"key", cfg.Server.Key,
+		)
 
 		server.Serve(cfg.Server)
 	},
@@ -0,0 +1,3478 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: pkg/api/govnocloud.proto
+
+package api
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// EventType is the kind of change reported by a watch.
+type EventType int32
+
+const (
+	EventType_EVENT_TYPE_UNSPECIFIED EventType = 0
+	// ADDED is sent for every existing resource when a watch starts and for resources created later.
+	EventType_ADDED    EventType = 1
+	EventType_MODIFIED EventType = 2
+	EventType_DELETED  EventType = 3
+)
+
+// Enum value maps for EventType.
+var (
+	EventType_name = map[int32]string{
+		0: "EVENT_TYPE_UNSPECIFIED",
+		1: "ADDED",
+		2: "MODIFIED",
+		3: "DELETED",
+	}
+	EventType_value = map[string]int32{
+		"EVENT_TYPE_UNSPECIFIED": 0,
+		"ADDED":                  1,
+		"MODIFIED":               2,
+		"DELETED":                3,
+	}
+)
+
+func (x EventType) Enum() *EventType {
+	p := new(EventType)
+	*p = x
+	return p
+}
+
+func (x EventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_pkg_api_govnocloud_proto_enumTypes[0].Descriptor()
+}
+
+func (EventType) Type() protoreflect.EnumType {
+	return &file_pkg_api_govnocloud_proto_enumTypes[0]
+}
+
+func (x EventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EventType.Descriptor instead.
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{0}
+}
+
+// Empty is returned by calls that have nothing to report besides success.
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{0}
+}
+
+// NamespaceRequest selects all resources of a kind in a namespace.
+type NamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceRequest) Reset() {
+	*x = NamespaceRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceRequest) ProtoMessage() {}
+
+func (x *NamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceRequest.ProtoReflect.Descriptor instead.
+func (*NamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *NamespaceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+// ResourceRequest selects a single namespaced resource.
+type ResourceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResourceRequest) Reset() {
+	*x = ResourceRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResourceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResourceRequest) ProtoMessage() {}
+
+func (x *ResourceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResourceRequest.ProtoReflect.Descriptor instead.
+func (*ResourceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ResourceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ResourceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// NameRequest selects a single cluster scoped resource.
+type NameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NameRequest) Reset() {
+	*x = NameRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameRequest) ProtoMessage() {}
+
+func (x *NameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameRequest.ProtoReflect.Descriptor instead.
+func (*NameRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *NameRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// ListRequest lists cluster scoped resources.
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{4}
+}
+
+// VMPort is a virtual machine port.
+type VMPort struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	SourcePort      int32                  `protobuf:"varint,2,opt,name=source_port,json=sourcePort,proto3" json:"source_port,omitempty"`
+	DestinationPort int32                  `protobuf:"varint,3,opt,name=destination_port,json=destinationPort,proto3" json:"destination_port,omitempty"`
+	Protocol        string                 `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	// Allocated node port and LB endpoint, only set in responses.
+	NodePort      int32  `protobuf:"varint,5,opt,name=node_port,json=nodePort,proto3" json:"node_port,omitempty"`
+	Endpoint      string `protobuf:"bytes,6,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VMPort) Reset() {
+	*x = VMPort{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VMPort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMPort) ProtoMessage() {}
+
+func (x *VMPort) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMPort.ProtoReflect.Descriptor instead.
+func (*VMPort) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *VMPort) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VMPort) GetSourcePort() int32 {
+	if x != nil {
+		return x.SourcePort
+	}
+	return 0
+}
+
+func (x *VMPort) GetDestinationPort() int32 {
+	if x != nil {
+		return x.DestinationPort
+	}
+	return 0
+}
+
+func (x *VMPort) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *VMPort) GetNodePort() int32 {
+	if x != nil {
+		return x.NodePort
+	}
+	return 0
+}
+
+func (x *VMPort) GetEndpoint() string {
+	if x != nil {
+		return x.Endpoint
+	}
+	return ""
+}
+
+// VM is a virtual machine.
+type VM struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Image     string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Size      string                 `protobuf:"bytes,4,opt,name=size,proto3" json:"size,omitempty"`
+	Disk      string                 `protobuf:"bytes,5,opt,name=disk,proto3" json:"disk,omitempty"`
+	Status    string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Ports     []*VMPort              `protobuf:"bytes,7,rep,name=ports,proto3" json:"ports,omitempty"`
+	// Cloud-init settings, only read on create.
+	User            string   `protobuf:"bytes,8,opt,name=user,proto3" json:"user,omitempty"`
+	SshKeys         []string `protobuf:"bytes,9,rep,name=ssh_keys,json=sshKeys,proto3" json:"ssh_keys,omitempty"`
+	SshKeyNames     []string `protobuf:"bytes,10,rep,name=ssh_key_names,json=sshKeyNames,proto3" json:"ssh_key_names,omitempty"`
+	UserData        string   `protobuf:"bytes,11,opt,name=user_data,json=userData,proto3" json:"user_data,omitempty"`
+	NetworkData     string   `protobuf:"bytes,12,opt,name=network_data,json=networkData,proto3" json:"network_data,omitempty"`
+	CloudInitSecret string   `protobuf:"bytes,13,opt,name=cloud_init_secret,json=cloudInitSecret,proto3" json:"cloud_init_secret,omitempty"`
+	ServiceType     string   `protobuf:"bytes,14,opt,name=service_type,json=serviceType,proto3" json:"service_type,omitempty"`
+	// Attached disks, only set in responses.
+	Volumes []*VMVolume `protobuf:"bytes,15,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	// Always, RerunOnFailure, Manual or Halted, Always by default.
+	RunStrategy string `protobuf:"bytes,16,opt,name=run_strategy,json=runStrategy,proto3" json:"run_strategy,omitempty"`
+	// Stops the running VM after it was idle this long, e.g. 4h.
+	IdleTimeout string `protobuf:"bytes,17,opt,name=idle_timeout,json=idleTimeout,proto3" json:"idle_timeout,omitempty"`
+	// Scheduling and CPU and memory backing, the effective placement in responses.
+	Placement *VMPlacement `protobuf:"bytes,18,opt,name=placement,proto3" json:"placement,omitempty"`
+	// Network interfaces, the pod network alone by default.
+	Networks []*VMNetwork `protobuf:"bytes,19,rep,name=networks,proto3" json:"networks,omitempty"`
+	// unattend.xml answer file of images provisioned with sysprep, only read on create.
+	Sysprep       string `protobuf:"bytes,20,opt,name=sysprep,proto3" json:"sysprep,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VM) Reset() {
+	*x = VM{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VM) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VM) ProtoMessage() {}
+
+func (x *VM) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VM.ProtoReflect.Descriptor instead.
+func (*VM) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *VM) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VM) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *VM) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *VM) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *VM) GetDisk() string {
+	if x != nil {
+		return x.Disk
+	}
+	return ""
+}
+
+func (x *VM) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *VM) GetPorts() []*VMPort {
+	if x != nil {
+		return x.Ports
+	}
+	return nil
+}
+
+func (x *VM) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *VM) GetSshKeys() []string {
+	if x != nil {
+		return x.SshKeys
+	}
+	return nil
+}
+
+func (x *VM) GetSshKeyNames() []string {
+	if x != nil {
+		return x.SshKeyNames
+	}
+	return nil
+}
+
+func (x *VM) GetUserData() string {
+	if x != nil {
+		return x.UserData
+	}
+	return ""
+}
+
+func (x *VM) GetNetworkData() string {
+	if x != nil {
+		return x.NetworkData
+	}
+	return ""
+}
+
+func (x *VM) GetCloudInitSecret() string {
+	if x != nil {
+		return x.CloudInitSecret
+	}
+	return ""
+}
+
+func (x *VM) GetServiceType() string {
+	if x != nil {
+		return x.ServiceType
+	}
+	return ""
+}
+
+func (x *VM) GetVolumes() []*VMVolume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+func (x *VM) GetRunStrategy() string {
+	if x != nil {
+		return x.RunStrategy
+	}
+	return ""
+}
+
+func (x *VM) GetIdleTimeout() string {
+	if x != nil {
+		return x.IdleTimeout
+	}
+	return ""
+}
+
+func (x *VM) GetPlacement() *VMPlacement {
+	if x != nil {
+		return x.Placement
+	}
+	return nil
+}
+
+func (x *VM) GetNetworks() []*VMNetwork {
+	if x != nil {
+		return x.Networks
+	}
+	return nil
+}
+
+func (x *VM) GetSysprep() string {
+	if x != nil {
+		return x.Sysprep
+	}
+	return ""
+}
+
+// VMNetwork is a network interface of a virtual machine.
+type VMNetwork struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// pod, bridge or nad.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// NetworkAttachmentDefinition of a nad network in the VM's namespace.
+	Nad string `protobuf:"bytes,3,opt,name=nad,proto3" json:"nad,omitempty"`
+	// Generated when empty.
+	Mac string `protobuf:"bytes,4,opt,name=mac,proto3" json:"mac,omitempty"`
+	// dhcp or static, dhcp by default.
+	Addressing string `protobuf:"bytes,5,opt,name=addressing,proto3" json:"addressing,omitempty"`
+	// Static address in CIDR notation, gateway and nameservers.
+	Address       string   `protobuf:"bytes,6,opt,name=address,proto3" json:"address,omitempty"`
+	Gateway       string   `protobuf:"bytes,7,opt,name=gateway,proto3" json:"gateway,omitempty"`
+	Dns           []string `protobuf:"bytes,8,rep,name=dns,proto3" json:"dns,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VMNetwork) Reset() {
+	*x = VMNetwork{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VMNetwork) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMNetwork) ProtoMessage() {}
+
+func (x *VMNetwork) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMNetwork.ProtoReflect.Descriptor instead.
+func (*VMNetwork) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *VMNetwork) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VMNetwork) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *VMNetwork) GetNad() string {
+	if x != nil {
+		return x.Nad
+	}
+	return ""
+}
+
+func (x *VMNetwork) GetMac() string {
+	if x != nil {
+		return x.Mac
+	}
+	return ""
+}
+
+func (x *VMNetwork) GetAddressing() string {
+	if x != nil {
+		return x.Addressing
+	}
+	return ""
+}
+
+func (x *VMNetwork) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *VMNetwork) GetGateway() string {
+	if x != nil {
+		return x.Gateway
+	}
+	return ""
+}
+
+func (x *VMNetwork) GetDns() []string {
+	if x != nil {
+		return x.Dns
+	}
+	return nil
+}
+
+// VMPlacement controls where a virtual machine is scheduled and how its CPUs and memory are backed.
+type VMPlacement struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	NodeSelector       []*NodeLabel           `protobuf:"bytes,1,rep,name=node_selector,json=nodeSelector,proto3" json:"node_selector,omitempty"`
+	AffinityGroups     []string               `protobuf:"bytes,2,rep,name=affinity_groups,json=affinityGroups,proto3" json:"affinity_groups,omitempty"`
+	AntiAffinityGroups []string               `protobuf:"bytes,3,rep,name=anti_affinity_groups,json=antiAffinityGroups,proto3" json:"anti_affinity_groups,omitempty"`
+	Preferred          bool                   `protobuf:"varint,4,opt,name=preferred,proto3" json:"preferred,omitempty"`
+	Tolerations        []*VMToleration        `protobuf:"bytes,5,rep,name=tolerations,proto3" json:"tolerations,omitempty"`
+	DedicatedCpu       bool                   `protobuf:"varint,6,opt,name=dedicated_cpu,json=dedicatedCpu,proto3" json:"dedicated_cpu,omitempty"`
+	// 2Mi or 1Gi.
+	Hugepages     string `protobuf:"bytes,7,opt,name=hugepages,proto3" json:"hugepages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VMPlacement) Reset() {
+	*x = VMPlacement{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VMPlacement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMPlacement) ProtoMessage() {}
+
+func (x *VMPlacement) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMPlacement.ProtoReflect.Descriptor instead.
+func (*VMPlacement) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *VMPlacement) GetNodeSelector() []*NodeLabel {
+	if x != nil {
+		return x.NodeSelector
+	}
+	return nil
+}
+
+func (x *VMPlacement) GetAffinityGroups() []string {
+	if x != nil {
+		return x.AffinityGroups
+	}
+	return nil
+}
+
+func (x *VMPlacement) GetAntiAffinityGroups() []string {
+	if x != nil {
+		return x.AntiAffinityGroups
+	}
+	return nil
+}
+
+func (x *VMPlacement) GetPreferred() bool {
+	if x != nil {
+		return x.Preferred
+	}
+	return false
+}
+
+func (x *VMPlacement) GetTolerations() []*VMToleration {
+	if x != nil {
+		return x.Tolerations
+	}
+	return nil
+}
+
+func (x *VMPlacement) GetDedicatedCpu() bool {
+	if x != nil {
+		return x.DedicatedCpu
+	}
+	return false
+}
+
+func (x *VMPlacement) GetHugepages() string {
+	if x != nil {
+		return x.Hugepages
+	}
+	return ""
+}
+
+// NodeLabel is a node label a virtual machine is only scheduled on nodes with.
+type NodeLabel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeLabel) Reset() {
+	*x = NodeLabel{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeLabel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeLabel) ProtoMessage() {}
+
+func (x *NodeLabel) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeLabel.ProtoReflect.Descriptor instead.
+func (*NodeLabel) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *NodeLabel) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *NodeLabel) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// VMToleration is a toleration of a node taint.
+type VMToleration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Operator      string                 `protobuf:"bytes,2,opt,name=operator,proto3" json:"operator,omitempty"`
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Effect        string                 `protobuf:"bytes,4,opt,name=effect,proto3" json:"effect,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VMToleration) Reset() {
+	*x = VMToleration{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VMToleration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMToleration) ProtoMessage() {}
+
+func (x *VMToleration) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMToleration.ProtoReflect.Descriptor instead.
+func (*VMToleration) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *VMToleration) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *VMToleration) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *VMToleration) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *VMToleration) GetEffect() string {
+	if x != nil {
+		return x.Effect
+	}
+	return ""
+}
+
+// VMVolume is a disk attached to a virtual machine.
+type VMVolume struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Claim         string                 `protobuf:"bytes,2,opt,name=claim,proto3" json:"claim,omitempty"`
+	Serial        string                 `protobuf:"bytes,3,opt,name=serial,proto3" json:"serial,omitempty"`
+	Bus           string                 `protobuf:"bytes,4,opt,name=bus,proto3" json:"bus,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VMVolume) Reset() {
+	*x = VMVolume{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VMVolume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMVolume) ProtoMessage() {}
+
+func (x *VMVolume) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMVolume.ProtoReflect.Descriptor instead.
+func (*VMVolume) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *VMVolume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VMVolume) GetClaim() string {
+	if x != nil {
+		return x.Claim
+	}
+	return ""
+}
+
+func (x *VMVolume) GetSerial() string {
+	if x != nil {
+		return x.Serial
+	}
+	return ""
+}
+
+func (x *VMVolume) GetBus() string {
+	if x != nil {
+		return x.Bus
+	}
+	return ""
+}
+
+// VMVolumeRequest names a volume of a virtual machine.
+type VMVolumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Volume        string                 `protobuf:"bytes,3,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VMVolumeRequest) Reset() {
+	*x = VMVolumeRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VMVolumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMVolumeRequest) ProtoMessage() {}
+
+func (x *VMVolumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMVolumeRequest.ProtoReflect.Descriptor instead.
+func (*VMVolumeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VMVolumeRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *VMVolumeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *VMVolumeRequest) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+type ListVMsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Vms           []*VM                  `protobuf:"bytes,1,rep,name=vms,proto3" json:"vms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVMsResponse) Reset() {
+	*x = ListVMsResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVMsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVMsResponse) ProtoMessage() {}
+
+func (x *ListVMsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVMsResponse.ProtoReflect.Descriptor instead.
+func (*ListVMsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListVMsResponse) GetVms() []*VM {
+	if x != nil {
+		return x.Vms
+	}
+	return nil
+}
+
+type VMEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Vm            *VM                    `protobuf:"bytes,2,opt,name=vm,proto3" json:"vm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VMEvent) Reset() {
+	*x = VMEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VMEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VMEvent) ProtoMessage() {}
+
+func (x *VMEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VMEvent.ProtoReflect.Descriptor instead.
+func (*VMEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *VMEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *VMEvent) GetVm() *VM {
+	if x != nil {
+		return x.Vm
+	}
+	return nil
+}
+
+// Container is a container.
+type Container struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	Port          int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Cpu           int32                  `protobuf:"varint,5,opt,name=cpu,proto3" json:"cpu,omitempty"`
+	Ram           int32                  `protobuf:"varint,6,opt,name=ram,proto3" json:"ram,omitempty"`
+	Disk          int32                  `protobuf:"varint,7,opt,name=disk,proto3" json:"disk,omitempty"`
+	Volume        string                 `protobuf:"bytes,8,opt,name=volume,proto3" json:"volume,omitempty"`
+	MountPath     string                 `protobuf:"bytes,9,opt,name=mount_path,json=mountPath,proto3" json:"mount_path,omitempty"`
+	Env           []string               `protobuf:"bytes,10,rep,name=env,proto3" json:"env,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Container) Reset() {
+	*x = Container{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Container) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Container) ProtoMessage() {}
+
+func (x *Container) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Container.ProtoReflect.Descriptor instead.
+func (*Container) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Container) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Container) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Container) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Container) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *Container) GetCpu() int32 {
+	if x != nil {
+		return x.Cpu
+	}
+	return 0
+}
+
+func (x *Container) GetRam() int32 {
+	if x != nil {
+		return x.Ram
+	}
+	return 0
+}
+
+func (x *Container) GetDisk() int32 {
+	if x != nil {
+		return x.Disk
+	}
+	return 0
+}
+
+func (x *Container) GetVolume() string {
+	if x != nil {
+		return x.Volume
+	}
+	return ""
+}
+
+func (x *Container) GetMountPath() string {
+	if x != nil {
+		return x.MountPath
+	}
+	return ""
+}
+
+func (x *Container) GetEnv() []string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+type ListContainersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Containers    []*Container           `protobuf:"bytes,1,rep,name=containers,proto3" json:"containers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListContainersResponse) Reset() {
+	*x = ListContainersResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListContainersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListContainersResponse) ProtoMessage() {}
+
+func (x *ListContainersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListContainersResponse.ProtoReflect.Descriptor instead.
+func (*ListContainersResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListContainersResponse) GetContainers() []*Container {
+	if x != nil {
+		return x.Containers
+	}
+	return nil
+}
+
+type ContainerEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Container     *Container             `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ContainerEvent) Reset() {
+	*x = ContainerEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ContainerEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContainerEvent) ProtoMessage() {}
+
+func (x *ContainerEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContainerEvent.ProtoReflect.Descriptor instead.
+func (*ContainerEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ContainerEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ContainerEvent) GetContainer() *Container {
+	if x != nil {
+		return x.Container
+	}
+	return nil
+}
+
+// Volume is a persistent volume.
+type Volume struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Size          string                 `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Volume) Reset() {
+	*x = Volume{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Volume) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Volume) ProtoMessage() {}
+
+func (x *Volume) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Volume.ProtoReflect.Descriptor instead.
+func (*Volume) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *Volume) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Volume) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Volume) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *Volume) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ListVolumesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Volumes       []*Volume              `protobuf:"bytes,1,rep,name=volumes,proto3" json:"volumes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListVolumesResponse) Reset() {
+	*x = ListVolumesResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListVolumesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVolumesResponse) ProtoMessage() {}
+
+func (x *ListVolumesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVolumesResponse.ProtoReflect.Descriptor instead.
+func (*ListVolumesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListVolumesResponse) GetVolumes() []*Volume {
+	if x != nil {
+		return x.Volumes
+	}
+	return nil
+}
+
+type VolumeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Volume        *Volume                `protobuf:"bytes,2,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VolumeEvent) Reset() {
+	*x = VolumeEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VolumeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VolumeEvent) ProtoMessage() {}
+
+func (x *VolumeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VolumeEvent.ProtoReflect.Descriptor instead.
+func (*VolumeEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *VolumeEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *VolumeEvent) GetVolume() *Volume {
+	if x != nil {
+		return x.Volume
+	}
+	return nil
+}
+
+// Postgres is a postgres cluster.
+type Postgres struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Size          string                 `protobuf:"bytes,3,opt,name=size,proto3" json:"size,omitempty"`
+	Replicas      int32                  `protobuf:"varint,4,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	Storage       int32                  `protobuf:"varint,5,opt,name=storage,proto3" json:"storage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Postgres) Reset() {
+	*x = Postgres{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Postgres) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Postgres) ProtoMessage() {}
+
+func (x *Postgres) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Postgres.ProtoReflect.Descriptor instead.
+func (*Postgres) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Postgres) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Postgres) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Postgres) GetSize() string {
+	if x != nil {
+		return x.Size
+	}
+	return ""
+}
+
+func (x *Postgres) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *Postgres) GetStorage() int32 {
+	if x != nil {
+		return x.Storage
+	}
+	return 0
+}
+
+type ListPostgresResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clusters      []*Postgres            `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPostgresResponse) Reset() {
+	*x = ListPostgresResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPostgresResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPostgresResponse) ProtoMessage() {}
+
+func (x *ListPostgresResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPostgresResponse.ProtoReflect.Descriptor instead.
+func (*ListPostgresResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPostgresResponse) GetClusters() []*Postgres {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+type PostgresEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Postgres      *Postgres              `protobuf:"bytes,2,opt,name=postgres,proto3" json:"postgres,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostgresEvent) Reset() {
+	*x = PostgresEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostgresEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostgresEvent) ProtoMessage() {}
+
+func (x *PostgresEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostgresEvent.ProtoReflect.Descriptor instead.
+func (*PostgresEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *PostgresEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *PostgresEvent) GetPostgres() *Postgres {
+	if x != nil {
+		return x.Postgres
+	}
+	return nil
+}
+
+// Mysql is a mysql cluster.
+type Mysql struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Name            string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace       string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Instances       int32                  `protobuf:"varint,3,opt,name=instances,proto3" json:"instances,omitempty"`
+	RouterInstances int32                  `protobuf:"varint,4,opt,name=router_instances,json=routerInstances,proto3" json:"router_instances,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Mysql) Reset() {
+	*x = Mysql{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Mysql) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Mysql) ProtoMessage() {}
+
+func (x *Mysql) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Mysql.ProtoReflect.Descriptor instead.
+func (*Mysql) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Mysql) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Mysql) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Mysql) GetInstances() int32 {
+	if x != nil {
+		return x.Instances
+	}
+	return 0
+}
+
+func (x *Mysql) GetRouterInstances() int32 {
+	if x != nil {
+		return x.RouterInstances
+	}
+	return 0
+}
+
+type ListMysqlResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clusters      []*Mysql               `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMysqlResponse) Reset() {
+	*x = ListMysqlResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMysqlResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMysqlResponse) ProtoMessage() {}
+
+func (x *ListMysqlResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMysqlResponse.ProtoReflect.Descriptor instead.
+func (*ListMysqlResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ListMysqlResponse) GetClusters() []*Mysql {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+type MysqlEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Mysql         *Mysql                 `protobuf:"bytes,2,opt,name=mysql,proto3" json:"mysql,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MysqlEvent) Reset() {
+	*x = MysqlEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MysqlEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MysqlEvent) ProtoMessage() {}
+
+func (x *MysqlEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MysqlEvent.ProtoReflect.Descriptor instead.
+func (*MysqlEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MysqlEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *MysqlEvent) GetMysql() *Mysql {
+	if x != nil {
+		return x.Mysql
+	}
+	return nil
+}
+
+// Clickhouse is a clickhouse cluster.
+type Clickhouse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Replicas      int32                  `protobuf:"varint,3,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	Shards        int32                  `protobuf:"varint,4,opt,name=shards,proto3" json:"shards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Clickhouse) Reset() {
+	*x = Clickhouse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Clickhouse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Clickhouse) ProtoMessage() {}
+
+func (x *Clickhouse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Clickhouse.ProtoReflect.Descriptor instead.
+func (*Clickhouse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *Clickhouse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Clickhouse) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *Clickhouse) GetReplicas() int32 {
+	if x != nil {
+		return x.Replicas
+	}
+	return 0
+}
+
+func (x *Clickhouse) GetShards() int32 {
+	if x != nil {
+		return x.Shards
+	}
+	return 0
+}
+
+type ListClickhouseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Clusters      []*Clickhouse          `protobuf:"bytes,1,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClickhouseResponse) Reset() {
+	*x = ListClickhouseResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClickhouseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClickhouseResponse) ProtoMessage() {}
+
+func (x *ListClickhouseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClickhouseResponse.ProtoReflect.Descriptor instead.
+func (*ListClickhouseResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ListClickhouseResponse) GetClusters() []*Clickhouse {
+	if x != nil {
+		return x.Clusters
+	}
+	return nil
+}
+
+type ClickhouseEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Clickhouse    *Clickhouse            `protobuf:"bytes,2,opt,name=clickhouse,proto3" json:"clickhouse,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClickhouseEvent) Reset() {
+	*x = ClickhouseEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClickhouseEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClickhouseEvent) ProtoMessage() {}
+
+func (x *ClickhouseEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClickhouseEvent.ProtoReflect.Descriptor instead.
+func (*ClickhouseEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ClickhouseEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *ClickhouseEvent) GetClickhouse() *Clickhouse {
+	if x != nil {
+		return x.Clickhouse
+	}
+	return nil
+}
+
+// LLM is a large language model served by ollama.
+type LLM struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LLM) Reset() {
+	*x = LLM{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LLM) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLM) ProtoMessage() {}
+
+func (x *LLM) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLM.ProtoReflect.Descriptor instead.
+func (*LLM) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *LLM) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LLM) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *LLM) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type ListLLMsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Llms          []*LLM                 `protobuf:"bytes,1,rep,name=llms,proto3" json:"llms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLLMsResponse) Reset() {
+	*x = ListLLMsResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLLMsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLLMsResponse) ProtoMessage() {}
+
+func (x *ListLLMsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLLMsResponse.ProtoReflect.Descriptor instead.
+func (*ListLLMsResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ListLLMsResponse) GetLlms() []*LLM {
+	if x != nil {
+		return x.Llms
+	}
+	return nil
+}
+
+type LLMEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Llm           *LLM                   `protobuf:"bytes,2,opt,name=llm,proto3" json:"llm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LLMEvent) Reset() {
+	*x = LLMEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LLMEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLMEvent) ProtoMessage() {}
+
+func (x *LLMEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLMEvent.ProtoReflect.Descriptor instead.
+func (*LLMEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *LLMEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *LLMEvent) GetLlm() *LLM {
+	if x != nil {
+		return x.Llm
+	}
+	return nil
+}
+
+// Namespace is a namespace.
+type Namespace struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Namespace) Reset() {
+	*x = Namespace{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Namespace) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Namespace) ProtoMessage() {}
+
+func (x *Namespace) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Namespace.ProtoReflect.Descriptor instead.
+func (*Namespace) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *Namespace) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListNamespacesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespaces    []*Namespace           `protobuf:"bytes,1,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNamespacesResponse) Reset() {
+	*x = ListNamespacesResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNamespacesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNamespacesResponse) ProtoMessage() {}
+
+func (x *ListNamespacesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNamespacesResponse.ProtoReflect.Descriptor instead.
+func (*ListNamespacesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListNamespacesResponse) GetNamespaces() []*Namespace {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+type NamespaceEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Namespace     *Namespace             `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NamespaceEvent) Reset() {
+	*x = NamespaceEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NamespaceEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NamespaceEvent) ProtoMessage() {}
+
+func (x *NamespaceEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NamespaceEvent.ProtoReflect.Descriptor instead.
+func (*NamespaceEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *NamespaceEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *NamespaceEvent) GetNamespace() *Namespace {
+	if x != nil {
+		return x.Namespace
+	}
+	return nil
+}
+
+// Node is a cluster node.
+type Node struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	User          string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	MasterHost    string                 `protobuf:"bytes,4,opt,name=master_host,json=masterHost,proto3" json:"master_host,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	MacAddress    string                 `protobuf:"bytes,6,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Node) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Node) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *Node) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *Node) GetMasterHost() string {
+	if x != nil {
+		return x.MasterHost
+	}
+	return ""
+}
+
+func (x *Node) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Node) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+// AddNodeRequest joins a host to the cluster over ssh.
+type AddNodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	User          string                 `protobuf:"bytes,3,opt,name=user,proto3" json:"user,omitempty"`
+	Key           string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Password      string                 `protobuf:"bytes,5,opt,name=password,proto3" json:"password,omitempty"`
+	MasterHost    string                 `protobuf:"bytes,6,opt,name=master_host,json=masterHost,proto3" json:"master_host,omitempty"`
+	MacAddress    string                 `protobuf:"bytes,7,opt,name=mac_address,json=macAddress,proto3" json:"mac_address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddNodeRequest) Reset() {
+	*x = AddNodeRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddNodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddNodeRequest) ProtoMessage() {}
+
+func (x *AddNodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddNodeRequest.ProtoReflect.Descriptor instead.
+func (*AddNodeRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AddNodeRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AddNodeRequest) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *AddNodeRequest) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *AddNodeRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *AddNodeRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *AddNodeRequest) GetMasterHost() string {
+	if x != nil {
+		return x.MasterHost
+	}
+	return ""
+}
+
+func (x *AddNodeRequest) GetMacAddress() string {
+	if x != nil {
+		return x.MacAddress
+	}
+	return ""
+}
+
+type ListNodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nodes         []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNodesResponse) Reset() {
+	*x = ListNodesResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNodesResponse) ProtoMessage() {}
+
+func (x *ListNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNodesResponse.ProtoReflect.Descriptor instead.
+func (*ListNodesResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListNodesResponse) GetNodes() []*Node {
+	if x != nil {
+		return x.Nodes
+	}
+	return nil
+}
+
+type NodeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	Node          *Node                  `protobuf:"bytes,2,opt,name=node,proto3" json:"node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeEvent) Reset() {
+	*x = NodeEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeEvent) ProtoMessage() {}
+
+func (x *NodeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeEvent.ProtoReflect.Descriptor instead.
+func (*NodeEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *NodeEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *NodeEvent) GetNode() *Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+// User is a govnocloud user. Passwords are never returned.
+type User struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespaces    []string               `protobuf:"bytes,2,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	IsAdmin       bool                   `protobuf:"varint,3,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *User) Reset() {
+	*x = User{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *User) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*User) ProtoMessage() {}
+
+func (x *User) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use User.ProtoReflect.Descriptor instead.
+func (*User) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *User) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+type CreateUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	Namespaces    []string               `protobuf:"bytes,3,rep,name=namespaces,proto3" json:"namespaces,omitempty"`
+	IsAdmin       bool                   `protobuf:"varint,4,opt,name=is_admin,json=isAdmin,proto3" json:"is_admin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUserRequest) Reset() {
+	*x = CreateUserRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUserRequest) ProtoMessage() {}
+
+func (x *CreateUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUserRequest.ProtoReflect.Descriptor instead.
+func (*CreateUserRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CreateUserRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateUserRequest) GetNamespaces() []string {
+	if x != nil {
+		return x.Namespaces
+	}
+	return nil
+}
+
+func (x *CreateUserRequest) GetIsAdmin() bool {
+	if x != nil {
+		return x.IsAdmin
+	}
+	return false
+}
+
+type SetUserPasswordRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserPasswordRequest) Reset() {
+	*x = SetUserPasswordRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserPasswordRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserPasswordRequest) ProtoMessage() {}
+
+func (x *SetUserPasswordRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserPasswordRequest.ProtoReflect.Descriptor instead.
+func (*SetUserPasswordRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetUserPasswordRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SetUserPasswordRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type UserNamespaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserNamespaceRequest) Reset() {
+	*x = UserNamespaceRequest{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserNamespaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserNamespaceRequest) ProtoMessage() {}
+
+func (x *UserNamespaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserNamespaceRequest.ProtoReflect.Descriptor instead.
+func (*UserNamespaceRequest) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UserNamespaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UserNamespaceRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type UserEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          EventType              `protobuf:"varint,1,opt,name=type,proto3,enum=govnocloud.v0.EventType" json:"type,omitempty"`
+	User          *User                  `protobuf:"bytes,2,opt,name=user,proto3" json:"user,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserEvent) Reset() {
+	*x = UserEvent{}
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserEvent) ProtoMessage() {}
+
+func (x *UserEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_pkg_api_govnocloud_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserEvent.ProtoReflect.Descriptor instead.
+func (*UserEvent) Descriptor() ([]byte, []int) {
+	return file_pkg_api_govnocloud_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *UserEvent) GetType() EventType {
+	if x != nil {
+		return x.Type
+	}
+	return EventType_EVENT_TYPE_UNSPECIFIED
+}
+
+func (x *UserEvent) GetUser() *User {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+var File_pkg_api_govnocloud_proto protoreflect.FileDescriptor
+
+const file_pkg_api_govnocloud_proto_rawDesc = "" +
+	"\n" +
+	"\x18pkg/api/govnocloud.proto\x12\rgovnocloud.v0\"\a\n" +
+	"\x05Empty\"0\n" +
+	"\x10NamespaceRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\"C\n" +
+	"\x0fResourceRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"!\n" +
+	"\vNameRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"\r\n" +
+	"\vListRequest\"\xbd\x01\n" +
+	"\x06VMPort\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1f\n" +
+	"\vsource_port\x18\x02 \x01(\x05R\n" +
+	"sourcePort\x12)\n" +
+	"\x10destination_port\x18\x03 \x01(\x05R\x0fdestinationPort\x12\x1a\n" +
+	"\bprotocol\x18\x04 \x01(\tR\bprotocol\x12\x1b\n" +
+	"\tnode_port\x18\x05 \x01(\x05R\bnodePort\x12\x1a\n" +
+	"\bendpoint\x18\x06 \x01(\tR\bendpoint\"\x96\x05\n" +
+	"\x02VM\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x12\n" +
+	"\x04size\x18\x04 \x01(\tR\x04size\x12\x12\n" +
+	"\x04disk\x18\x05 \x01(\tR\x04disk\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12)\n" +
+	"\x05ports\x18\a \x032\x15.govnocloud.v0.VMPortR\x05ports\x12\x12\n" +
+	"\x04user\x18\b \x01(\tR\x04user\x12\x19\n" +
+	"\bssh_keys\x18\t \x03(\tR\asshKeys\x12\"\n" +
+	"\rssh_key_names\x18\n" +
+	" \x03(\tR\vsshKeyNames\x12\x1b\n" +
+	"\tuser_data\x18\v \x01(\tR\buserData\x12!\n" +
+	"\fnetwork_data\x18\f \x01(\tR\vnetworkData\x12*\n" +
+	"\x11cloud_init_secret\x18\r \x01(\tR\x0fcloudInitSecret\x12!\n" +
+	"\fservice_type\x18\x0e \x01(\tR\vserviceType\x12/\n" +
+	"\avolumes\x18\x0f \x032\x17.govnocloud.v0.VMVolumeR\avolumes\x12!\n" +
+	"\frun_strategy\x18\x10 \x01(\tR\vrunStrategy\x12!\n" +
+	"\fidle_timeout\x18\x11 \x01(\tR\vidleTimeout\x126\n" +
+	"\tplacement\x18\x12 \x012\x1a.govnocloud.v0.VMPlacementR\tplacement\x122\n" +
+	"\bnetworks\x18\x13 \x032\x18.govnocloud.v0.VMNetworkR\bnetworks\x12\x18\n" +
+	"\asysprep\x18\x14 \x01(\tR\asysprep\"\xbd\x01\n" +
+	"\tVMNetwork\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x10\n" +
+	"\x03nad\x18\x03 \x01(\tR\x03nad\x12\x10\n" +
+	"\x03mac\x18\x04 \x01(\tR\x03mac\x12\x1e\n" +
+	"\n" +
+	"addressing\x18\x05 \x01(\tR\n" +
+	"addressing\x12\x18\n" +
+	"\aaddress\x18\x06 \x01(\tR\aaddress\x12\x18\n" +
+	"\agateway\x18\a \x01(\tR\agateway\x12\x10\n" +
+	"\x03dns\x18\b \x03(\tR\x03dns\"\xc3\x02\n" +
+	"\vVMPlacement\x12;\n" +
+	"\rnode_selector\x18\x01 \x032\x18.govnocloud.v0.NodeLabelR\fnodeSelector\x12'\n" +
+	"\x0faffinity_groups\x18\x02 \x03(\tR\x0eaffinityGroups\x120\n" +
+	"\x14anti_affinity_groups\x18\x03 \x03(\tR\x12antiAffinityGroups\x12\x1c\n" +
+	"\tpreferred\x18\x04 \x01(\bR\tpreferred\x12;\n" +
+	"\vtolerations\x18\x05 \x032\x1b.govnocloud.v0.VMTolerationR\vtolerations\x12#\n" +
+	"\rdedicated_cpu\x18\x06 \x01(\bR\fdedicatedCpu\x12\x1c\n" +
+	"\thugepages\x18\a \x01(\tR\thugepages\"3\n" +
+	"\tNodeLabel\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"j\n" +
+	"\fVMToleration\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x1a\n" +
+	"\boperator\x18\x02 \x01(\tR\boperator\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x16\n" +
+	"\x06effect\x18\x04 \x01(\tR\x06effect\"^\n" +
+	"\bVMVolume\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05claim\x18\x02 \x01(\tR\x05claim\x12\x16\n" +
+	"\x06serial\x18\x03 \x01(\tR\x06serial\x12\x10\n" +
+	"\x03bus\x18\x04 \x01(\tR\x03bus\"[\n" +
+	"\x0fVMVolumeRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06volume\x18\x03 \x01(\tR\x06volume\"4\n" +
+	"\x0fListVMsResponse\x12!\n" +
+	"\x03vms\x18\x01 \x032\x11.govnocloud.v0.VMR\x03vms\"V\n" +
+	"\aVMEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x12\x1f\n" +
+	"\x02vm\x18\x02 \x012\x11.govnocloud.v0.VMR\x02vm\"\xe8\x01\n" +
+	"\tContainer\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x14\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x12\n" +
+	"\x04port\x18\x04 \x01(\x05R\x04port\x12\x10\n" +
+	"\x03cpu\x18\x05 \x01(\x05R\x03cpu\x12\x10\n" +
+	"\x03ram\x18\x06 \x01(\x05R\x03ram\x12\x12\n" +
+	"\x04disk\x18\a \x01(\x05R\x04disk\x12\x16\n" +
+	"\x06volume\x18\b \x01(\tR\x06volume\x12\x1d\n" +
+	"\n" +
+	"mount_path\x18\t \x01(\tR\tmountPath\x12\x10\n" +
+	"\x03env\x18\n" +
+	" \x03(\tR\x03env\"P\n" +
+	"\x16ListContainersResponse\x126\n" +
+	"\n" +
+	"containers\x18\x01 \x032\x18.govnocloud.v0.ContainerR\n" +
+	"containers\"r\n" +
+	"\x0eContainerEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x124\n" +
+	"\tcontainer\x18\x02 \x012\x18.govnocloud.v0.ContainerR\tcontainer\"f\n" +
+	"\x06Volume\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"D\n" +
+	"\x13ListVolumesResponse\x12-\n" +
+	"\avolumes\x18\x01 \x032\x15.govnocloud.v0.VolumeR\avolumes\"f\n" +
+	"\vVolumeEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x12+\n" +
+	"\x06volume\x18\x02 \x012\x15.govnocloud.v0.VolumeR\x06volume\"\x86\x01\n" +
+	"\bPostgres\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\tR\x04size\x12\x1a\n" +
+	"\breplicas\x18\x04 \x01(\x05R\breplicas\x12\x18\n" +
+	"\astorage\x18\x05 \x01(\x05R\astorage\"I\n" +
+	"\x14ListPostgresResponse\x121\n" +
+	"\bclusters\x18\x01 \x032\x17.govnocloud.v0.PostgresR\bclusters\"n\n" +
+	"\rPostgresEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x121\n" +
+	"\bpostgres\x18\x02 \x012\x17.govnocloud.v0.PostgresR\bpostgres\"\x82\x01\n" +
+	"\x05Mysql\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1c\n" +
+	"\tinstances\x18\x03 \x01(\x05R\tinstances\x12)\n" +
+	"\x10router_instances\x18\x04 \x01(\x05R\x0frouterInstances\"C\n" +
+	"\x11ListMysqlResponse\x12.\n" +
+	"\bclusters\x18\x01 \x032\x14.govnocloud.v0.MysqlR\bclusters\"b\n" +
+	"\n" +
+	"MysqlEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x12(\n" +
+	"\x05mysql\x18\x02 \x012\x14.govnocloud.v0.MysqlR\x05mysql\"r\n" +
+	"\n" +
+	"Clickhouse\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\breplicas\x18\x03 \x01(\x05R\breplicas\x12\x16\n" +
+	"\x06shards\x18\x04 \x01(\x05R\x06shards\"M\n" +
+	"\x16ListClickhouseResponse\x123\n" +
+	"\bclusters\x18\x01 \x032\x19.govnocloud.v0.ClickhouseR\bclusters\"v\n" +
+	"\x0fClickhouseEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x127\n" +
+	"\n" +
+	"clickhouse\x18\x02 \x012\x19.govnocloud.v0.ClickhouseR\n" +
+	"clickhouse\"K\n" +
+	"\x03LLM\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"8\n" +
+	"\x10ListLLMsResponse\x12$\n" +
+	"\x04llms\x18\x01 \x032\x12.govnocloud.v0.LLMR\x04llms\"Z\n" +
+	"\bLLMEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x12\"\n" +
+	"\x03llm\x18\x02 \x012\x12.govnocloud.v0.LLMR\x03llm\"\x1f\n" +
+	"\tNamespace\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"P\n" +
+	"\x16ListNamespacesResponse\x126\n" +
+	"\n" +
+	"namespaces\x18\x01 \x032\x18.govnocloud.v0.NamespaceR\n" +
+	"namespaces\"r\n" +
+	"\x0eNamespaceEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x124\n" +
+	"\tnamespace\x18\x02 \x012\x18.govnocloud.v0.NamespaceR\tnamespace\"\x9c\x01\n" +
+	"\x04Node\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\x12\x1f\n" +
+	"\vmaster_host\x18\x04 \x01(\tR\n" +
+	"masterHost\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1f\n" +
+	"\vmac_address\x18\x06 \x01(\tR\n" +
+	"macAddress\"\xbc\x01\n" +
+	"\x0eAddNodeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04user\x18\x03 \x01(\tR\x04user\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x12\x1a\n" +
+	"\bpassword\x18\x05 \x01(\tR\bpassword\x12\x1f\n" +
+	"\vmaster_host\x18\x06 \x01(\tR\n" +
+	"masterHost\x12\x1f\n" +
+	"\vmac_address\x18\a \x01(\tR\n" +
+	"macAddress\"<\n" +
+	"\x11ListNodesResponse\x12'\n" +
+	"\x05nodes\x18\x01 \x032\x13.govnocloud.v0.NodeR\x05nodes\"^\n" +
+	"\tNodeEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x12%\n" +
+	"\x04node\x18\x02 \x012\x13.govnocloud.v0.NodeR\x04node\"U\n" +
+	"\x04User\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x02 \x03(\tR\n" +
+	"namespaces\x12\x19\n" +
+	"\bis_admin\x18\x03 \x01(\bR\aisAdmin\"~\n" +
+	"\x11CreateUserRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x1e\n" +
+	"\n" +
+	"namespaces\x18\x03 \x03(\tR\n" +
+	"namespaces\x12\x19\n" +
+	"\bis_admin\x18\x04 \x01(\bR\aisAdmin\"H\n" +
+	"\x16SetUserPasswordRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"H\n" +
+	"\x14UserNamespaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\"<\n" +
+	"\x11ListUsersResponse\x12'\n" +
+	"\x05users\x18\x01 \x032\x13.govnocloud.v0.UserR\x05users\"^\n" +
+	"\tUserEvent\x12*\n" +
+	"\x04type\x18\x01 \x012\x18.govnocloud.v0.EventTypeR\x04type\x12%\n" +
+	"\x04user\x18\x02 \x012\x13.govnocloud.v0.UserR\x04user*M\n" +
+	"\tEventType\x12\x1a\n" +
+	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05ADDED\x10\x01\x12\f\n" +
+	"\bMODIFIED\x10\x02\x12\v\n" +
+	"\aDELETED\x10\x032\x90\x06\n" +
+	"\tVMService\x12J\n" +
+	"\aListVMs\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x1e.govnocloud.v0.ListVMsResponse\x12:\n" +
+	"\x05GetVM\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x11.govnocloud.v0.VM\x120\n" +
+	"\bCreateVM\x12\x11.govnocloud.v0.VM\x1a\x11.govnocloud.v0.VM\x12@\n" +
+	"\bDeleteVM\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12?\n" +
+	"\aStartVM\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12>\n" +
+	"\x06StopVM\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12A\n" +
+	"\tRestartVM\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x122\n" +
+	"\n" +
+	"SetVMPorts\x12\x11.govnocloud.v0.VM\x1a\x11.govnocloud.v0.VM\x12C\n" +
+	"\x0eAttachVMVolume\x12\x1e.govnocloud.v0.VMVolumeRequest\x1a\x11.govnocloud.v0.VM\x12C\n" +
+	"\x0eDetachVMVolume\x12\x1e.govnocloud.v0.VMVolumeRequest\x1a\x11.govnocloud.v0.VM\x12>\n" +
+	"\x06WaitVM\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12E\n" +
+	"\bWatchVMs\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x16.govnocloud.v0.VMEvent0\x012\x9b\x03\n" +
+	"\x10ContainerService\x12X\n" +
+	"\x0eListContainers\x12\x1f.govnocloud.v0.NamespaceRequest\x1a%.govnocloud.v0.ListContainersResponse\x12H\n" +
+	"\fGetContainer\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x18.govnocloud.v0.Container\x12E\n" +
+	"\x0fCreateContainer\x12\x18.govnocloud.v0.Container\x1a\x18.govnocloud.v0.Container\x12G\n" +
+	"\x0fDeleteContainer\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12S\n" +
+	"\x0fWatchContainers\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x1d.govnocloud.v0.ContainerEvent0\x012\xfa\x02\n" +
+	"\rVolumeService\x12R\n" +
+	"\vListVolumes\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\".govnocloud.v0.ListVolumesResponse\x12B\n" +
+	"\tGetVolume\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x15.govnocloud.v0.Volume\x12<\n" +
+	"\fCreateVolume\x12\x15.govnocloud.v0.Volume\x1a\x15.govnocloud.v0.Volume\x12D\n" +
+	"\fDeleteVolume\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12M\n" +
+	"\fWatchVolumes\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x1a.govnocloud.v0.VolumeEvent0\x012\x8d\x03\n" +
+	"\x0fPostgresService\x12T\n" +
+	"\fListPostgres\x12\x1f.govnocloud.v0.NamespaceRequest\x1a#.govnocloud.v0.ListPostgresResponse\x12F\n" +
+	"\vGetPostgres\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x17.govnocloud.v0.Postgres\x12B\n" +
+	"\x0eCreatePostgres\x12\x17.govnocloud.v0.Postgres\x1a\x17.govnocloud.v0.Postgres\x12F\n" +
+	"\x0eDeletePostgres\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12P\n" +
+	"\rWatchPostgres\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x1c.govnocloud.v0.PostgresEvent0\x012\xec\x02\n" +
+	"\fMysqlService\x12N\n" +
+	"\tListMysql\x12\x1f.govnocloud.v0.NamespaceRequest\x1a .govnocloud.v0.ListMysqlResponse\x12@\n" +
+	"\bGetMysql\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Mysql\x129\n" +
+	"\vCreateMysql\x12\x14.govnocloud.v0.Mysql\x1a\x14.govnocloud.v0.Mysql\x12C\n" +
+	"\vDeleteMysql\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12J\n" +
+	"\n" +
+	"WatchMysql\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x19.govnocloud.v0.MysqlEvent0\x012\xa3\x03\n" +
+	"\x11ClickhouseService\x12X\n" +
+	"\x0eListClickhouse\x12\x1f.govnocloud.v0.NamespaceRequest\x1a%.govnocloud.v0.ListClickhouseResponse\x12J\n" +
+	"\rGetClickhouse\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x19.govnocloud.v0.Clickhouse\x12H\n" +
+	"\x10CreateClickhouse\x12\x19.govnocloud.v0.Clickhouse\x1a\x19.govnocloud.v0.Clickhouse\x12H\n" +
+	"\x10DeleteClickhouse\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12T\n" +
+	"\x0fWatchClickhouse\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x1e.govnocloud.v0.ClickhouseEvent0\x012\xd9\x02\n" +
+	"\n" +
+	"LLMService\x12L\n" +
+	"\bListLLMs\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x1f.govnocloud.v0.ListLLMsResponse\x12<\n" +
+	"\x06GetLLM\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x12.govnocloud.v0.LLM\x123\n" +
+	"\tCreateLLM\x12\x12.govnocloud.v0.LLM\x1a\x12.govnocloud.v0.LLM\x12A\n" +
+	"\tDeleteLLM\x12\x1e.govnocloud.v0.ResourceRequest\x1a\x14.govnocloud.v0.Empty\x12G\n" +
+	"\tWatchLLMs\x12\x1f.govnocloud.v0.NamespaceRequest\x1a\x17.govnocloud.v0.LLMEvent0\x012\x8b\x03\n" +
+	"\x10NamespaceService\x12S\n" +
+	"\x0eListNamespaces\x12\x1a.govnocloud.v0.ListRequest\x1a%.govnocloud.v0.ListNamespacesResponse\x12D\n" +
+	"\fGetNamespace\x12\x1a.govnocloud.v0.NameRequest\x1a\x18.govnocloud.v0.Namespace\x12G\n" +
+	"\x0fCreateNamespace\x12\x1a.govnocloud.v0.NameRequest\x1a\x18.govnocloud.v0.Namespace\x12C\n" +
+	"\x0fDeleteNamespace\x12\x1a.govnocloud.v0.NameRequest\x1a\x14.govnocloud.v0.Empty\x12N\n" +
+	"\x0fWatchNamespaces\x12\x1a.govnocloud.v0.ListRequest\x1a\x1d.govnocloud.v0.NamespaceEvent0\x012\xdc\x04\n" +
+	"\vNodeService\x12I\n" +
+	"\tListNodes\x12\x1a.govnocloud.v0.ListRequest\x1a .govnocloud.v0.ListNodesResponse\x12:\n" +
+	"\aGetNode\x12\x1a.govnocloud.v0.NameRequest\x1a\x13.govnocloud.v0.Node\x12=\n" +
+	"\aAddNode\x12\x1d.govnocloud.v0.AddNodeRequest\x1a\x13.govnocloud.v0.Node\x12>\n" +
+	"\n" +
+	"DeleteNode\x12\x1a.govnocloud.v0.NameRequest\x1a\x14.govnocloud.v0.Empty\x12?\n" +
+	"\vRestartNode\x12\x1a.govnocloud.v0.NameRequest\x1a\x14.govnocloud.v0.Empty\x12?\n" +
+	"\vSuspendNode\x12\x1a.govnocloud.v0.NameRequest\x1a\x14.govnocloud.v0.Empty\x12>\n" +
+	"\n" +
+	"ResumeNode\x12\x1a.govnocloud.v0.NameRequest\x1a\x14.govnocloud.v0.Empty\x12?\n" +
+	"\vUpgradeNode\x12\x1a.govnocloud.v0.NameRequest\x1a\x14.govnocloud.v0.Empty\x12D\n" +
+	"\n" +
+	"WatchNodes\x12\x1a.govnocloud.v0.ListRequest\x1a\x18.govnocloud.v0.NodeEvent0\x012\xd4\x04\n" +
+	"\vUserService\x12I\n" +
+	"\tListUsers\x12\x1a.govnocloud.v0.ListRequest\x1a .govnocloud.v0.ListUsersResponse\x12:\n" +
+	"\aGetUser\x12\x1a.govnocloud.v0.NameRequest\x1a\x13.govnocloud.v0.User\x12C\n" +
+	"\n" +
+	"CreateUser\x12 .govnocloud.v0.CreateUserRequest\x1a\x13.govnocloud.v0.User\x12>\n" +
+	"\n" +
+	"DeleteUser\x12\x1a.govnocloud.v0.NameRequest\x1a\x14.govnocloud.v0.Empty\x12N\n" +
+	"\x0fSetUserPassword\x12%.govnocloud.v0.SetUserPasswordRequest\x1a\x14.govnocloud.v0.Empty\x12N\n" +
+	"\x12AddNamespaceToUser\x12#.govnocloud.v0.UserNamespaceRequest\x1a\x13.govnocloud.v0.User\x12S\n" +
+	"\x17RemoveNamespaceFromUser\x12#.govnocloud.v0.UserNamespaceRequest\x1a\x13.govnocloud.v0.User\x12D\n" +
+	"\n" +
+	"WatchUsers\x12\x1a.govnocloud.v0.ListRequest\x1a\x18.govnocloud.v0.UserEvent0\x01B(Z&github.com/rusik69/govnocloud2/pkg/apib\x06proto3"
+
+var (
+	file_pkg_api_govnocloud_proto_rawDescOnce sync.Once
+	file_pkg_api_govnocloud_proto_rawDescData []byte
+)
+
+func file_pkg_api_govnocloud_proto_rawDescGZIP() []byte {
+	file_pkg_api_govnocloud_proto_rawDescOnce.Do(func() {
+		file_pkg_api_govnocloud_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_pkg_api_govnocloud_proto_rawDesc), len(file_pkg_api_govnocloud_proto_rawDesc)))
+	})
+	return file_pkg_api_govnocloud_proto_rawDescData
+}
+
+var file_pkg_api_govnocloud_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_pkg_api_govnocloud_proto_msgTypes = make([]protoimpl.MessageInfo, 46)
+var file_pkg_api_govnocloud_proto_goTypes = []any{
+	(EventType)(0),                 // 0: govnocloud.v0.EventType
+	(*Empty)(nil),                  // 1: govnocloud.v0.Empty
+	(*NamespaceRequest)(nil),       // 2: govnocloud.v0.NamespaceRequest
+	(*ResourceRequest)(nil),        // 3: govnocloud.v0.ResourceRequest
+	(*NameRequest)(nil),            // 4: govnocloud.v0.NameRequest
+	(*ListRequest)(nil),            // 5: govnocloud.v0.ListRequest
+	(*VMPort)(nil),                 // 6: govnocloud.v0.VMPort
+	(*VM)(nil),                     // 7: govnocloud.v0.VM
+	(*VMNetwork)(nil),              // 8: govnocloud.v0.VMNetwork
+	(*VMPlacement)(nil),            // 9: govnocloud.v0.VMPlacement
+	(*NodeLabel)(nil),              // 10: govnocloud.v0.NodeLabel
+	(*VMToleration)(nil),           // 11: govnocloud.v0.VMToleration
+	(*VMVolume)(nil),               // 12: govnocloud.v0.VMVolume
+	(*VMVolumeRequest)(nil),        // 13: govnocloud.v0.VMVolumeRequest
+	(*ListVMsResponse)(nil),        // 14: govnocloud.v0.ListVMsResponse
+	(*VMEvent)(nil),                // 15: govnocloud.v0.VMEvent
+	(*Container)(nil),              // 16: govnocloud.v0.Container
+	(*ListContainersResponse)(nil), // 17: govnocloud.v0.ListContainersResponse
+	(*ContainerEvent)(nil),         // 18: govnocloud.v0.ContainerEvent
+	(*Volume)(nil),                 // 19: govnocloud.v0.Volume
+	(*ListVolumesResponse)(nil),    // 20: govnocloud.v0.ListVolumesResponse
+	(*VolumeEvent)(nil),            // 21: govnocloud.v0.VolumeEvent
+	(*Postgres)(nil),               // 22: govnocloud.v0.Postgres
+	(*ListPostgresResponse)(nil),   // 23: govnocloud.v0.ListPostgresResponse
+	(*PostgresEvent)(nil),          // 24: govnocloud.v0.PostgresEvent
+	(*Mysql)(nil),                  // 25: govnocloud.v0.Mysql
+	(*ListMysqlResponse)(nil),      // 26: govnocloud.v0.ListMysqlResponse
+	(*MysqlEvent)(nil),             // 27: govnocloud.v0.MysqlEvent
+	(*Clickhouse)(nil),             // 28: govnocloud.v0.Clickhouse
+	(*ListClickhouseResponse)(nil), // 29: govnocloud.v0.ListClickhouseResponse
+	(*ClickhouseEvent)(nil),        // 30: govnocloud.v0.ClickhouseEvent
+	(*LLM)(nil),                    // 31: govnocloud.v0.LLM
+	(*ListLLMsResponse)(nil),       // 32: govnocloud.v0.ListLLMsResponse
+	(*LLMEvent)(nil),               // 33: govnocloud.v0.LLMEvent
+	(*Namespace)(nil),              // 34: govnocloud.v0.Namespace
+	(*ListNamespacesResponse)(nil), // 35: govnocloud.v0.ListNamespacesResponse
+	(*NamespaceEvent)(nil),         // 36: govnocloud.v0.NamespaceEvent
+	(*Node)(nil),                   // 37: govnocloud.v0.Node
+	(*AddNodeRequest)(nil),         // 38: govnocloud.v0.AddNodeRequest
+	(*ListNodesResponse)(nil),      // 39: govnocloud.v0.ListNodesResponse
+	(*NodeEvent)(nil),              // 40: govnocloud.v0.NodeEvent
+	(*User)(nil),                   // 41: govnocloud.v0.User
+	(*CreateUserRequest)(nil),      // 42: govnocloud.v0.CreateUserRequest
+	(*SetUserPasswordRequest)(nil), // 43: govnocloud.v0.SetUserPasswordRequest
+	(*UserNamespaceRequest)(nil),   // 44: govnocloud.v0.UserNamespaceRequest
+	(*ListUsersResponse)(nil),      // 45: govnocloud.v0.ListUsersResponse
+	(*UserEvent)(nil),              // 46: govnocloud.v0.UserEvent
+}
+var file_pkg_api_govnocloud_proto_depIdxs = []int32{
+	6,   // 0: govnocloud.v0.VM.ports:type_name -> govnocloud.v0.VMPort
+	12,  // 1: govnocloud.v0.VM.volumes:type_name -> govnocloud.v0.VMVolume
+	9,   // 2: govnocloud.v0.VM.placement:type_name -> govnocloud.v0.VMPlacement
+	8,   // 3: govnocloud.v0.VM.networks:type_name -> govnocloud.v0.VMNetwork
+	10,  // 4: govnocloud.v0.VMPlacement.node_selector:type_name -> govnocloud.v0.NodeLabel
+	11,  // 5: govnocloud.v0.VMPlacement.tolerations:type_name -> govnocloud.v0.VMToleration
+	7,   // 6: govnocloud.v0.ListVMsResponse.vms:type_name -> govnocloud.v0.VM
+	0,   // 7: govnocloud.v0.VMEvent.type:type_name -> govnocloud.v0.EventType
+	7,   // 8: govnocloud.v0.VMEvent.vm:type_name -> govnocloud.v0.VM
+	16,  // 9: govnocloud.v0.ListContainersResponse.containers:type_name -> govnocloud.v0.Container
+	0,   // 10: govnocloud.v0.ContainerEvent.type:type_name -> govnocloud.v0.EventType
+	16,  // 11: govnocloud.v0.ContainerEvent.container:type_name -> govnocloud.v0.Container
+	19,  // 12: govnocloud.v0.ListVolumesResponse.volumes:type_name -> govnocloud.v0.Volume
+	0,   // 13: govnocloud.v0.VolumeEvent.type:type_name -> govnocloud.v0.EventType
+	19,  // 14: govnocloud.v0.VolumeEvent.volume:type_name -> govnocloud.v0.Volume
+	22,  // 15: govnocloud.v0.ListPostgresResponse.clusters:type_name -> govnocloud.v0.Postgres
+	0,   // 16: govnocloud.v0.PostgresEvent.type:type_name -> govnocloud.v0.EventType
+	22,  // 17: govnocloud.v0.PostgresEvent.postgres:type_name -> govnocloud.v0.Postgres
+	25,  // 18: govnocloud.v0.ListMysqlResponse.clusters:type_name -> govnocloud.v0.Mysql
+	0,   // 19: govnocloud.v0.MysqlEvent.type:type_name -> govnocloud.v0.EventType
+	25,  // 20: govnocloud.v0.MysqlEvent.mysql:type_name -> govnocloud.v0.Mysql
+	28,  // 21: govnocloud.v0.ListClickhouseResponse.clusters:type_name -> govnocloud.v0.Clickhouse
+	0,   // 22: govnocloud.v0.ClickhouseEvent.type:type_name -> govnocloud.v0.EventType
+	28,  // 23: govnocloud.v0.ClickhouseEvent.clickhouse:type_name -> govnocloud.v0.Clickhouse
+	31,  // 24: govnocloud.v0.ListLLMsResponse.llms:type_name -> govnocloud.v0.LLM
+	0,   // 25: govnocloud.v0.LLMEvent.type:type_name -> govnocloud.v0.EventType
+	31,  // 26: govnocloud.v0.LLMEvent.llm:type_name -> govnocloud.v0.LLM
+	34,  // 27: govnocloud.v0.ListNamespacesResponse.namespaces:type_name -> govnocloud.v0.Namespace
+	0,   // 28: govnocloud.v0.NamespaceEvent.type:type_name -> govnocloud.v0.EventType
+	34,  // 29: govnocloud.v0.NamespaceEvent.namespace:type_name -> govnocloud.v0.Namespace
+	37,  // 30: govnocloud.v0.ListNodesResponse.nodes:type_name -> govnocloud.v0.Node
+	0,   // 31: govnocloud.v0.NodeEvent.type:type_name -> govnocloud.v0.EventType
+	37,  // 32: govnocloud.v0.NodeEvent.node:type_name -> govnocloud.v0.Node
+	41,  // 33: govnocloud.v0.ListUsersResponse.users:type_name -> govnocloud.v0.User
+	0,   // 34: govnocloud.v0.UserEvent.type:type_name -> govnocloud.v0.EventType
+	41,  // 35: govnocloud.v0.UserEvent.user:type_name -> govnocloud.v0.User
+	2,   // 36: govnocloud.v0.VMService.ListVMs:input_type -> govnocloud.v0.NamespaceRequest
+	3,   // 37: govnocloud.v0.VMService.GetVM:input_type -> govnocloud.v0.ResourceRequest
+	7,   // 38: govnocloud.v0.VMService.CreateVM:input_type -> govnocloud.v0.VM
+	3,   // 39: govnocloud.v0.VMService.DeleteVM:input_type -> govnocloud.v0.ResourceRequest
+	3,   // 40: govnocloud.v0.VMService.StartVM:input_type -> govnocloud.v0.ResourceRequest
+	3,   // 41: govnocloud.v0.VMService.StopVM:input_type -> govnocloud.v0.ResourceRequest
+	3,   // 42: govnocloud.v0.VMService.RestartVM:input_type -> govnocloud.v0.ResourceRequest
+	7,   // 43: govnocloud.v0.VMService.SetVMPorts:input_type -> govnocloud.v0.VM
+	13,  // 44: govnocloud.v0.VMService.AttachVMVolume:input_type -> govnocloud.v0.VMVolumeRequest
+	13,  // 45: govnocloud.v0.VMService.DetachVMVolume:input_type -> govnocloud.v0.VMVolumeRequest
+	3,   // 46: govnocloud.v0.VMService.WaitVM:input_type -> govnocloud.v0.ResourceRequest
+	2,   // 47: govnocloud.v0.VMService.WatchVMs:input_type -> govnocloud.v0.NamespaceRequest
+	2,   // 48: govnocloud.v0.ContainerService.ListContainers:input_type -> govnocloud.v0.NamespaceRequest
+	3,   // 49: govnocloud.v0.ContainerService.GetContainer:input_type -> govnocloud.v0.ResourceRequest
+	16,  // 50: govnocloud.v0.ContainerService.CreateContainer:input_type -> govnocloud.v0.Container
+	3,   // 51: govnocloud.v0.ContainerService.DeleteContainer:input_type -> govnocloud.v0.ResourceRequest
+	2,   // 52: govnocloud.v0.ContainerService.WatchContainers:input_type -> govnocloud.v0.NamespaceRequest
+	2,   // 53: govnocloud.v0.VolumeService.ListVolumes:input_type -> govnocloud.v0.NamespaceRequest
+	3,   // 54: govnocloud.v0.VolumeService.GetVolume:input_type -> govnocloud.v0.ResourceRequest
+	19,  // 55: govnocloud.v0.VolumeService.CreateVolume:input_type -> govnocloud.v0.Volume
+	3,   // 56: govnocloud.v0.VolumeService.DeleteVolume:input_type -> govnocloud.v0.ResourceRequest
+	2,   // 57: govnocloud.v0.VolumeService.WatchVolumes:input_type -> govnocloud.v0.NamespaceRequest
+	2,   // 58: govnocloud.v0.PostgresService.ListPostgres:input_type -> govnocloud.v0.NamespaceRequest
+	3,   // 59: govnocloud.v0.PostgresService.GetPostgres:input_type -> govnocloud.v0.ResourceRequest
+	22,  // 60: govnocloud.v0.PostgresService.CreatePostgres:input_type -> govnocloud.v0.Postgres
+	3,   // 61: govnocloud.v0.PostgresService.DeletePostgres:input_type -> govnocloud.v0.ResourceRequest
+	2,   // 62: govnocloud.v0.PostgresService.WatchPostgres:input_type -> govnocloud.v0.NamespaceRequest
+	2,   // 63: govnocloud.v0.MysqlService.ListMysql:input_type -> govnocloud.v0.NamespaceRequest
+	3,   // 64: govnocloud.v0.MysqlService.GetMysql:input_type -> govnocloud.v0.ResourceRequest
+	25,  // 65: govnocloud.v0.MysqlService.CreateMysql:input_type -> govnocloud.v0.Mysql
+	3,   // 66: govnocloud.v0.MysqlService.DeleteMysql:input_type -> govnocloud.v0.ResourceRequest
+	2,   // 67: govnocloud.v0.MysqlService.WatchMysql:input_type -> govnocloud.v0.NamespaceRequest
+	2,   // 68: govnocloud.v0.ClickhouseService.ListClickhouse:input_type -> govnocloud.v0.NamespaceRequest
+	3,   // 69: govnocloud.v0.ClickhouseService.GetClickhouse:input_type -> govnocloud.v0.ResourceRequest
+	28,  // 70: govnocloud.v0.ClickhouseService.CreateClickhouse:input_type -> govnocloud.v0.Clickhouse
+	3,   // 71: govnocloud.v0.ClickhouseService.DeleteClickhouse:input_type -> govnocloud.v0.ResourceRequest
+	2,   // 72: govnocloud.v0.ClickhouseService.WatchClickhouse:input_type -> govnocloud.v0.NamespaceRequest
+	2,   // 73: govnocloud.v0.LLMService.ListLLMs:input_type -> govnocloud.v0.NamespaceRequest
+	3,   // 74: govnocloud.v0.LLMService.GetLLM:input_type -> govnocloud.v0.ResourceRequest
+	31,  // 75: govnocloud.v0.LLMService.CreateLLM:input_type -> govnocloud.v0.LLM
+	3,   // 76: govnocloud.v0.LLMService.DeleteLLM:input_type -> govnocloud.v0.ResourceRequest
+	2,   // 77: govnocloud.v0.LLMService.WatchLLMs:input_type -> govnocloud.v0.NamespaceRequest
+	5,   // 78: govnocloud.v0.NamespaceService.ListNamespaces:input_type -> govnocloud.v0.ListRequest
+	4,   // 79: govnocloud.v0.NamespaceService.GetNamespace:input_type -> govnocloud.v0.NameRequest
+	4,   // 80: govnocloud.v0.NamespaceService.CreateNamespace:input_type -> govnocloud.v0.NameRequest
+	4,   // 81: govnocloud.v0.NamespaceService.DeleteNamespace:input_type -> govnocloud.v0.NameRequest
+	5,   // 82: govnocloud.v0.NamespaceService.WatchNamespaces:input_type -> govnocloud.v0.ListRequest
+	5,   // 83: govnocloud.v0.NodeService.ListNodes:input_type -> govnocloud.v0.ListRequest
+	4,   // 84: govnocloud.v0.NodeService.GetNode:input_type -> govnocloud.v0.NameRequest
+	38,  // 85: govnocloud.v0.NodeService.AddNode:input_type -> govnocloud.v0.AddNodeRequest
+	4,   // 86: govnocloud.v0.NodeService.DeleteNode:input_type -> govnocloud.v0.NameRequest
+	4,   // 87: govnocloud.v0.NodeService.RestartNode:input_type -> govnocloud.v0.NameRequest
+	4,   // 88: govnocloud.v0.NodeService.SuspendNode:input_type -> govnocloud.v0.NameRequest
+	4,   // 89: govnocloud.v0.NodeService.ResumeNode:input_type -> govnocloud.v0.NameRequest
+	4,   // 90: govnocloud.v0.NodeService.UpgradeNode:input_type -> govnocloud.v0.NameRequest
+	5,   // 91: govnocloud.v0.NodeService.WatchNodes:input_type -> govnocloud.v0.ListRequest
+	5,   // 92: govnocloud.v0.UserService.ListUsers:input_type -> govnocloud.v0.ListRequest
+	4,   // 93: govnocloud.v0.UserService.GetUser:input_type -> govnocloud.v0.NameRequest
+	42,  // 94: govnocloud.v0.UserService.CreateUser:input_type -> govnocloud.v0.CreateUserRequest
+	4,   // 95: govnocloud.v0.UserService.DeleteUser:input_type -> govnocloud.v0.NameRequest
+	43,  // 96: govnocloud.v0.UserService.SetUserPassword:input_type -> govnocloud.v0.SetUserPasswordRequest
+	44,  // 97: govnocloud.v0.UserService.AddNamespaceToUser:input_type -> govnocloud.v0.UserNamespaceRequest
+	44,  // 98: govnocloud.v0.UserService.RemoveNamespaceFromUser:input_type -> govnocloud.v0.UserNamespaceRequest
+	5,   // 99: govnocloud.v0.UserService.WatchUsers:input_type -> govnocloud.v0.ListRequest
+	14,  // 100: govnocloud.v0.VMService.ListVMs:output_type -> govnocloud.v0.ListVMsResponse
+	7,   // 101: govnocloud.v0.VMService.GetVM:output_type -> govnocloud.v0.VM
+	7,   // 102: govnocloud.v0.VMService.CreateVM:output_type -> govnocloud.v0.VM
+	1,   // 103: govnocloud.v0.VMService.DeleteVM:output_type -> govnocloud.v0.Empty
+	1,   // 104: govnocloud.v0.VMService.StartVM:output_type -> govnocloud.v0.Empty
+	1,   // 105: govnocloud.v0.VMService.StopVM:output_type -> govnocloud.v0.Empty
+	1,   // 106: govnocloud.v0.VMService.RestartVM:output_type -> govnocloud.v0.Empty
+	7,   // 107: govnocloud.v0.VMService.SetVMPorts:output_type -> govnocloud.v0.VM
+	7,   // 108: govnocloud.v0.VMService.AttachVMVolume:output_type -> govnocloud.v0.VM
+	7,   // 109: govnocloud.v0.VMService.DetachVMVolume:output_type -> govnocloud.v0.VM
+	1,   // 110: govnocloud.v0.VMService.WaitVM:output_type -> govnocloud.v0.Empty
+	15,  // 111: govnocloud.v0.VMService.WatchVMs:output_type -> govnocloud.v0.VMEvent
+	17,  // 112: govnocloud.v0.ContainerService.ListContainers:output_type -> govnocloud.v0.ListContainersResponse
+	16,  // 113: govnocloud.v0.ContainerService.GetContainer:output_type -> govnocloud.v0.Container
+	16,  // 114: govnocloud.v0.ContainerService.CreateContainer:output_type -> govnocloud.v0.Container
+	1,   // 115: govnocloud.v0.ContainerService.DeleteContainer:output_type -> govnocloud.v0.Empty
+	18,  // 116: govnocloud.v0.ContainerService.WatchContainers:output_type -> govnocloud.v0.ContainerEvent
+	20,  // 117: govnocloud.v0.VolumeService.ListVolumes:output_type -> govnocloud.v0.ListVolumesResponse
+	19,  // 118: govnocloud.v0.VolumeService.GetVolume:output_type -> govnocloud.v0.Volume
+	19,  // 119: govnocloud.v0.VolumeService.CreateVolume:output_type -> govnocloud.v0.Volume
+	1,   // 120: govnocloud.v0.VolumeService.DeleteVolume:output_type -> govnocloud.v0.Empty
+	21,  // 121: govnocloud.v0.VolumeService.WatchVolumes:output_type -> govnocloud.v0.VolumeEvent
+	23,  // 122: govnocloud.v0.PostgresService.ListPostgres:output_type -> govnocloud.v0.ListPostgresResponse
+	22,  // 123: govnocloud.v0.PostgresService.GetPostgres:output_type -> govnocloud.v0.Postgres
+	22,  // 124: govnocloud.v0.PostgresService.CreatePostgres:output_type -> govnocloud.v0.Postgres
+	1,   // 125: govnocloud.v0.PostgresService.DeletePostgres:output_type -> govnocloud.v0.Empty
+	24,  // 126: govnocloud.v0.PostgresService.WatchPostgres:output_type -> govnocloud.v0.PostgresEvent
+	26,  // 127: govnocloud.v0.MysqlService.ListMysql:output_type -> govnocloud.v0.ListMysqlResponse
+	25,  // 128: govnocloud.v0.MysqlService.GetMysql:output_type -> govnocloud.v0.Mysql
+	25,  // 129: govnocloud.v0.MysqlService.CreateMysql:output_type -> govnocloud.v0.Mysql
+	1,   // 130: govnocloud.v0.MysqlService.DeleteMysql:output_type -> govnocloud.v0.Empty
+	27,  // 131: govnocloud.v0.MysqlService.WatchMysql:output_type -> govnocloud.v0.MysqlEvent
+	29,  // 132: govnocloud.v0.ClickhouseService.ListClickhouse:output_type -> govnocloud.v0.ListClickhouseResponse
+	28,  // 133: govnocloud.v0.ClickhouseService.GetClickhouse:output_type -> govnocloud.v0.Clickhouse
+	28,  // 134: govnocloud.v0.ClickhouseService.CreateClickhouse:output_type -> govnocloud.v0.Clickhouse
+	1,   // 135: govnocloud.v0.ClickhouseService.DeleteClickhouse:output_type -> govnocloud.v0.Empty
+	30,  // 136: govnocloud.v0.ClickhouseService.WatchClickhouse:output_type -> govnocloud.v0.ClickhouseEvent
+	32,  // 137: govnocloud.v0.LLMService.ListLLMs:output_type -> govnocloud.v0.ListLLMsResponse
+	31,  // 138: govnocloud.v0.LLMService.GetLLM:output_type -> govnocloud.v0.LLM
+	31,  // 139: govnocloud.v0.LLMService.CreateLLM:output_type -> govnocloud.v0.LLM
+	1,   // 140: govnocloud.v0.LLMService.DeleteLLM:output_type -> govnocloud.v0.Empty
+	33,  // 141: govnocloud.v0.LLMService.WatchLLMs:output_type -> govnocloud.v0.LLMEvent
+	35,  // 142: govnocloud.v0.NamespaceService.ListNamespaces:output_type -> govnocloud.v0.ListNamespacesResponse
+	34,  // 143: govnocloud.v0.NamespaceService.GetNamespace:output_type -> govnocloud.v0.Namespace
+	34,  // 144: govnocloud.v0.NamespaceService.CreateNamespace:output_type -> govnocloud.v0.Namespace
+	1,   // 145: govnocloud.v0.NamespaceService.DeleteNamespace:output_type -> govnocloud.v0.Empty
+	36,  // 146: govnocloud.v0.NamespaceService.WatchNamespaces:output_type -> govnocloud.v0.NamespaceEvent
+	39,  // 147: govnocloud.v0.NodeService.ListNodes:output_type -> govnocloud.v0.ListNodesResponse
+	37,  // 148: govnocloud.v0.NodeService.GetNode:output_type -> govnocloud.v0.Node
+	37,  // 149: govnocloud.v0.NodeService.AddNode:output_type -> govnocloud.v0.Node
+	1,   // 150: govnocloud.v0.NodeService.DeleteNode:output_type -> govnocloud.v0.Empty
+	1,   // 151: govnocloud.v0.NodeService.RestartNode:output_type -> govnocloud.v0.Empty
+	1,   // 152: govnocloud.v0.NodeService.SuspendNode:output_type -> govnocloud.v0.Empty
+	1,   // 153: govnocloud.v0.NodeService.ResumeNode:output_type -> govnocloud.v0.Empty
+	1,   // 154: govnocloud.v0.NodeService.UpgradeNode:output_type -> govnocloud.v0.Empty
+	40,  // 155: govnocloud.v0.NodeService.WatchNodes:output_type -> govnocloud.v0.NodeEvent
+	45,  // 156: govnocloud.v0.UserService.ListUsers:output_type -> govnocloud.v0.ListUsersResponse
+	41,  // 157: govnocloud.v0.UserService.GetUser:output_type -> govnocloud.v0.User
+	41,  // 158: govnocloud.v0.UserService.CreateUser:output_type -> govnocloud.v0.User
+	1,   // 159: govnocloud.v0.UserService.DeleteUser:output_type -> govnocloud.v0.Empty
+	1,   // 160: govnocloud.v0.UserService.SetUserPassword:output_type -> govnocloud.v0.Empty
+	41,  // 161: govnocloud.v0.UserService.AddNamespaceToUser:output_type -> govnocloud.v0.User
+	41,  // 162: govnocloud.v0.UserService.RemoveNamespaceFromUser:output_type -> govnocloud.v0.User
+	46,  // 163: govnocloud.v0.UserService.WatchUsers:output_type -> govnocloud.v0.UserEvent
+	100, // [100:164] is the sub-list for method output_type
+	36,  // [36:100] is the sub-list for method input_type
+	36,  // [36:36] is the sub-list for extension type_name
+	36,  // [36:36] is the sub-list for extension extendee
+	0,   // [0:36] is the sub-list for field type_name
+}
+
+func init() { file_pkg_api_govnocloud_proto_init() }
+func file_pkg_api_govnocloud_proto_init() {
+	if File_pkg_api_govnocloud_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_pkg_api_govnocloud_proto_rawDesc), len(file_pkg_api_govnocloud_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   46,
+			NumExtensions: 0,
+			NumServices:   10,
+		},
+		GoTypes:           file_pkg_api_govnocloud_proto_goTypes,
+		DependencyIndexes: file_pkg_api_govnocloud_proto_depIdxs,
+		EnumInfos:         file_pkg_api_govnocloud_proto_enumTypes,
+		MessageInfos:      file_pkg_api_govnocloud_proto_msgTypes,
+	}.Build()
+	File_pkg_api_govnocloud_proto = out.File
+	file_pkg_api_govnocloud_proto_goTypes = nil
+	file_pkg_api_govnocloud_proto_depIdxs = nil
+}
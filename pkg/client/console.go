@@ -0,0 +1,38 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+// openVMStream opens a websocket to a VM subresource proxied by the server
+func (c *Client) openVMStream(name, namespace, subresource string) (*websocket.Conn, error) {
+	url := strings.Replace(fmt.Sprintf("%s/vms/%s/%s/%s", c.baseURL, namespace, name, subresource), "http", "ws", 1)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.SetBasicAuth(c.username, c.password)
+	dialer := websocket.Dialer{Subprotocols: []string{"plain.kubevirt.io"}}
+	conn, resp, err := dialer.Dial(url, req.Header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("error opening %s of VM: status=%s: %w", subresource, resp.Status, err)
+		}
+		return nil, fmt.Errorf("error opening %s of VM: %w", subresource, err)
+	}
+	return conn, nil
+}
+
+// ConsoleVM opens the serial console of a VM. Messages carry raw terminal bytes in both directions.
+func (c *Client) ConsoleVM(name, namespace string) (*websocket.Conn, error) {
+	return c.openVMStream(name, namespace, "console")
+}
+
+// VNCVM opens the VNC display of a VM. Messages carry the raw RFB protocol in both directions.
+func (c *Client) VNCVM(name, namespace string) (*websocket.Conn, error) {
+	return c.openVMStream(name, namespace, "vnc")
+}
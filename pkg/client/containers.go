@@ -162,3 +162,41 @@ func (c *Client) DeleteContainer(name, namespace string) error {
 
 	return nil
 }
+
+// containerPower runs a power action on a container.
+func (c *Client) containerPower(name, namespace, action string) error {
+	url := fmt.Sprintf("%s/containers/%s/%s/%s", c.baseURL, namespace, name, action)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error running %s on container: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error running %s on container: status=%s body=%s", action, resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// StartContainer starts a stopped container.
+func (c *Client) StartContainer(name, namespace string) error {
+	return c.containerPower(name, namespace, "start")
+}
+
+// StopContainer stops a container, deleting its pod until it is started again.
+func (c *Client) StopContainer(name, namespace string) error {
+	return c.containerPower(name, namespace, "stop")
+}
+
+// RestartContainer recreates the pod of a container.
+func (c *Client) RestartContainer(name, namespace string) error {
+	return c.containerPower(name, namespace, "restart")
+}
@@ -33,6 +33,24 @@ func TestGetContainer(t *testing.T) {
 	t.Logf("Container: %v", container)
 }
 
+// TestStopStartContainer tests the StopContainer and StartContainer functions.
+func TestStopStartContainer(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.StopContainer("test-container", testNamespace); err != nil {
+		t.Fatalf("error stopping container: %v", err)
+	}
+	container, err := cli.GetContainer("test-container", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting container: %v", err)
+	}
+	if !container.Stopped {
+		t.Errorf("container is not stopped: %v", container)
+	}
+	if err := cli.StartContainer("test-container", testNamespace); err != nil {
+		t.Fatalf("error starting container: %v", err)
+	}
+}
+
 // TestDeleteContainer tests the DeleteContainer function.
 func TestDeleteContainer(t *testing.T) {
 	cli := setupTestClient(t)
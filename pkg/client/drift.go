@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// GetDrift retrieves the last drift report, limited to a namespace unless namespace is empty
+func (c *Client) GetDrift(namespace string) (*types.DriftReport, error) {
+	reqURL := fmt.Sprintf("%s/drift", c.baseURL)
+	if namespace != "" {
+		reqURL += "?namespace=" + url.QueryEscape(namespace)
+	}
+	req, err := http.NewRequest("GET", reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting drift: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error getting drift: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var report types.DriftReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("error decoding drift report: %w", err)
+	}
+
+	return &report, nil
+}
+
+// HealDrift checks for drift now and re-applies drifted resources from their stored spec
+func (c *Client) HealDrift() (*types.DriftReport, error) {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/drift/heal", c.baseURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error healing drift: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error healing drift: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var report types.DriftReport
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, fmt.Errorf("error decoding drift report: %w", err)
+	}
+
+	return &report, nil
+}
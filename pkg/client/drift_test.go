@@ -0,0 +1,28 @@
+package client_test
+
+import (
+	"testing"
+)
+
+func TestGetDrift(t *testing.T) {
+	cli := setupTestClient(t)
+	report, err := cli.GetDrift("")
+	if err != nil {
+		t.Fatalf("error getting drift: %v", err)
+	}
+	t.Logf("drift: %v", report)
+}
+
+func TestHealDrift(t *testing.T) {
+	cli := setupTestClient(t)
+	report, err := cli.HealDrift()
+	if err != nil {
+		t.Fatalf("error healing drift: %v", err)
+	}
+	for _, drift := range report.Drifts {
+		if !drift.Healed && drift.Error == "" {
+			t.Errorf("drift of %s/%s was not healed", drift.Namespace, drift.Name)
+		}
+	}
+	t.Logf("drift: %v", report)
+}
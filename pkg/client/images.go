@@ -0,0 +1,190 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// ListImages lists the images visible to the user, only those usable in namespace when it is not empty.
+func (c *Client) ListImages(namespace string) ([]types.VMImage, error) {
+	endpoint := fmt.Sprintf("%s/images", c.baseURL)
+	if namespace != "" {
+		endpoint += "?namespace=" + url.QueryEscape(namespace)
+	}
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error listing images: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error listing images: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var images []types.VMImage
+	if err := json.NewDecoder(resp.Body).Decode(&images); err != nil {
+		return nil, fmt.Errorf("error decoding images: %w", err)
+	}
+	return images, nil
+}
+
+// GetImage gets an image with its import phase.
+func (c *Client) GetImage(name string) (*types.VMImage, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/images/%s", c.baseURL, name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error getting image: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var image types.VMImage
+	if err := json.NewDecoder(resp.Body).Decode(&image); err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+	return &image, nil
+}
+
+// CreateImage registers an image by containerdisk (Image) or by the URL of a qcow2, raw or ISO file.
+// An image without namespace is public and needs admin access.
+func (c *Client) CreateImage(image types.VMImage) (*types.VMImage, error) {
+	data, err := json.Marshal(image)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling image: %w", err)
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/images/%s", c.baseURL, image.Name), bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error creating image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error creating image: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var created types.VMImage
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+	return &created, nil
+}
+
+// UploadImage uploads a qcow2, raw or ISO file as an image, its metadata taken from image.
+func (c *Client) UploadImage(image types.VMImage, path string) (*types.VMImage, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening image file: %w", err)
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("error reading image file: %w", err)
+	}
+
+	query := url.Values{}
+	for key, value := range map[string]string{
+		"namespace":   image.Namespace,
+		"format":      image.Format,
+		"checksum":    image.Checksum,
+		"size":        image.Size,
+		"os":          image.OS,
+		"osVersion":   image.OSVersion,
+		"description": image.Description,
+	} {
+		if value != "" {
+			query.Set(key, value)
+		}
+	}
+	if devices := image.Devices; devices != nil {
+		for key, value := range map[string]string{
+			"firmware":       devices.Firmware,
+			"diskBus":        devices.DiskBus,
+			"interfaceModel": devices.InterfaceModel,
+			"provisioning":   devices.Provisioning,
+		} {
+			if value != "" {
+				query.Set(key, value)
+			}
+		}
+		if devices.TPM {
+			query.Set("tpm", "true")
+		}
+	}
+	endpoint := fmt.Sprintf("%s/images/%s/upload?%s", c.baseURL, image.Name, query.Encode())
+	req, err := http.NewRequest("POST", endpoint, file)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.SetBasicAuth(c.username, c.password)
+	// uploads outlast the timeout of the regular client
+	uploader := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := uploader.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error uploading image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error uploading image: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var uploaded types.VMImage
+	if err := json.NewDecoder(resp.Body).Decode(&uploaded); err != nil {
+		return nil, fmt.Errorf("error decoding image: %w", err)
+	}
+	return &uploaded, nil
+}
+
+// DeleteImage deletes an image with its disk.
+func (c *Client) DeleteImage(name string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/images/%s", c.baseURL, name), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error deleting image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error deleting image: status=%s body=%s", resp.Status, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+const testImageName = "test-image"
+
+func TestCreateImage(t *testing.T) {
+	cli := setupTestClient(t)
+	image, err := cli.CreateImage(types.VMImage{
+		Name:      testImageName,
+		Image:     "quay.io/containerdisks/debian:12",
+		Namespace: testNamespace,
+		OS:        "linux",
+		OSVersion: "debian 12",
+	})
+	if err != nil {
+		t.Fatalf("error creating image: %v", err)
+	}
+	if image.Phase != types.ImagePhaseReady {
+		t.Errorf("expected containerdisk image to be ready, got %s", image.Phase)
+	}
+}
+
+func TestListImages(t *testing.T) {
+	cli := setupTestClient(t)
+	images, err := cli.ListImages(testNamespace)
+	if err != nil {
+		t.Fatalf("error listing images: %v", err)
+	}
+	found := false
+	for _, image := range images {
+		if image.Name == testImageName {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("image %s not listed: %v", testImageName, images)
+	}
+}
+
+func TestGetImage(t *testing.T) {
+	cli := setupTestClient(t)
+	image, err := cli.GetImage("ubuntu24")
+	if err != nil {
+		t.Fatalf("error getting image: %v", err)
+	}
+	t.Logf("image: %v", image)
+}
+
+func TestWindowsImageDevices(t *testing.T) {
+	cli := setupTestClient(t)
+	image, err := cli.CreateImage(types.VMImage{
+		Name:      "test-windows",
+		Image:     "quay.io/containerdisks/debian:12",
+		Namespace: testNamespace,
+		OS:        "windows",
+		Devices:   &types.VMDevices{TPM: true, DiskBus: types.VMDiskBusSATA},
+	})
+	if err != nil {
+		t.Fatalf("error creating image: %v", err)
+	}
+	defer cli.DeleteImage("test-windows")
+	if image.Devices == nil || image.Devices.Firmware != types.VMFirmwareUEFI ||
+		image.Devices.Provisioning != types.VMProvisioningSysprep || !image.Devices.TPM {
+		t.Errorf("expected a Windows image to default to UEFI and sysprep, got %+v", image.Devices)
+	}
+	vm := types.VM{Name: "test-windows-vm", Image: "test-windows", Size: "small", Namespace: testNamespace, UserData: "#cloud-config"}
+	if err := cli.CreateVMFromSpec(vm); err == nil {
+		cli.DeleteVM(vm.Name, testNamespace)
+		t.Errorf("expected cloud-init user-data to be refused for a sysprep image")
+	}
+}
+
+func TestDeleteImage(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.DeleteImage(testImageName); err != nil {
+		t.Fatalf("error deleting image: %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// MigrateVM live-migrates a running VM to node, or to a node the scheduler picks when node is empty.
+func (c *Client) MigrateVM(name, namespace, node string) (*types.VMMigration, error) {
+	endpoint := fmt.Sprintf("%s/vms/%s/%s/migrate", c.baseURL, namespace, name)
+	if node != "" {
+		endpoint += "?node=" + url.QueryEscape(node)
+	}
+	req, err := http.NewRequest("POST", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error migrating VM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error migrating VM: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var migration types.VMMigration
+	if err := json.NewDecoder(resp.Body).Decode(&migration); err != nil {
+		return nil, fmt.Errorf("error decoding migration: %w", err)
+	}
+	return &migration, nil
+}
+
+// ListVMMigrations lists the migrations of a VM with their progress.
+func (c *Client) ListVMMigrations(name, namespace string) ([]types.VMMigration, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/vms/%s/%s/migrations", c.baseURL, namespace, name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error listing migrations: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error listing migrations: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var migrations []types.VMMigration
+	if err := json.NewDecoder(resp.Body).Decode(&migrations); err != nil {
+		return nil, fmt.Errorf("error decoding migrations: %w", err)
+	}
+	return migrations, nil
+}
+
+// GetVMMigration gets a migration of a VM with its progress.
+func (c *Client) GetVMMigration(name, namespace, migration string) (*types.VMMigration, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/vms/%s/%s/migrations/%s", c.baseURL, namespace, name, migration), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting migration: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error getting migration: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var m types.VMMigration
+	if err := json.NewDecoder(resp.Body).Decode(&m); err != nil {
+		return nil, fmt.Errorf("error decoding migration: %w", err)
+	}
+	return &m, nil
+}
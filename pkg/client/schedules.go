@@ -0,0 +1,83 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// scheduleURL returns the URL of the schedules of a namespace, or of one of them
+func (c *Client) scheduleURL(namespace, name string) string {
+	url := fmt.Sprintf("%s/schedules/%s", c.baseURL, namespace)
+	if name != "" {
+		url += "/" + name
+	}
+	return url
+}
+
+// doScheduleRequest sends a schedule request with in as its body unless in is nil, and decodes its response into out unless out is nil
+func (c *Client) doScheduleRequest(method, url, action string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("error marshaling schedule: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error %s: status=%s body=%s", action, resp.Status, string(body))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListSchedules lists the schedules of a namespace with their last and next runs.
+func (c *Client) ListSchedules(namespace string) ([]types.Schedule, error) {
+	var schedules []types.Schedule
+	if err := c.doScheduleRequest(http.MethodGet, c.scheduleURL(namespace, ""), "listing schedules", nil, &schedules); err != nil {
+		return nil, err
+	}
+	return schedules, nil
+}
+
+// GetSchedule gets a schedule.
+func (c *Client) GetSchedule(namespace, name string) (*types.Schedule, error) {
+	var schedule types.Schedule
+	if err := c.doScheduleRequest(http.MethodGet, c.scheduleURL(namespace, name), "getting schedule", nil, &schedule); err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+// PutSchedule creates or replaces a schedule.
+func (c *Client) PutSchedule(schedule types.Schedule) error {
+	return c.doScheduleRequest(http.MethodPut, c.scheduleURL(schedule.Namespace, schedule.Name), "storing schedule", schedule, nil)
+}
+
+// DeleteSchedule deletes a schedule.
+func (c *Client) DeleteSchedule(namespace, name string) error {
+	return c.doScheduleRequest(http.MethodDelete, c.scheduleURL(namespace, name), "deleting schedule", nil, nil)
+}
@@ -0,0 +1,42 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+func TestSchedules(t *testing.T) {
+	cli := setupTestClient(t)
+	schedule := types.Schedule{
+		Name:      "test-schedule",
+		Namespace: testNamespace,
+		Kind:      types.ScheduleKindVM,
+		Targets:   []string{"test-vm"},
+		Action:    types.ScheduleActionStop,
+		Cron:      "0 20 * * 1-5",
+		Timezone:  "Europe/Berlin",
+	}
+	if err := cli.PutSchedule(schedule); err != nil {
+		t.Fatalf("error storing schedule: %v", err)
+	}
+	defer cli.DeleteSchedule(testNamespace, "test-schedule")
+	got, err := cli.GetSchedule(testNamespace, "test-schedule")
+	if err != nil {
+		t.Fatalf("error getting schedule: %v", err)
+	}
+	if got.Cron != schedule.Cron || got.NextRun == nil {
+		t.Errorf("unexpected schedule: %+v", got)
+	}
+	schedules, err := cli.ListSchedules(testNamespace)
+	if err != nil {
+		t.Fatalf("error listing schedules: %v", err)
+	}
+	if len(schedules) == 0 {
+		t.Errorf("schedule test-schedule not listed")
+	}
+	schedule.Cron = "61 * * * *"
+	if err := cli.PutSchedule(schedule); err == nil {
+		t.Errorf("expected an invalid cron expression to be rejected")
+	}
+}
@@ -0,0 +1,117 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// Kinds of sizes, the path segment of their endpoints
+const (
+	SizeKindVM       = "vms"
+	SizeKindPostgres = "postgres"
+)
+
+// sizeURL returns the URL of the sizes of a kind, or of one of them
+func (c *Client) sizeURL(kind, name string) string {
+	url := fmt.Sprintf("%s/sizes/%s", c.baseURL, kind)
+	if name != "" {
+		url += "/" + name
+	}
+	return url
+}
+
+// doSizeRequest sends a size request with in as its body unless in is nil, and decodes its response into out unless out is nil
+func (c *Client) doSizeRequest(method, url, action string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("error marshaling size: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error %s: status=%s body=%s", action, resp.Status, string(body))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListVMSizes lists the VM sizes, retired ones included.
+func (c *Client) ListVMSizes() ([]types.VMSize, error) {
+	var sizes []types.VMSize
+	if err := c.doSizeRequest(http.MethodGet, c.sizeURL(SizeKindVM, ""), "listing sizes", nil, &sizes); err != nil {
+		return nil, err
+	}
+	return sizes, nil
+}
+
+// GetVMSize gets a VM size.
+func (c *Client) GetVMSize(name string) (*types.VMSize, error) {
+	var size types.VMSize
+	if err := c.doSizeRequest(http.MethodGet, c.sizeURL(SizeKindVM, name), "getting size", nil, &size); err != nil {
+		return nil, err
+	}
+	return &size, nil
+}
+
+// PutVMSize defines or edits a VM size, retire it by setting Retired. Needs admin access.
+func (c *Client) PutVMSize(size types.VMSize) error {
+	return c.doSizeRequest(http.MethodPut, c.sizeURL(SizeKindVM, size.Name), "storing size", size, nil)
+}
+
+// DeleteVMSize deletes a VM size no VM uses. Needs admin access.
+func (c *Client) DeleteVMSize(name string) error {
+	return c.doSizeRequest(http.MethodDelete, c.sizeURL(SizeKindVM, name), "deleting size", nil, nil)
+}
+
+// ListPostgresSizes lists the postgres sizes, retired ones included.
+func (c *Client) ListPostgresSizes() ([]types.PostgresSize, error) {
+	var sizes []types.PostgresSize
+	if err := c.doSizeRequest(http.MethodGet, c.sizeURL(SizeKindPostgres, ""), "listing sizes", nil, &sizes); err != nil {
+		return nil, err
+	}
+	return sizes, nil
+}
+
+// GetPostgresSize gets a postgres size.
+func (c *Client) GetPostgresSize(name string) (*types.PostgresSize, error) {
+	var size types.PostgresSize
+	if err := c.doSizeRequest(http.MethodGet, c.sizeURL(SizeKindPostgres, name), "getting size", nil, &size); err != nil {
+		return nil, err
+	}
+	return &size, nil
+}
+
+// PutPostgresSize defines or edits a postgres size, retire it by setting Retired. Needs admin access.
+func (c *Client) PutPostgresSize(size types.PostgresSize) error {
+	return c.doSizeRequest(http.MethodPut, c.sizeURL(SizeKindPostgres, size.Name), "storing size", size, nil)
+}
+
+// DeletePostgresSize deletes a postgres size no database uses. Needs admin access.
+func (c *Client) DeletePostgresSize(name string) error {
+	return c.doSizeRequest(http.MethodDelete, c.sizeURL(SizeKindPostgres, name), "deleting size", nil, nil)
+}
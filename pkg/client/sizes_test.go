@@ -0,0 +1,77 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+const testSizeName = "test-size"
+
+func TestPutVMSize(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.PutVMSize(types.VMSize{Name: testSizeName, CPU: 2, RAM: 3072, Disk: 15}); err != nil {
+		t.Fatalf("error storing size: %v", err)
+	}
+	size, err := cli.GetVMSize(testSizeName)
+	if err != nil {
+		t.Fatalf("error getting size: %v", err)
+	}
+	if size.CPU != 2 || size.RAM != 3072 || size.Disk != 15 {
+		t.Errorf("size = %+v, want 2 cpu, 3072 ram, 15 disk", size)
+	}
+}
+
+func TestListVMSizes(t *testing.T) {
+	cli := setupTestClient(t)
+	sizes, err := cli.ListVMSizes()
+	if err != nil {
+		t.Fatalf("error listing sizes: %v", err)
+	}
+	found := false
+	for _, size := range sizes {
+		if size.Name == testSizeName {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("size %s not listed: %v", testSizeName, sizes)
+	}
+}
+
+func TestRetireVMSize(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.PutVMSize(types.VMSize{Name: testSizeName, CPU: 2, RAM: 3072, Disk: 15, Retired: true}); err != nil {
+		t.Fatalf("error retiring size: %v", err)
+	}
+	if err := cli.CreateVM("test-size-vm", "ubuntu24", testSizeName, testNamespace); err == nil {
+		t.Errorf("expected creating a VM of a retired size to fail")
+	}
+}
+
+func TestDeleteVMSize(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.DeleteVMSize(testSizeName); err != nil {
+		t.Fatalf("error deleting size: %v", err)
+	}
+	if _, err := cli.GetVMSize(testSizeName); err == nil {
+		t.Errorf("expected size %s to be deleted", testSizeName)
+	}
+}
+
+func TestPostgresSizes(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.PutPostgresSize(types.PostgresSize{Name: testSizeName, CPU: 1, RAM: 512}); err != nil {
+		t.Fatalf("error storing postgres size: %v", err)
+	}
+	sizes, err := cli.ListPostgresSizes()
+	if err != nil {
+		t.Fatalf("error listing postgres sizes: %v", err)
+	}
+	if len(sizes) == 0 {
+		t.Errorf("no postgres sizes listed")
+	}
+	if err := cli.DeletePostgresSize(testSizeName); err != nil {
+		t.Fatalf("error deleting postgres size: %v", err)
+	}
+}
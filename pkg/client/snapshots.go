@@ -0,0 +1,83 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// snapshotURL returns the URL of the snapshots of a VM, or of one of them
+func (c *Client) snapshotURL(name, namespace, snapshot string) string {
+	url := fmt.Sprintf("%s/vms/%s/%s/snapshots", c.baseURL, namespace, name)
+	if snapshot != "" {
+		url += "/" + snapshot
+	}
+	return url
+}
+
+// doSnapshotRequest sends a snapshot request and decodes its response into out unless out is nil
+func (c *Client) doSnapshotRequest(method, url, action string, out any) error {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error %s: status=%s body=%s", action, resp.Status, string(body))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+	return nil
+}
+
+// CreateVMSnapshot takes a snapshot of a VM and its disks.
+func (c *Client) CreateVMSnapshot(name, namespace, snapshot string) error {
+	return c.doSnapshotRequest(http.MethodPost, c.snapshotURL(name, namespace, snapshot), "creating snapshot", nil)
+}
+
+// ListVMSnapshots lists the snapshots of a VM.
+func (c *Client) ListVMSnapshots(name, namespace string) ([]types.VMSnapshot, error) {
+	var snapshots []types.VMSnapshot
+	if err := c.doSnapshotRequest(http.MethodGet, c.snapshotURL(name, namespace, ""), "listing snapshots", &snapshots); err != nil {
+		return nil, err
+	}
+	return snapshots, nil
+}
+
+// GetVMSnapshot gets a snapshot of a VM with its status and size.
+func (c *Client) GetVMSnapshot(name, namespace, snapshot string) (*types.VMSnapshot, error) {
+	var s types.VMSnapshot
+	if err := c.doSnapshotRequest(http.MethodGet, c.snapshotURL(name, namespace, snapshot), "getting snapshot", &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// DeleteVMSnapshot deletes a snapshot of a VM.
+func (c *Client) DeleteVMSnapshot(name, namespace, snapshot string) error {
+	return c.doSnapshotRequest(http.MethodDelete, c.snapshotURL(name, namespace, snapshot), "deleting snapshot", nil)
+}
+
+// RestoreVMSnapshot restores a stopped VM from a snapshot.
+func (c *Client) RestoreVMSnapshot(name, namespace, snapshot string) (*types.VMRestore, error) {
+	var restore types.VMRestore
+	if err := c.doSnapshotRequest(http.MethodPost, c.snapshotURL(name, namespace, snapshot)+"/restore", "restoring snapshot", &restore); err != nil {
+		return nil, err
+	}
+	return &restore, nil
+}
@@ -0,0 +1,109 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// ListSSHKeys lists the SSH keys of the user.
+func (c *Client) ListSSHKeys() ([]types.SSHKey, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/sshkeys", c.baseURL), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error listing SSH keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error listing SSH keys: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var keys []types.SSHKey
+	if err := json.NewDecoder(resp.Body).Decode(&keys); err != nil {
+		return nil, fmt.Errorf("error decoding SSH keys: %w", err)
+	}
+	return keys, nil
+}
+
+// AddSSHKey adds an SSH public key in authorized_keys format to the user's registry.
+func (c *Client) AddSSHKey(name, publicKey string) error {
+	data, err := json.Marshal(types.SSHKey{Name: name, PublicKey: publicKey})
+	if err != nil {
+		return fmt.Errorf("error marshaling SSH key: %w", err)
+	}
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/sshkeys/%s", c.baseURL, name), bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error adding SSH key: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error adding SSH key: status=%s body=%s", resp.Status, string(body))
+	}
+	return nil
+}
+
+// GetSSHKey gets one of the user's SSH keys.
+func (c *Client) GetSSHKey(name string) (*types.SSHKey, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/sshkeys/%s", c.baseURL, name), nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting SSH key: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error getting SSH key: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var key types.SSHKey
+	if err := json.NewDecoder(resp.Body).Decode(&key); err != nil {
+		return nil, fmt.Errorf("error decoding SSH key: %w", err)
+	}
+	return &key, nil
+}
+
+// DeleteSSHKey deletes one of the user's SSH keys.
+func (c *Client) DeleteSSHKey(name string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/sshkeys/%s", c.baseURL, name), nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error deleting SSH key: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error deleting SSH key: status=%s body=%s", resp.Status, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package client_test
+
+import (
+	"testing"
+)
+
+const (
+	testSSHKeyName = "test-sshkey"
+	testSSHKey     = "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIGtQUDZWhs8k/cZcykMkaUX8bvJ2A9qXCrvq39hgDGPV test@govnocloud"
+)
+
+func TestAddSSHKey(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.AddSSHKey(testSSHKeyName, testSSHKey); err != nil {
+		t.Fatalf("error adding SSH key: %v", err)
+	}
+}
+
+func TestGetSSHKey(t *testing.T) {
+	cli := setupTestClient(t)
+	key, err := cli.GetSSHKey(testSSHKeyName)
+	if err != nil {
+		t.Fatalf("error getting SSH key: %v", err)
+	}
+	if key.Fingerprint == "" {
+		t.Errorf("SSH key %s has no fingerprint", testSSHKeyName)
+	}
+	t.Logf("SSH key: %v", key)
+}
+
+func TestListSSHKeys(t *testing.T) {
+	cli := setupTestClient(t)
+	keys, err := cli.ListSSHKeys()
+	if err != nil {
+		t.Fatalf("error listing SSH keys: %v", err)
+	}
+	t.Logf("SSH keys: %v", keys)
+}
+
+func TestDeleteSSHKey(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.DeleteSSHKey(testSSHKeyName); err != nil {
+		t.Fatalf("error deleting SSH key: %v", err)
+	}
+}
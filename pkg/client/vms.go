@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/rusik69/govnocloud2/pkg/types"
@@ -14,13 +15,17 @@ import (
 
 // CreateVM creates a VM.
 func (c *Client) CreateVM(name, image, size, namespace string) error {
-	vm := types.VM{
+	return c.CreateVMFromSpec(types.VM{
 		Name:      name,
 		Image:     image,
 		Size:      size,
 		Namespace: namespace,
-	}
+	})
+}
 
+// CreateVMFromSpec creates a VM with its cloud-init settings.
+func (c *Client) CreateVMFromSpec(vm types.VM) error {
+	name, namespace := vm.Name, vm.Namespace
 	data, err := json.Marshal(vm)
 	if err != nil {
 		return fmt.Errorf("error marshaling VM: %w", err)
@@ -51,8 +56,8 @@ func (c *Client) CreateVM(name, image, size, namespace string) error {
 	return nil
 }
 
-// ListVMs lists VMs.
-func (c *Client) ListVMs(namespace string) ([]string, error) {
+// ListVMs lists the VMs of a namespace with their node, addresses and status.
+func (c *Client) ListVMs(namespace string) ([]types.VM, error) {
 	url := fmt.Sprintf("%s/vms/%s", c.baseURL, namespace)
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
@@ -71,7 +76,7 @@ func (c *Client) ListVMs(namespace string) ([]string, error) {
 		return nil, fmt.Errorf("error listing VMs: status=%s body=%s", resp.Status, string(body))
 	}
 
-	var vms []string
+	var vms []types.VM
 	if err := json.NewDecoder(resp.Body).Decode(&vms); err != nil {
 		return nil, fmt.Errorf("error decoding VMs: %w", err)
 	}
@@ -109,7 +114,12 @@ func (c *Client) GetVM(name, namespace string) (*types.VM, error) {
 
 // DeleteVM deletes a VM.
 func (c *Client) DeleteVM(name, namespace string) error {
-	url := fmt.Sprintf("%s/vms/%s/%s", c.baseURL, namespace, name)
+	return c.DeleteVMWithDisk(name, namespace, false)
+}
+
+// DeleteVMWithDisk deletes a VM, keeping its root disk for a later VM with the same name when retainDisk is set.
+func (c *Client) DeleteVMWithDisk(name, namespace string, retainDisk bool) error {
+	url := fmt.Sprintf("%s/vms/%s/%s?retainDisk=%t", c.baseURL, namespace, name, retainDisk)
 	req, err := http.NewRequest(http.MethodDelete, url, nil)
 	if err != nil {
 		return fmt.Errorf("error creating delete request: %w", err)
@@ -221,3 +231,300 @@ func (c *Client) RestartVM(name, namespace string) error {
 
 	return nil
 }
+
+// GetVMPorts gets the ports of a VM with their allocated node ports and endpoints.
+func (c *Client) GetVMPorts(name, namespace string) (*types.VMPorts, error) {
+	url := fmt.Sprintf("%s/vms/%s/%s/ports", c.baseURL, namespace, name)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting VM ports: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error getting VM ports: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var ports types.VMPorts
+	if err := json.NewDecoder(resp.Body).Decode(&ports); err != nil {
+		return nil, fmt.Errorf("error decoding VM ports: %w", err)
+	}
+
+	return &ports, nil
+}
+
+// SetVMPorts replaces the ports of a VM.
+func (c *Client) SetVMPorts(name, namespace string, ports types.VMPorts) error {
+	data, err := json.Marshal(ports)
+	if err != nil {
+		return fmt.Errorf("error marshaling VM ports: %w", err)
+	}
+	url := fmt.Sprintf("%s/vms/%s/%s/ports", c.baseURL, namespace, name)
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error setting VM ports: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error setting VM ports: status=%s body=%s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// SetVMRunPolicy changes the run strategy and idle timeout of a VM, Halted stops it and Always or RerunOnFailure start
+// it, and returns its effective run policy.
+func (c *Client) SetVMRunPolicy(name, namespace string, policy types.VMRunPolicy) (*types.VMRunPolicy, error) {
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling VM run policy: %w", err)
+	}
+	url := fmt.Sprintf("%s/vms/%s/%s/run", c.baseURL, namespace, name)
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error setting VM run policy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error setting VM run policy: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var effective types.VMRunPolicy
+	if err := json.NewDecoder(resp.Body).Decode(&effective); err != nil {
+		return nil, fmt.Errorf("error decoding VM run policy: %w", err)
+	}
+	return &effective, nil
+}
+
+// ResizeVM changes the size of a VM to another size or to custom CPU and RAM.
+func (c *Client) ResizeVM(name, namespace string, resize types.VMResize) (*types.VMResizeResult, error) {
+	data, err := json.Marshal(resize)
+	if err != nil {
+		return nil, fmt.Errorf("error marshaling VM size: %w", err)
+	}
+	url := fmt.Sprintf("%s/vms/%s/%s/size", c.baseURL, namespace, name)
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error resizing VM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error resizing VM: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var result types.VMResizeResult
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("error decoding resize result: %w", err)
+	}
+	return &result, nil
+}
+
+// AttachVMVolume attaches a volume to a VM, hotplugging it when the VM is running.
+func (c *Client) AttachVMVolume(name, namespace, volume string) error {
+	url := fmt.Sprintf("%s/vms/%s/%s/volumes/%s", c.baseURL, namespace, name, volume)
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error attaching volume: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error attaching volume: status=%s body=%s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// DetachVMVolume detaches a volume from a VM.
+func (c *Client) DetachVMVolume(name, namespace, volume string) error {
+	url := fmt.Sprintf("%s/vms/%s/%s/volumes/%s", c.baseURL, namespace, name, volume)
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error detaching volume: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error detaching volume: status=%s body=%s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// CloneVM creates the VM target from the root disk and spec of a VM.
+func (c *Client) CloneVM(name, namespace, target string) error {
+	url := fmt.Sprintf("%s/vms/%s/%s/clone/%s", c.baseURL, namespace, name, target)
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error cloning VM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error cloning VM: status=%s body=%s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// ExportVMImage registers the root disk of a VM as an image VMs can be created from.
+func (c *Client) ExportVMImage(name, namespace, image string) (*types.VMImage, error) {
+	url := fmt.Sprintf("%s/vms/%s/%s/image/%s", c.baseURL, namespace, name, image)
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error exporting VM image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error exporting VM image: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var exported types.VMImage
+	if err := json.NewDecoder(resp.Body).Decode(&exported); err != nil {
+		return nil, fmt.Errorf("error decoding VM image: %w", err)
+	}
+
+	return &exported, nil
+}
+
+// ExportVM downloads a stopped VM into w, as a tar archive of its spec and disks or as its qcow2 root disk.
+func (c *Client) ExportVM(name, namespace, format string, w io.Writer) error {
+	url := fmt.Sprintf("%s/vms/%s/%s/export?format=%s", c.baseURL, namespace, name, format)
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.SetBasicAuth(c.username, c.password)
+	// downloads outlast the timeout of the regular client
+	downloader := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := downloader.Do(req)
+	if err != nil {
+		return fmt.Errorf("error exporting VM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error exporting VM: status=%s body=%s", resp.Status, string(body))
+	}
+
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return fmt.Errorf("error downloading VM export: %w", err)
+	}
+	return nil
+}
+
+// ImportVM creates a VM from an export archive read from r.
+func (c *Client) ImportVM(name, namespace string, r io.Reader) error {
+	url := fmt.Sprintf("%s/vms/%s/%s/import", c.baseURL, namespace, name)
+	req, err := http.NewRequest("POST", url, r)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-tar")
+	req.SetBasicAuth(c.username, c.password)
+	// uploads outlast the timeout of the regular client
+	uploader := &http.Client{Transport: c.httpClient.Transport}
+	resp, err := uploader.Do(req)
+	if err != nil {
+		return fmt.Errorf("error importing VM: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error importing VM: status=%s body=%s", resp.Status, string(body))
+	}
+
+	return nil
+}
+
+// GetVMMetrics gets the CPU, memory, disk and network usage of a VM over a range such as 1h or 7d, 1h when empty.
+func (c *Client) GetVMMetrics(name, namespace, timeRange string) (*types.VMMetrics, error) {
+	endpoint := fmt.Sprintf("%s/vms/%s/%s/metrics", c.baseURL, namespace, name)
+	if timeRange != "" {
+		endpoint += "?range=" + url.QueryEscape(timeRange)
+	}
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting VM metrics: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("error getting VM metrics: status=%s body=%s", resp.Status, string(body))
+	}
+
+	var metrics types.VMMetrics
+	if err := json.NewDecoder(resp.Body).Decode(&metrics); err != nil {
+		return nil, fmt.Errorf("error decoding VM metrics: %w", err)
+	}
+	return &metrics, nil
+}
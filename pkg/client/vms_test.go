@@ -1,8 +1,14 @@
 package client_test
 
 import (
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/rusik69/govnocloud2/pkg/types"
 )
 
 func TestCreateVM(t *testing.T) {
@@ -30,6 +36,11 @@ func TestListVMs(t *testing.T) {
 	if len(vms) == 0 {
 		t.Fatalf("no VMs found")
 	}
+	for _, vm := range vms {
+		if vm.Name == "" || vm.Size == "" {
+			t.Errorf("VM reported without name or size: %+v", vm)
+		}
+	}
 	t.Logf("VMs: %v", vms)
 }
 
@@ -39,6 +50,9 @@ func TestGetVM(t *testing.T) {
 	if err != nil {
 		t.Fatalf("error getting VM: %v", err)
 	}
+	if vm.Status == "Running" && (vm.Node == "" || len(vm.Interfaces) == 0 || vm.StartedAt == nil) {
+		t.Errorf("running VM reported without node, interfaces or start time: %+v", vm)
+	}
 	t.Logf("VM: %v", vm)
 }
 
@@ -67,6 +81,297 @@ func TestRestartVM(t *testing.T) {
 	}
 }
 
+func TestSetVMPorts(t *testing.T) {
+	cli := setupTestClient(t)
+	ports := types.VMPorts{
+		ServiceType: types.VMServiceTypeNodePort,
+		Ports:       []types.VMPort{{Name: "ssh", SourcePort: 22, DestinationPort: 22}},
+	}
+	if err := cli.SetVMPorts("test-vm", testNamespace, ports); err != nil {
+		t.Fatalf("error setting VM ports: %v", err)
+	}
+}
+
+func TestGetVMPorts(t *testing.T) {
+	cli := setupTestClient(t)
+	ports, err := cli.GetVMPorts("test-vm", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting VM ports: %v", err)
+	}
+	if len(ports.Ports) != 1 || ports.Ports[0].Name != "ssh" {
+		t.Errorf("unexpected VM ports: %v", ports)
+	}
+	t.Logf("VM ports: %v", ports)
+}
+
+func TestAttachDetachVMVolume(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.CreateVolume("test-vm-data", testNamespace, "1Gi"); err != nil {
+		t.Fatalf("error creating volume: %v", err)
+	}
+	defer cli.DeleteVolume("test-vm-data", testNamespace)
+	if err := cli.AttachVMVolume("test-vm", testNamespace, "test-vm-data"); err != nil {
+		t.Fatalf("error attaching volume: %v", err)
+	}
+	vm, err := cli.GetVM("test-vm", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting VM: %v", err)
+	}
+	attached := false
+	for _, volume := range vm.Volumes {
+		if volume.Claim == "test-vm-data" {
+			attached = true
+		}
+	}
+	if !attached {
+		t.Errorf("volume test-vm-data is not attached: %v", vm.Volumes)
+	}
+	if err := cli.DetachVMVolume("test-vm", testNamespace, "test-vm-data"); err != nil {
+		t.Fatalf("error detaching volume: %v", err)
+	}
+}
+
+func TestConsoleVM(t *testing.T) {
+	cli := setupTestClient(t)
+	conn, err := cli.ConsoleVM("test-vm", testNamespace)
+	if err != nil {
+		t.Fatalf("error opening VM console: %v", err)
+	}
+	defer conn.Close()
+	if err := conn.WriteMessage(websocket.BinaryMessage, []byte("\r")); err != nil {
+		t.Fatalf("error writing to VM console: %v", err)
+	}
+}
+
+func TestVMSnapshots(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.CreateVMSnapshot("test-vm", testNamespace, "test-snapshot"); err != nil {
+		t.Fatalf("error creating snapshot: %v", err)
+	}
+	defer cli.DeleteVMSnapshot("test-vm", testNamespace, "test-snapshot")
+	snapshots, err := cli.ListVMSnapshots("test-vm", testNamespace)
+	if err != nil {
+		t.Fatalf("error listing snapshots: %v", err)
+	}
+	found := false
+	for _, snapshot := range snapshots {
+		if snapshot.Name == "test-snapshot" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("snapshot test-snapshot not listed: %v", snapshots)
+	}
+	snapshot, err := cli.GetVMSnapshot("test-vm", testNamespace, "test-snapshot")
+	if err != nil {
+		t.Fatalf("error getting snapshot: %v", err)
+	}
+	if snapshot.VM != "test-vm" {
+		t.Errorf("expected snapshot of test-vm, got %s", snapshot.VM)
+	}
+}
+
+func TestCloneVM(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.CloneVM("test-vm", testNamespace, "test-vm-clone"); err != nil {
+		t.Fatalf("error cloning VM: %v", err)
+	}
+	defer cli.DeleteVM("test-vm-clone", testNamespace)
+	vm, err := cli.GetVM("test-vm-clone", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting cloned VM: %v", err)
+	}
+	if vm.Image != "ubuntu24" {
+		t.Errorf("expected cloned VM image ubuntu24, got %s", vm.Image)
+	}
+}
+
+func TestExportVMImage(t *testing.T) {
+	cli := setupTestClient(t)
+	image, err := cli.ExportVMImage("test-vm", testNamespace, "test-vm-image")
+	if err != nil {
+		t.Fatalf("error exporting VM image: %v", err)
+	}
+	if image.SourceVM != "test-vm" {
+		t.Errorf("expected image exported from test-vm, got %s", image.SourceVM)
+	}
+	if err := cli.CreateVM("test-vm-from-image", "test-vm-image", "small", testNamespace); err != nil {
+		t.Fatalf("error creating VM from exported image: %v", err)
+	}
+	defer cli.DeleteVM("test-vm-from-image", testNamespace)
+}
+
+func TestResizeVM(t *testing.T) {
+	cli := setupTestClient(t)
+	result, err := cli.ResizeVM("test-vm", testNamespace, types.VMResize{Size: "medium"})
+	if err != nil {
+		t.Fatalf("error resizing VM: %v", err)
+	}
+	t.Logf("resized with %s", result.Method)
+	vm, err := cli.GetVM("test-vm", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting VM: %v", err)
+	}
+	if vm.Size != "medium" || vm.CPU != types.DefaultVMSizes["medium"].CPU || vm.RAM != types.DefaultVMSizes["medium"].RAM {
+		t.Errorf("VM size = %s %d/%d, want medium", vm.Size, vm.CPU, vm.RAM)
+	}
+	if _, err := cli.ResizeVM("test-vm", testNamespace, types.VMResize{CPU: 2, RAM: 3072}); err != nil {
+		t.Fatalf("error resizing VM to custom values: %v", err)
+	}
+	vm, err = cli.GetVM("test-vm", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting VM: %v", err)
+	}
+	if vm.Size != types.VMSizeCustom || vm.CPU != 2 || vm.RAM != 3072 {
+		t.Errorf("VM size = %s %d/%d, want custom 2/3072", vm.Size, vm.CPU, vm.RAM)
+	}
+}
+
+func TestMigrateVM(t *testing.T) {
+	cli := setupTestClient(t)
+	migration, err := cli.MigrateVM("test-vm", testNamespace, "")
+	if err != nil {
+		t.Fatalf("error migrating VM: %v", err)
+	}
+	got, err := cli.GetVMMigration("test-vm", testNamespace, migration.Name)
+	if err != nil {
+		t.Fatalf("error getting migration: %v", err)
+	}
+	t.Logf("migration: %v", got)
+	migrations, err := cli.ListVMMigrations("test-vm", testNamespace)
+	if err != nil {
+		t.Fatalf("error listing migrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Errorf("migration %s not listed", migration.Name)
+	}
+}
+
+func TestCreateVMWithNetworks(t *testing.T) {
+	cli := setupTestClient(t)
+	err := cli.CreateVMFromSpec(types.VM{
+		Name:      "test-vm-networks",
+		Image:     "ubuntu24",
+		Size:      "small",
+		Namespace: testNamespace,
+		Networks:  []types.VMNetwork{{Name: "eth0", Type: types.VMNetworkPod}},
+	})
+	if err != nil {
+		t.Fatalf("error creating VM with networks: %v", err)
+	}
+	defer cli.DeleteVM("test-vm-networks", testNamespace)
+	vm, err := cli.GetVM("test-vm-networks", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting VM: %v", err)
+	}
+	if len(vm.Networks) != 1 || vm.Networks[0].MAC == "" || vm.Networks[0].Addressing != types.VMAddressingDHCP {
+		t.Errorf("expected a DHCP pod network with a generated MAC, got %v", vm.Networks)
+	}
+}
+
+func TestSetVMRunPolicy(t *testing.T) {
+	cli := setupTestClient(t)
+	policy := types.VMRunPolicy{RunStrategy: types.VMRunStrategyRerunOnFailure, IdleTimeout: "4h"}
+	effective, err := cli.SetVMRunPolicy("test-vm", testNamespace, policy)
+	if err != nil {
+		t.Fatalf("error setting VM run policy: %v", err)
+	}
+	if effective.RunStrategy != types.VMRunStrategyRerunOnFailure {
+		t.Errorf("effective run strategy = %s, want RerunOnFailure", effective.RunStrategy)
+	}
+	vm, err := cli.GetVM("test-vm", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting VM: %v", err)
+	}
+	if vm.RunStrategy != types.VMRunStrategyRerunOnFailure || vm.IdleTimeout != "4h" {
+		t.Errorf("VM run policy = %s %s, want RerunOnFailure 4h", vm.RunStrategy, vm.IdleTimeout)
+	}
+	if _, err := cli.SetVMRunPolicy("test-vm", testNamespace, types.VMRunPolicy{RunStrategy: "Sometimes"}); err == nil {
+		t.Errorf("expected an invalid run strategy to be rejected")
+	}
+}
+
+func TestCreateVMWithPlacement(t *testing.T) {
+	cli := setupTestClient(t)
+	placement := &types.VMPlacement{AntiAffinityGroups: []string{"test"}, Preferred: true}
+	for _, name := range []string{"test-vm-spread-1", "test-vm-spread-2"} {
+		err := cli.CreateVMFromSpec(types.VM{
+			Name:      name,
+			Image:     "ubuntu24",
+			Size:      "small",
+			Namespace: testNamespace,
+			Placement: placement,
+		})
+		if err != nil {
+			t.Fatalf("error creating VM with placement: %v", err)
+		}
+		defer cli.DeleteVM(name, testNamespace)
+	}
+	vm, err := cli.GetVM("test-vm-spread-1", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting VM: %v", err)
+	}
+	if vm.Placement == nil || len(vm.Placement.AntiAffinityGroups) != 1 || !vm.Placement.Preferred {
+		t.Errorf("expected a preferred anti-affinity group, got %+v", vm.Placement)
+	}
+	err = cli.CreateVMFromSpec(types.VM{
+		Name:      "test-vm-hugepages",
+		Image:     "ubuntu24",
+		Size:      "small",
+		Namespace: testNamespace,
+		Placement: &types.VMPlacement{Hugepages: "4Ki"},
+	})
+	if err == nil {
+		cli.DeleteVM("test-vm-hugepages", testNamespace)
+		t.Errorf("expected invalid hugepages to be rejected")
+	}
+}
+
+func TestExportImportVM(t *testing.T) {
+	cli := setupTestClient(t)
+	if err := cli.StopVM("test-vm", testNamespace); err != nil {
+		t.Fatalf("error stopping VM: %v", err)
+	}
+	time.Sleep(30 * time.Second)
+	path := filepath.Join(t.TempDir(), "test-vm.tar")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("error creating archive file: %v", err)
+	}
+	defer file.Close()
+	if err := cli.ExportVM("test-vm", testNamespace, types.VMExportFormatTar, file); err != nil {
+		t.Fatalf("error exporting VM: %v", err)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("error rewinding archive file: %v", err)
+	}
+	if err := cli.ImportVM("test-vm-imported", testNamespace, file); err != nil {
+		t.Fatalf("error importing VM: %v", err)
+	}
+	defer cli.DeleteVM("test-vm-imported", testNamespace)
+	vm, err := cli.GetVM("test-vm-imported", testNamespace)
+	if err != nil {
+		t.Fatalf("error getting imported VM: %v", err)
+	}
+	if vm.Image != "ubuntu24" {
+		t.Errorf("expected imported VM image ubuntu24, got %s", vm.Image)
+	}
+}
+
+func TestGetVMMetrics(t *testing.T) {
+	cli := setupTestClient(t)
+	metrics, err := cli.GetVMMetrics("test-vm", testNamespace, "1h")
+	if err != nil {
+		t.Fatalf("error getting VM metrics: %v", err)
+	}
+	if metrics.Step == 0 || metrics.End.Sub(metrics.Start) != time.Hour {
+		t.Errorf("unexpected metrics range: %+v", metrics)
+	}
+	if _, err := cli.GetVMMetrics("test-vm", testNamespace, "1y"); err == nil {
+		t.Errorf("expected an invalid range to be refused")
+	}
+}
+
 func TestDeleteVM(t *testing.T) {
 	cli := setupTestClient(t)
 	err := cli.DeleteVM("test-vm", testNamespace)
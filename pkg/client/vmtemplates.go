@@ -0,0 +1,108 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// vmTemplateURL returns the URL of the VM templates of a namespace, or of one of them
+func (c *Client) vmTemplateURL(namespace, name string) string {
+	url := fmt.Sprintf("%s/vmtemplates/%s", c.baseURL, namespace)
+	if name != "" {
+		url += "/" + name
+	}
+	return url
+}
+
+// doVMTemplateRequest sends a template or pool request with in as its body unless in is nil, and decodes its response into out unless out is nil
+func (c *Client) doVMTemplateRequest(method, url, action string, in, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("error marshaling request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.SetBasicAuth(c.username, c.password)
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error %s: %w", action, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("error %s: status=%s body=%s", action, resp.Status, string(body))
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("error decoding response: %w", err)
+		}
+	}
+	return nil
+}
+
+// ListVMTemplates lists the VM templates of a namespace.
+func (c *Client) ListVMTemplates(namespace string) ([]types.VMTemplate, error) {
+	var templates []types.VMTemplate
+	if err := c.doVMTemplateRequest(http.MethodGet, c.vmTemplateURL(namespace, ""), "listing templates", nil, &templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// GetVMTemplate gets a VM template.
+func (c *Client) GetVMTemplate(namespace, name string) (*types.VMTemplate, error) {
+	var template types.VMTemplate
+	if err := c.doVMTemplateRequest(http.MethodGet, c.vmTemplateURL(namespace, name), "getting template", nil, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// PutVMTemplate creates or replaces a VM template.
+func (c *Client) PutVMTemplate(template types.VMTemplate) error {
+	return c.doVMTemplateRequest(http.MethodPut, c.vmTemplateURL(template.Namespace, template.Name), "storing template", template, nil)
+}
+
+// DeleteVMTemplate deletes a VM template, the VMs created from it stay.
+func (c *Client) DeleteVMTemplate(namespace, name string) error {
+	return c.doVMTemplateRequest(http.MethodDelete, c.vmTemplateURL(namespace, name), "deleting template", nil, nil)
+}
+
+// CreateVMBatch creates VMs from a template and returns the result of every VM.
+func (c *Client) CreateVMBatch(namespace, template string, batch types.VMBatch) (*types.VMBatchResult, error) {
+	var result types.VMBatchResult
+	if err := c.doVMTemplateRequest(http.MethodPost, c.vmTemplateURL(namespace, template)+"/vms", "creating VMs", batch, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListVMPools lists the VM pools of a namespace.
+func (c *Client) ListVMPools(namespace string) ([]types.VMPool, error) {
+	var pools []types.VMPool
+	url := fmt.Sprintf("%s/vmpools/%s", c.baseURL, namespace)
+	if err := c.doVMTemplateRequest(http.MethodGet, url, "listing pools", nil, &pools); err != nil {
+		return nil, err
+	}
+	return pools, nil
+}
+
+// DeleteVMPool deletes a VM pool with its VMs.
+func (c *Client) DeleteVMPool(namespace, name string) error {
+	url := fmt.Sprintf("%s/vmpools/%s/%s", c.baseURL, namespace, name)
+	return c.doVMTemplateRequest(http.MethodDelete, url, "deleting pool", nil, nil)
+}
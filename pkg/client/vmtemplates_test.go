@@ -0,0 +1,49 @@
+package client_test
+
+import (
+	"testing"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+func TestVMTemplates(t *testing.T) {
+	cli := setupTestClient(t)
+	template := types.VMTemplate{
+		Name:      "test-template",
+		Namespace: testNamespace,
+		VM:        types.VM{Image: "ubuntu24", Size: "small"},
+		Volumes:   []types.VMTemplateVolume{{Name: "data", Size: "1Gi"}},
+	}
+	if err := cli.PutVMTemplate(template); err != nil {
+		t.Fatalf("error storing template: %v", err)
+	}
+	defer cli.DeleteVMTemplate(testNamespace, "test-template")
+	got, err := cli.GetVMTemplate(testNamespace, "test-template")
+	if err != nil {
+		t.Fatalf("error getting template: %v", err)
+	}
+	if got.VM.Image != "ubuntu24" || len(got.Volumes) != 1 {
+		t.Errorf("unexpected template: %+v", got)
+	}
+	templates, err := cli.ListVMTemplates(testNamespace)
+	if err != nil {
+		t.Fatalf("error listing templates: %v", err)
+	}
+	if len(templates) == 0 {
+		t.Errorf("template test-template not listed")
+	}
+
+	result, err := cli.CreateVMBatch(testNamespace, "test-template", types.VMBatch{Count: 2, NamePattern: "test-batch-{n}"})
+	if err != nil {
+		t.Fatalf("error creating VMs: %v", err)
+	}
+	for _, item := range result.VMs {
+		defer cli.DeleteVM(item.Name, testNamespace)
+	}
+	if result.Created != 2 || len(result.VMs) != 2 || result.VMs[0].Name != "test-batch-1" {
+		t.Errorf("unexpected batch result: %+v", result)
+	}
+	if _, err := cli.CreateVMBatch(testNamespace, "test-template", types.VMBatch{Count: 1, Pool: true}); err == nil {
+		t.Errorf("expected a template with volumes to be refused as a pool")
+	}
+}
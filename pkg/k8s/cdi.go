@@ -0,0 +1,35 @@
+package k8s
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/rusik69/govnocloud2/pkg/ssh"
+)
+
+// InstallCDI installs the Containerized Data Importer that imports VM images into root disk DataVolumes, unless it is already deployed
+func InstallCDI(host, user, key, version string) error {
+	checkCmd := "kubectl get cdi cdi"
+	if _, err := ssh.Run(checkCmd, host, key, user, "", true, 60); err == nil {
+		log.Println("CDI is already installed")
+		return nil
+	}
+
+	baseURL := fmt.Sprintf("https://github.com/kubevirt/containerized-data-importer/releases/download/%s", version)
+	for _, manifest := range []string{"cdi-operator.yaml", "cdi-cr.yaml"} {
+		cmd := fmt.Sprintf("kubectl apply -f %s/%s --wait=true --timeout=300s", baseURL, manifest)
+		log.Println(cmd)
+		if out, err := ssh.Run(cmd, host, key, user, "", true, 60); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", manifest, err)
+		} else {
+			log.Println(out)
+		}
+	}
+
+	waitCmd := "kubectl wait --for=condition=Available --timeout=600s cdi cdi"
+	log.Println(waitCmd)
+	if _, err := ssh.Run(waitCmd, host, key, user, "", true, 600); err != nil {
+		return fmt.Errorf("failed to wait for CDI: %w", err)
+	}
+	return nil
+}
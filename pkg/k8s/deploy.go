@@ -44,7 +44,7 @@ WantedBy=multi-user.target
 }
 
 // Deploy deploys the server.
-func Deploy(host, serverHost, webHost, serverPort, webPort, user, password, key, webPath string) error {
+func Deploy(host, serverHost, webHost, serverPort, webPort, user, password, key, webPath, imagesDir string) error {
 	const (
 		binaryPath = "bin/govnocloud2-linux-amd64"
 		destPath   = "/usr/local/bin/govnocloud2"
@@ -67,11 +67,18 @@ func Deploy(host, serverHost, webHost, serverPort, webPort, user, password, key,
 		return fmt.Errorf("failed to make binary executable: %s", out)
 	}
 
+	// Create the directory uploaded VM images are kept in
+	cmd = fmt.Sprintf("sudo mkdir -p %s", imagesDir)
+	log.Println(cmd)
+	if out, err := ssh.Run(cmd, host, key, user, password, false, 5); err != nil {
+		return fmt.Errorf("failed to create images directory: %s", out)
+	}
+
 	// Create and deploy server service
 	serverConfig := GovnocloudServiceConfig{
 		Name:        "govnocloud2",
 		Description: "govnocloud2 server",
-		ExecStart:   fmt.Sprintf("%s server --port %s --host %s", destPath, serverPort, serverHost),
+		ExecStart:   fmt.Sprintf("%s server --port %s --host %s --imagesdir %s", destPath, serverPort, serverHost, imagesDir),
 		User:        "root",
 	}
 
@@ -1,12 +1,12 @@
 package k8s
 
 import (
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/rusik69/govnocloud2/pkg/ssh"
-	"github.com/rusik69/govnocloud2/pkg/types"
 )
 
 func InstallKubeVirt(host, user, key, managerHost, version string) error {
@@ -49,6 +49,11 @@ func InstallKubeVirt(host, user, key, managerHost, version string) error {
 		return fmt.Errorf("failed to wait for KubeVirt CR: %w", err)
 	}
 
+	// Enable the feature gates volume hotplug and snapshots rely on
+	if err := EnableKubeVirtFeatureGates(host, user, key); err != nil {
+		return err
+	}
+
 	// Additional wait to ensure all components are created
 	time.Sleep(30 * time.Second)
 
@@ -87,10 +92,32 @@ func InstallKubeVirt(host, user, key, managerHost, version string) error {
 	if err := RemoveDefaultVirtualMachineInstanceTypes(host, user, key); err != nil {
 		return fmt.Errorf("failed to remove default virtualmachineinstancetypes: %w", err)
 	}
+	// the server syncs its size catalog to cluster instancetypes when it starts
+	return nil
+}
+
+// kubeVirtFeatureGates are the KubeVirt feature gates govnocloud relies on
+var kubeVirtFeatureGates = []string{"HotplugVolumes", "Snapshot", "CPUManager", "VMPersistentState"}
 
-	// create virtualmachineinstancetypes
-	if err := CreateVirtualMachineInstanceTypes(host, user, key); err != nil {
-		return fmt.Errorf("failed to create virtualmachineinstancetypes: %w", err)
+// vmStateStorageClass is the storage class of the persistent TPM and EFI state of VMs
+const vmStateStorageClass = "longhorn"
+
+// EnableKubeVirtFeatureGates enables the feature gates needed for volume hotplug, VM snapshots, dedicated CPUs and
+// persistent TPM and EFI state, and the LiveUpdate rollout strategy CPU and memory hotplug need
+func EnableKubeVirtFeatureGates(host, user, key string) error {
+	gates, err := json.Marshal(kubeVirtFeatureGates)
+	if err != nil {
+		return fmt.Errorf("failed to marshal feature gates: %w", err)
+	}
+	patch := fmt.Sprintf(`{"spec":{"configuration":{"vmRolloutStrategy":"LiveUpdate","vmStateStorageClass":"%s",`+
+		`"developerConfiguration":{"featureGates":%s}},"workloadUpdateStrategy":{"workloadUpdateMethods":["LiveMigrate"]}}}`,
+		vmStateStorageClass, gates)
+	cmd := fmt.Sprintf("kubectl patch kubevirt kubevirt -n kubevirt --type=merge -p '%s'", patch)
+	log.Println(cmd)
+	if out, err := ssh.Run(cmd, host, key, user, "", true, 60); err != nil {
+		return fmt.Errorf("failed to enable KubeVirt feature gates: %w", err)
+	} else {
+		log.Println(out)
 	}
 	return nil
 }
@@ -149,21 +176,6 @@ spec:
 	return nil
 }
 
-func CreateVirtualMachineInstanceTypes(host, user, key string) error {
-	// create virtualmachineinstancetypes based on vmsizes
-	for _, vmSize := range types.VMSizes {
-		cmd := fmt.Sprintf("virtctl create instancetype --name %s --cpu %d --memory %d | kubectl apply -f -", vmSize.Name, vmSize.CPU, vmSize.RAM)
-		log.Println(cmd)
-		out, err := ssh.Run(cmd, host, key, user, "", true, 60)
-		if err != nil {
-			return fmt.Errorf("failed to create virtualmachineinstancetype: %w", err)
-		}
-		log.Println(out)
-	}
-	log.Println("KubeVirt Manager is successfully installed")
-	return nil
-}
-
 func RemoveDefaultVirtualMachineInstanceTypes(host, user, key string) error {
 	// remove default virtualmachineinstancetypes
 	cmd := "kubectl delete virtualmachineclusterinstancetype --all"
@@ -9,6 +9,22 @@ import (
 	"github.com/rusik69/govnocloud2/pkg/ssh"
 )
 
+// LonghornMigratableClass is the storage class of VM disks, whose RWX block volumes KubeVirt can live-migrate. The
+// server applies it too, so clusters installed before it existed get it on upgrade.
+const LonghornMigratableClass = `apiVersion: storage.k8s.io/v1
+kind: StorageClass
+metadata:
+  name: longhorn-migratable
+provisioner: driver.longhorn.io
+allowVolumeExpansion: true
+reclaimPolicy: Delete
+volumeBindingMode: Immediate
+parameters:
+  numberOfReplicas: "1"
+  staleReplicaTimeout: "30"
+  fromBackup: ""
+  migratable: "true"`
+
 // InstallLonghorn installs Longhorn storage system into the Kubernetes cluster
 func InstallLonghorn(master string, nodeIPs []string, user, keyPath, ingressHost, disk string, formatDisk bool) error {
 	log.Println("Installing Longhorn storage system...")
@@ -211,6 +227,13 @@ spec:
 		return fmt.Errorf("failed to wait for pods to be ready: %w", err)
 	}
 
+	// Create the storage class of live-migratable VM disks
+	cmd = fmt.Sprintf("cat << 'EOF' | kubectl apply -f -\n%s\nEOF", LonghornMigratableClass)
+	log.Println("Creating Longhorn migratable storage class")
+	if out, err := ssh.Run(cmd, master, keyPath, user, "", true, 0); err != nil {
+		return fmt.Errorf("failed to create migratable storage class: %s: %w", out, err)
+	}
+
 	log.Println("Longhorn installation completed successfully")
 	return nil
 }
@@ -145,6 +145,25 @@ func deployMonitoringStack(cfg *MonitoringConfig) error {
 		return err
 	}
 
+	if err := enableKubeVirtMetrics(cfg); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// enableKubeVirtMetrics makes KubeVirt create the ServiceMonitor of its kubevirt_vmi_* metrics for the Prometheus of
+// the monitoring stack, which the VM metrics API queries
+func enableKubeVirtMetrics(cfg *MonitoringConfig) error {
+	patch := fmt.Sprintf(`{"spec":{"monitorNamespace":"%s","monitorAccount":"%s-kube-prometheus-prometheus"}}`,
+		cfg.Release.Namespace, cfg.Release.Name)
+	cmd := fmt.Sprintf("kubectl patch kubevirt kubevirt -n kubevirt --type=merge -p '%s'", patch)
+	log.Println(cmd)
+	out, err := ssh.Run(cmd, cfg.Host, cfg.Key, cfg.User, "", true, 60)
+	if err != nil {
+		return fmt.Errorf("failed to enable KubeVirt metrics: %w", err)
+	}
+	log.Println(out)
 	return nil
 }
 
@@ -186,6 +205,7 @@ grafana:
 prometheus:
   prometheusSpec:
     retention: 30d
+    serviceMonitorSelectorNilUsesHelmValues: false
     maximumStartupDurationSeconds: 600
 alertmanager:
   alertmanagerSpec: {}
@@ -0,0 +1,124 @@
+package k8s
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/rusik69/govnocloud2/pkg/ssh"
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// bridgeScript moves the address of a worker interface to a bridge enslaving it. The bridge keeps the MAC of the
+// interface so dnsmasq hands out the same lease, and netplan is applied after the ssh session returned.
+const bridgeScript = `set -e
+ip link show %[2]s >/dev/null 2>&1 && exit 0
+mac=$(cat /sys/class/net/%[1]s/address)
+cat > /etc/netplan/60-govnocloud-bridge.yaml <<EOF
+network:
+  version: 2
+  ethernets:
+    %[1]s:
+      dhcp4: false
+  bridges:
+    %[2]s:
+      interfaces: [%[1]s]
+      macaddress: $mac
+      dhcp4: true
+      parameters:
+        stp: false
+        forward-delay: 0
+EOF
+chmod 600 /etc/netplan/60-govnocloud-bridge.yaml
+systemd-run --on-active=2 netplan apply
+`
+
+// bridgeNADManifest is the NetworkAttachmentDefinition of the bridge, without IPAM since guests address themselves on the LAN
+const bridgeNADManifest = `apiVersion: k8s.cni.cncf.io/v1
+kind: NetworkAttachmentDefinition
+metadata:
+  name: %[1]s
+  namespace: %[2]s
+spec:
+  config: '{"cniVersion":"0.3.1","name":"%[1]s","type":"bridge","bridge":"%[3]s","ipam":{}}'
+`
+
+// InstallMultus bridges the interface of the workers, installs Multus and creates the NetworkAttachmentDefinition
+// VMs attach to for L2 access to the LAN
+func InstallMultus(master string, workers []string, user, key, iface, bridge string) error {
+	script := base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf(bridgeScript, iface, bridge)))
+	for _, worker := range workers {
+		log.Printf("Bridging %s to %s on %s", iface, bridge, worker)
+		cmd := fmt.Sprintf("ssh -i %s -o StrictHostKeyChecking=no %s@%s 'echo %s | base64 -d | sudo sh'",
+			key, user, worker, script)
+		if out, err := ssh.Run(cmd, master, key, user, "", true, 60); err != nil {
+			return fmt.Errorf("failed to bridge %s on %s: %s: %w", iface, worker, out, err)
+		}
+	}
+	for _, worker := range workers {
+		if err := waitBridge(master, worker, user, key, bridge); err != nil {
+			return err
+		}
+	}
+
+	nad := fmt.Sprintf(bridgeNADManifest, types.BridgeNetworkName, types.BridgeNetworkNamespace, bridge)
+	cmds := []string{
+		"helm repo add rke2-charts https://rke2-charts.rancher.io && helm repo update",
+		"helm upgrade --install multus rke2-charts/rke2-multus -n kube-system --wait" +
+			" --set config.fullnameOverride=multus" +
+			" --set config.cni_conf.confDir=/var/lib/rancher/k3s/agent/etc/cni/net.d" +
+			" --set config.cni_conf.binDir=/var/lib/rancher/k3s/data/cni/" +
+			" --set config.cni_conf.kubeconfig=/var/lib/rancher/k3s/agent/etc/cni/net.d/multus.d/multus.kubeconfig",
+		fmt.Sprintf("echo %s | base64 -d | kubectl apply -f -", base64.StdEncoding.EncodeToString([]byte(nad))),
+	}
+	for _, cmd := range cmds {
+		log.Println(cmd)
+		out, err := ssh.Run(cmd, master, key, user, "", true, 300)
+		if err != nil {
+			return fmt.Errorf("failed to install multus: %s: %w", out, err)
+		}
+		log.Println(out)
+	}
+	return labelBridgedNodes(master, workers, user, key)
+}
+
+// waitBridge waits for the bridge of a worker to come up with an address after netplan was applied
+func waitBridge(master, worker, user, key, bridge string) error {
+	cmd := fmt.Sprintf("ssh -i %s -o StrictHostKeyChecking=no -o ConnectTimeout=5 %s@%s 'ip -4 addr show %s | grep -q inet'",
+		key, user, worker, bridge)
+	for i := 0; i < 30; i++ {
+		time.Sleep(5 * time.Second)
+		if _, err := ssh.Run(cmd, master, key, user, "", false, 30); err == nil {
+			log.Printf("Bridge %s is up on %s", bridge, worker)
+			return nil
+		}
+	}
+	return fmt.Errorf("bridge %s did not come up on %s", bridge, worker)
+}
+
+// labelBridgedNodes labels the nodes of the bridged workers, VMs with bridge networks are scheduled on them
+func labelBridgedNodes(master string, workers []string, user, key string) error {
+	cmd := `kubectl get nodes -o jsonpath='{range .items[*]}{.metadata.name} {.status.addresses[?(@.type=="InternalIP")].address}{"\n"}{end}'`
+	out, err := ssh.Run(cmd, master, key, user, "", false, 60)
+	if err != nil {
+		return fmt.Errorf("failed to get nodes: %s: %w", out, err)
+	}
+	bridged := make(map[string]bool, len(workers))
+	for _, worker := range workers {
+		bridged[worker] = true
+	}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || !bridged[fields[1]] {
+			continue
+		}
+		cmd := fmt.Sprintf("kubectl label node %s %s=true --overwrite", fields[0], types.BridgeNodeLabel)
+		log.Println(cmd)
+		if out, err := ssh.Run(cmd, master, key, user, "", true, 60); err != nil {
+			return fmt.Errorf("failed to label node %s: %s: %w", fields[0], out, err)
+		}
+	}
+	return nil
+}
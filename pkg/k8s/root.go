@@ -158,17 +158,10 @@ func CreateRootUser(password string) error {
 
 	if existingUser != nil {
 		log.Printf("Root user already exists, skipping creation")
-		// Verify the existing user's password
-		storedPassword, err := userManager.GetUserPassword("root")
-		if err != nil {
-			log.Printf("Error getting existing root user password: %v", err)
-		} else {
-			log.Printf("Existing root user password: '%s'", storedPassword)
-		}
 		return nil
 	}
 
-	log.Printf("Creating root user with password '%s'", password)
+	log.Printf("Creating root user")
 	user := types.User{
 		Name:     "root",
 		IsAdmin:  true,
@@ -180,7 +173,7 @@ func CreateRootUser(password string) error {
 		return fmt.Errorf("failed to create root user: %w", err)
 	}
 
-	log.Printf("Root user created successfully with password '%s'", password)
+	log.Printf("Root user created successfully")
 
 	// Verify the user was created correctly
 	createdUser, err := userManager.GetUser("root")
@@ -188,12 +181,6 @@ func CreateRootUser(password string) error {
 		log.Printf("Error verifying created root user: %v", err)
 	} else if createdUser != nil {
 		log.Printf("Verified root user exists: IsAdmin=%v", createdUser.IsAdmin)
-		storedPassword, err := userManager.GetUserPassword("root")
-		if err != nil {
-			log.Printf("Error getting created root user password: %v", err)
-		} else {
-			log.Printf("Verified root user password: '%s'", storedPassword)
-		}
 	}
 
 	return nil
@@ -0,0 +1,50 @@
+package k8s
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/rusik69/govnocloud2/pkg/ssh"
+)
+
+// longhornSnapshotClass is the VolumeSnapshotClass VM snapshots of Longhorn disks are taken with
+const longhornSnapshotClass = `apiVersion: snapshot.storage.k8s.io/v1
+kind: VolumeSnapshotClass
+metadata:
+  name: longhorn-snapshot
+  annotations:
+    snapshot.storage.kubernetes.io/is-default-class: "true"
+driver: driver.longhorn.io
+deletionPolicy: Delete
+parameters:
+  type: snap`
+
+// InstallVolumeSnapshots installs the CSI snapshot CRDs and controller and the Longhorn VolumeSnapshotClass VM snapshots need
+func InstallVolumeSnapshots(host, user, key, version string) error {
+	baseURL := fmt.Sprintf("https://raw.githubusercontent.com/kubernetes-csi/external-snapshotter/%s", version)
+	manifests := []string{
+		"client/config/crd/snapshot.storage.k8s.io_volumesnapshotclasses.yaml",
+		"client/config/crd/snapshot.storage.k8s.io_volumesnapshotcontents.yaml",
+		"client/config/crd/snapshot.storage.k8s.io_volumesnapshots.yaml",
+		"deploy/kubernetes/snapshot-controller/rbac-snapshot-controller.yaml",
+		"deploy/kubernetes/snapshot-controller/setup-snapshot-controller.yaml",
+	}
+	for _, manifest := range manifests {
+		cmd := fmt.Sprintf("kubectl apply -f %s/%s", baseURL, manifest)
+		log.Println(cmd)
+		if out, err := ssh.Run(cmd, host, key, user, "", true, 60); err != nil {
+			return fmt.Errorf("failed to apply %s: %w", manifest, err)
+		} else {
+			log.Println(out)
+		}
+	}
+
+	cmd := fmt.Sprintf("cat << 'EOF' | kubectl apply -f -\n%s\nEOF", longhornSnapshotClass)
+	log.Println("creating longhorn volume snapshot class")
+	if out, err := ssh.Run(cmd, host, key, user, "", true, 60); err != nil {
+		return fmt.Errorf("failed to create volume snapshot class: %w", err)
+	} else {
+		log.Println(out)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// RedactedValue replaces the value of sensitive fields in logs
+const RedactedValue = "[REDACTED]"
+
+// sensitiveKeys are substrings of field names whose values are never logged
+var sensitiveKeys = []string{"password", "passwd", "secret", "token", "key", "authorization", "credential", "userdata"}
+
+type loggerKey struct{}
+
+// Setup makes a JSON logger writing to stderr at the given level the default for both slog and the standard log package
+func Setup(level string) error {
+	return SetupWriter(os.Stderr, level)
+}
+
+// SetupWriter is like Setup but writes to w
+func SetupWriter(w io.Writer, level string) error {
+	lvl, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       lvl,
+		ReplaceAttr: redactAttr,
+	})
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// ParseLevel converts a level name (debug, info, warn, error) to a slog level
+func ParseLevel(level string) (slog.Level, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return lvl, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+	return lvl, nil
+}
+
+// NewRequestID returns a random identifier for a request
+func NewRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// WithLogger stores a logger in the context
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger stored in the context or the default logger
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
+
+// OrDefault returns logger, or the default logger if it is nil
+func OrDefault(logger *slog.Logger) *slog.Logger {
+	if logger == nil {
+		return slog.Default()
+	}
+	return logger
+}
+
+// IsSensitive reports whether a field with this name must not be logged
+func IsSensitive(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// Redact returns a copy of v with the values of sensitive fields replaced.
+// Structs, maps and slices are converted through their JSON form, so json tags decide the field names.
+func Redact(v any) any {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	var generic any
+	if err := json.Unmarshal(data, &generic); err != nil {
+		return v
+	}
+	return redactValue(generic)
+}
+
+// redactValue walks a decoded JSON value and replaces sensitive fields
+func redactValue(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, item := range val {
+			if IsSensitive(k) {
+				if item != nil && item != "" {
+					val[k] = RedactedValue
+				}
+				continue
+			}
+			val[k] = redactValue(item)
+		}
+		return val
+	case []any:
+		for i, item := range val {
+			val[i] = redactValue(item)
+		}
+		return val
+	default:
+		return v
+	}
+}
+
+// redactAttr hides sensitive attributes and sensitive fields of structured values
+func redactAttr(groups []string, a slog.Attr) slog.Attr {
+	if IsSensitive(a.Key) {
+		return slog.String(a.Key, RedactedValue)
+	}
+	if a.Value.Kind() != slog.KindAny {
+		return a
+	}
+	v := a.Value.Any()
+	switch val := v.(type) {
+	case error:
+		return a
+	case []byte:
+		// command output, keep it readable instead of base64
+		return slog.String(a.Key, string(val))
+	}
+	kind := reflect.Indirect(reflect.ValueOf(v)).Kind()
+	switch kind {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return slog.Any(a.Key, Redact(v))
+	}
+	return a
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+type testUser struct {
+	Name       string   `json:"name"`
+	Password   string   `json:"password"`
+	Namespaces []string `json:"namespaces"`
+}
+
+func TestRedactStruct(t *testing.T) {
+	redacted := Redact(testUser{Name: "bob", Password: "hunter2", Namespaces: []string{"a"}})
+	m, ok := redacted.(map[string]any)
+	if !ok {
+		t.Fatalf("expected map, got %T", redacted)
+	}
+	if m["password"] != RedactedValue {
+		t.Fatalf("password not redacted: %v", m["password"])
+	}
+	if m["name"] != "bob" {
+		t.Fatalf("name changed: %v", m["name"])
+	}
+}
+
+func TestHandlerRedactsAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	if err := SetupWriter(&buf, "debug"); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+	slog.Info("login", "username", "bob", "password", "hunter2", "user", &testUser{Name: "bob", Password: "hunter2"})
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Fatalf("secret leaked into log: %s", buf.String())
+	}
+	var entry map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("log is not JSON: %v", err)
+	}
+	if entry["username"] != "bob" {
+		t.Fatalf("unexpected username: %v", entry["username"])
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if _, err := ParseLevel("warn"); err != nil {
+		t.Fatalf("warn: %v", err)
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Fatalf("expected error for unknown level")
+	}
+}
@@ -2,7 +2,7 @@ package server
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"slices"
 
 	"github.com/gin-gonic/gin"
@@ -11,25 +11,34 @@ import (
 
 // CheckAuth verifies user authentication using HTTP Basic Auth
 func CheckAuth(c *gin.Context) (bool, string, error) {
+	logger := requestLogger(c)
 	username, password, ok := c.Request.BasicAuth()
 	if !ok {
-		log.Printf("Authentication failed: missing basic auth credentials")
+		logger.Info("authentication failed: missing basic auth credentials")
 		return false, "", fmt.Errorf("missing basic auth credentials")
 	}
 
+	if err := authenticate(logger, username, password); err != nil {
+		return false, "", err
+	}
+
+	return true, username, nil
+}
+
+// authenticate verifies a username and password against the stored credentials
+func authenticate(logger *slog.Logger, username, password string) error {
 	// Verify the password against stored password (plain text)
 	valid, err := userManager.VerifyPassword(username, password)
 	if err != nil {
-		log.Printf("Authentication error for user %s: %v", username, err)
-		return false, "", fmt.Errorf("authentication error: %w", err)
+		logger.Warn("authentication error", "username", username, "error", err)
+		return fmt.Errorf("authentication error: %w", err)
 	}
 
 	if !valid {
-		log.Printf("Authentication failed for user %s:%s invalid credentials", username, password)
-		return false, "", fmt.Errorf("invalid credentials")
+		logger.Info("authentication failed: invalid credentials", "username", username)
+		return fmt.Errorf("invalid credentials")
 	}
-
-	return true, username, nil
+	return nil
 }
 
 // CheckNamespaceAccess checks if a user has access to a namespace
@@ -1,11 +1,9 @@
 package server
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rusik69/govnocloud2/pkg/types"
@@ -14,12 +12,27 @@ import (
 // ClickhouseManager handles clickhouse operations
 type ClickhouseManager struct {
 	kubectl KubectlRunner
+	logger  *slog.Logger
 }
 
 // NewClickhouseManager creates a new clickhouse manager instance
 func NewClickhouseManager() *ClickhouseManager {
 	return &ClickhouseManager{
 		kubectl: &DefaultKubectlRunner{},
+		logger:  slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *ClickhouseManager) forRequest(c *gin.Context) *ClickhouseManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *ClickhouseManager) withLogger(logger *slog.Logger) *ClickhouseManager {
+	return &ClickhouseManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		logger:  logger,
 	}
 }
 
@@ -43,7 +56,7 @@ func ListClickhouseHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	clickhouse, err := clickhouseManager.ListClusters(namespace)
+	clickhouse, err := clickhouseManager.forRequest(c).ListClusters(namespace)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list clickhouse: %v", err))
 		return
@@ -76,7 +89,16 @@ func CreateClickhouseHandler(c *gin.Context) {
 		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("failed to bind clickhouse: %v", err))
 		return
 	}
-	err = clickhouseManager.CreateCluster(namespace, cluster)
+	if isDryRun(c) {
+		manifest, err := resourceManifest(clickhouseManager.generateResource(namespace, cluster))
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondWithDryRun(c, clickhouseManager.forRequest(c).kubectl, manifest, namespace)
+		return
+	}
+	err = clickhouseManager.forRequest(c).CreateCluster(namespace, cluster)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create clickhouse: %v", err))
 		return
@@ -104,7 +126,7 @@ func DeleteClickhouseHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	err = clickhouseManager.DeleteCluster(namespace, c.Param("name"))
+	err = clickhouseManager.forRequest(c).DeleteCluster(namespace, c.Param("name"))
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete clickhouse: %v", err))
 		return
@@ -132,7 +154,7 @@ func GetClickhouseHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	clickhouse, err := clickhouseManager.GetCluster(namespace, c.Param("name"))
+	clickhouse, err := clickhouseManager.forRequest(c).GetCluster(namespace, c.Param("name"))
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get clickhouse: %v", err))
 		return
@@ -140,9 +162,9 @@ func GetClickhouseHandler(c *gin.Context) {
 	c.JSON(http.StatusOK, clickhouse)
 }
 
-// CreateCluster creates a new clickhouse cluster
-func (m *ClickhouseManager) CreateCluster(namespace string, cluster types.Clickhouse) error {
-	manifest := fmt.Sprintf(`apiVersion: clickhouse.altinity.com/v1
+// generateManifest generates a ClickHouseInstallation manifest for the cluster
+func (m *ClickhouseManager) generateManifest(namespace string, cluster types.Clickhouse) string {
+	return fmt.Sprintf(`apiVersion: clickhouse.altinity.com/v1
 kind: ClickHouseInstallation
 metadata:
   name: %s
@@ -155,68 +177,97 @@ spec:
           shardsCount: %d
           replicasCount: %d
 `, cluster.Name, namespace, cluster.Name, cluster.Shards, cluster.Replicas)
-	log.Println(manifest)
-	tmpFile, err := os.CreateTemp("", "clickhouse-*.yaml")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
-	}
-	defer tmpFile.Close()
-	defer os.Remove(tmpFile.Name())
+}
 
-	if err := os.WriteFile(tmpFile.Name(), []byte(manifest), 0644); err != nil {
-		return fmt.Errorf("failed to write manifest: %w", err)
+// generateResource generates the GovnoDatabase custom resource for the clickhouse cluster
+func (m *ClickhouseManager) generateResource(namespace string, cluster types.Clickhouse) types.GovnoDatabase {
+	return databaseResource(namespace, cluster.Name, types.GovnoDatabaseSpec{
+		Engine:   types.DatabaseEngineClickhouse,
+		Replicas: cluster.Replicas,
+		Shards:   cluster.Shards,
+	})
+}
+
+// clickhouseFromResource converts a GovnoDatabase to a clickhouse cluster
+func clickhouseFromResource(db *types.GovnoDatabase) types.Clickhouse {
+	return types.Clickhouse{
+		Name:      db.Name,
+		Namespace: db.Namespace,
+		Replicas:  db.Spec.Replicas,
+		Shards:    db.Spec.Shards,
 	}
+}
 
-	if out, err := m.kubectl.Run("apply", "-f", tmpFile.Name(), "-n", namespace, "--wait=true", "--timeout=300s"); err != nil {
-		return fmt.Errorf("failed to create clickhouse cluster: %w %s", err, out)
+// CreateCluster creates a new clickhouse cluster by writing its GovnoDatabase, the controller brings it up
+func (m *ClickhouseManager) CreateCluster(namespace string, cluster types.Clickhouse) error {
+	resource := m.generateResource(namespace, cluster)
+	m.logger.Debug("generated clickhouse resource", "resource", resource)
+	if err := createDatabase(m.kubectl, resource); err != nil {
+		return fmt.Errorf("failed to create clickhouse cluster: %w", err)
 	}
 	return nil
 }
 
+// clickhouseInstallationResource is the Altinity ClickHouseInstallation resource as understood by kubectl
+const clickhouseInstallationResource = "clickhouseinstallations.clickhouse.altinity.com"
+
+// applyDatabase applies the ClickHouseInstallation of a clickhouse GovnoDatabase
+func (m *ClickhouseManager) applyDatabase(db *types.GovnoDatabase) error {
+	manifest := m.generateManifest(db.Namespace, clickhouseFromResource(db))
+	m.logger.Debug("generated clickhouse manifest", "manifest", manifest)
+	if out, err := applyManifest(m.kubectl, manifest, "-n", db.Namespace); err != nil {
+		return fmt.Errorf("failed to apply clickhouse cluster: %w %s", err, out)
+	}
+	return setOwner(m.kubectl, clickhouseInstallationResource, db.Namespace, db.Name, govnoDatabaseKind, db.ObjectMeta)
+}
+
+// databaseState returns the state of the ClickHouseInstallation of a clickhouse GovnoDatabase
+func (m *ClickhouseManager) databaseState(namespace, name string) (resourceState, error) {
+	var installation struct {
+		Status struct {
+			Status string `json:"status"`
+		} `json:"status"`
+	}
+	found, err := getObject(m.kubectl, clickhouseInstallationResource, namespace, name, &installation)
+	if err != nil || !found {
+		return resourceState{}, err
+	}
+	return resourceState{
+		Found: true,
+		Phase: installation.Status.Status,
+		Ready: installation.Status.Status == "Completed",
+	}, nil
+}
+
 // GetCluster retrieves clickhouse cluster details
 func (m *ClickhouseManager) GetCluster(namespace, name string) (types.Clickhouse, error) {
-	out, err := m.kubectl.Run("get", "ClickHouseInstallation", name, "-o", "json", "-n", namespace)
+	db, err := getDatabase(m.kubectl, namespace, name, types.DatabaseEngineClickhouse)
 	if err != nil {
-		return types.Clickhouse{}, fmt.Errorf("failed to get clickhouse cluster: %w %s", err, out)
+		return types.Clickhouse{}, fmt.Errorf("failed to get clickhouse cluster: %w", err)
 	}
-	cluster := types.ClickhouseInstallation{}
-	if err := json.Unmarshal([]byte(out), &cluster); err != nil {
-		return types.Clickhouse{}, fmt.Errorf("failed to unmarshal clickhouse cluster: %w", err)
+	if db == nil {
+		return types.Clickhouse{}, fmt.Errorf("clickhouse cluster %s not found in namespace %s", name, namespace)
 	}
-	return types.Clickhouse{
-		Name:      cluster.Metadata.Name,
-		Namespace: namespace,
-		Shards:    cluster.Spec.Clickhouse.Shards,
-		Replicas:  cluster.Spec.Clickhouse.Replicas,
-	}, nil
+	return clickhouseFromResource(db), nil
 }
 
 // ListClusters lists all clickhouse clusters
 func (m *ClickhouseManager) ListClusters(namespace string) ([]types.Clickhouse, error) {
-	out, err := m.kubectl.Run("get", "ClickHouseInstallation", "-o", "json", "-n", namespace)
+	databases, err := listDatabases(m.kubectl, namespace, types.DatabaseEngineClickhouse)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get clickhouse clusters: %w", err)
 	}
-	clusterList := types.ClickhouseInstallationList{}
-	if err := json.Unmarshal([]byte(out), &clusterList); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal clickhouse cluster list: %w", err)
-	}
 	res := []types.Clickhouse{}
-	for _, cluster := range clusterList.Items {
-		res = append(res, types.Clickhouse{
-			Name:      cluster.Metadata.Name,
-			Namespace: namespace,
-			Shards:    cluster.Spec.Clickhouse.Shards,
-			Replicas:  cluster.Spec.Clickhouse.Replicas,
-		})
+	for i := range databases {
+		res = append(res, clickhouseFromResource(&databases[i]))
 	}
 	return res, nil
 }
 
 // DeleteCluster deletes a clickhouse cluster
 func (m *ClickhouseManager) DeleteCluster(namespace, name string) error {
-	if out, err := m.kubectl.Run("delete", "ClickHouseInstallation", name, "-n", namespace, "--wait=true", "--timeout=300s"); err != nil {
-		return fmt.Errorf("failed to delete clickhouse cluster: %w %s", err, out)
+	if err := deleteDatabase(m.kubectl, namespace, name, types.DatabaseEngineClickhouse); err != nil {
+		return fmt.Errorf("failed to delete clickhouse cluster: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// Keys of the cloud-init Secret as read by KubeVirt's cloudInitNoCloud secretRef
+const (
+	cloudInitUserDataKey    = "userdata"
+	cloudInitNetworkDataKey = "networkdata"
+)
+
+// vmLabel labels the objects generated for a VM with its name
+const vmLabel = types.CRDGroup + "/vm"
+
+// cloudInitSecretName returns the name of the cloud-init Secret govnocloud generates for a VM
+func cloudInitSecretName(vmName string) string {
+	return vmName + "-cloudinit"
+}
+
+// validateCloudInit rejects cloud-init settings of a VM that contradict each other
+func validateCloudInit(vm types.VM) error {
+	if vm.CloudInitSecret != "" && (vm.UserData != "" || vm.NetworkData != "" || len(vm.SSHKeys) > 0 || len(vm.SSHKeyNames) > 0 || vm.User != "") {
+		return fmt.Errorf("cloudInitSecret can't be combined with user, sshKeys, sshKeyNames, userData or networkData")
+	}
+	if vm.UserData != "" && (len(vm.SSHKeys) > 0 || len(vm.SSHKeyNames) > 0 || vm.User != "") {
+		return fmt.Errorf("userData can't be combined with user, sshKeys or sshKeyNames, add them to the user-data instead")
+	}
+	if len(vm.Networks) > 0 && (vm.CloudInitSecret != "" || vm.NetworkData != "") {
+		return fmt.Errorf("networks can't be combined with cloudInitSecret or networkData, their network-data is generated")
+	}
+	return nil
+}
+
+// generateUserData returns the cloud-init user-data of a VM, creating a user with the VM's SSH keys unless user-data is given
+func generateUserData(vm types.VM) (string, error) {
+	if vm.UserData != "" {
+		return vm.UserData, nil
+	}
+	user := vm.User
+	if user == "" {
+		user = types.DefaultVMUser
+	}
+	userConfig := map[string]any{
+		"name":        user,
+		"sudo":        "ALL=(ALL) NOPASSWD:ALL",
+		"shell":       "/bin/bash",
+		"lock_passwd": true,
+	}
+	if len(vm.SSHKeys) > 0 {
+		userConfig["ssh_authorized_keys"] = vm.SSHKeys
+	}
+	config := map[string]any{
+		"users":      []map[string]any{userConfig},
+		"ssh_pwauth": false,
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal user-data: %w", err)
+	}
+	return "#cloud-config\n" + string(data), nil
+}
+
+// generateCloudInitSecret generates the Secret of the given name holding the cloud-init user-data and network-data
+// of a VM
+func generateCloudInitSecret(namespace, name string, vm types.VM, labels map[string]string) (string, error) {
+	userData, err := generateUserData(vm)
+	if err != nil {
+		return "", err
+	}
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		StringData: map[string]string{cloudInitUserDataKey: userData},
+	}
+	if vm.NetworkData != "" {
+		secret.StringData[cloudInitNetworkDataKey] = vm.NetworkData
+	}
+	if len(vm.Networks) > 0 {
+		networkData, err := generateNetworkData(vm.Networks)
+		if err != nil {
+			return "", err
+		}
+		secret.StringData[cloudInitNetworkDataKey] = networkData
+	}
+	manifest, err := json.Marshal(secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cloud-init secret: %w", err)
+	}
+	return string(manifest), nil
+}
+
+// cloudInitSecretHasNetworkData checks that an existing cloud-init Secret has user-data and reports whether it has network-data
+func cloudInitSecretHasNetworkData(kubectl KubectlRunner, namespace, name string) (bool, error) {
+	var secret corev1.Secret
+	found, err := getObject(kubectl, "secrets", namespace, name, &secret)
+	if err != nil {
+		return false, err
+	}
+	if !found {
+		return false, fmt.Errorf("cloud-init secret %s not found in namespace %s", name, namespace)
+	}
+	if _, ok := secret.Data[cloudInitUserDataKey]; !ok {
+		return false, fmt.Errorf("cloud-init secret %s has no %s key", name, cloudInitUserDataKey)
+	}
+	_, ok := secret.Data[cloudInitNetworkDataKey]
+	return ok, nil
+}
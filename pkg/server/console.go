@@ -0,0 +1,193 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// Subprotocols of the console websockets: KubeVirt speaks plain.kubevirt.io, noVNC asks for binary
+const (
+	kubevirtSubprotocol = "plain.kubevirt.io"
+	binarySubprotocol   = "binary"
+)
+
+// consoleUpgrader upgrades console requests from the CLI and from the web UI origins
+var consoleUpgrader = websocket.Upgrader{
+	Subprotocols: []string{binarySubprotocol, kubevirtSubprotocol},
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || slices.Contains(allowedOrigins, origin)
+	},
+}
+
+// kubeConfig is the part of a minified kubeconfig needed to reach the API server
+type kubeConfig struct {
+	Clusters []struct {
+		Cluster struct {
+			Server                   string `json:"server"`
+			CertificateAuthorityData string `json:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `json:"insecure-skip-tls-verify"`
+		} `json:"cluster"`
+	} `json:"clusters"`
+	Users []struct {
+		User struct {
+			ClientCertificateData string `json:"client-certificate-data"`
+			ClientKeyData         string `json:"client-key-data"`
+			Token                 string `json:"token"`
+		} `json:"user"`
+	} `json:"users"`
+}
+
+// kubeDialer returns the API server URL and a websocket dialer authenticated like kubectl
+func kubeDialer(kubectl KubectlRunner) (string, *websocket.Dialer, http.Header, error) {
+	out, err := kubectl.Run("config", "view", "--raw", "--minify", "-o", "json")
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to read kubeconfig: %w", err)
+	}
+	var config kubeConfig
+	if err := json.Unmarshal(out, &config); err != nil {
+		return "", nil, nil, fmt.Errorf("failed to parse kubeconfig: %w", err)
+	}
+	if len(config.Clusters) == 0 || len(config.Users) == 0 {
+		return "", nil, nil, fmt.Errorf("kubeconfig has no cluster or user")
+	}
+	cluster, user := config.Clusters[0].Cluster, config.Users[0].User
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: cluster.InsecureSkipTLSVerify}
+	if cluster.CertificateAuthorityData != "" {
+		ca, err := base64.StdEncoding.DecodeString(cluster.CertificateAuthorityData)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to decode cluster CA: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(ca)
+	}
+	if user.ClientCertificateData != "" {
+		cert, err := base64.StdEncoding.DecodeString(user.ClientCertificateData)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to decode client certificate: %w", err)
+		}
+		key, err := base64.StdEncoding.DecodeString(user.ClientKeyData)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to decode client key: %w", err)
+		}
+		pair, err := tls.X509KeyPair(cert, key)
+		if err != nil {
+			return "", nil, nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{pair}
+	}
+	header := http.Header{}
+	if user.Token != "" {
+		header.Set("Authorization", "Bearer "+user.Token)
+	}
+	dialer := &websocket.Dialer{
+		TLSClientConfig:  tlsConfig,
+		HandshakeTimeout: 30 * time.Second,
+		Subprotocols:     []string{kubevirtSubprotocol},
+	}
+	return cluster.Server, dialer, header, nil
+}
+
+// dialVMI opens a websocket to a KubeVirt VirtualMachineInstance subresource, console or vnc
+func (m *VMManager) dialVMI(name, namespace, subresource string) (*websocket.Conn, error) {
+	server, dialer, header, err := kubeDialer(m.kubectl)
+	if err != nil {
+		return nil, err
+	}
+	endpoint, err := url.Parse(server)
+	if err != nil {
+		return nil, fmt.Errorf("invalid API server URL %s: %w", server, err)
+	}
+	endpoint.Scheme = strings.Replace(endpoint.Scheme, "http", "ws", 1)
+	endpoint.Path = fmt.Sprintf("/apis/subresources.kubevirt.io/v1/namespaces/%s/virtualmachineinstances/%s/%s",
+		url.PathEscape(namespace), url.PathEscape(name), subresource)
+	conn, resp, err := dialer.Dial(endpoint.String(), header)
+	if err != nil {
+		if resp != nil {
+			return nil, fmt.Errorf("failed to open %s of VM %s: %s %w", subresource, name, resp.Status, err)
+		}
+		return nil, fmt.Errorf("failed to open %s of VM %s: %w", subresource, name, err)
+	}
+	return conn, nil
+}
+
+// pipeWebsockets copies messages from src to dst until either side closes
+func pipeWebsockets(dst, src *websocket.Conn, done chan<- error) {
+	for {
+		messageType, data, err := src.ReadMessage()
+		if err != nil {
+			done <- err
+			return
+		}
+		if err := dst.WriteMessage(messageType, data); err != nil {
+			done <- err
+			return
+		}
+	}
+}
+
+// proxyVMI handles a console or VNC request by proxying the websocket to the KubeVirt subresource
+func proxyVMI(c *gin.Context, subresource string) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	if !websocket.IsWebSocketUpgrade(c.Request) {
+		respondWithError(c, http.StatusBadRequest, "websocket upgrade required")
+		return
+	}
+	m := vmManager.forRequest(c)
+	upstream, err := m.dialVMI(name, namespace, subresource)
+	if err != nil {
+		respondWithError(c, http.StatusBadGateway, err.Error())
+		return
+	}
+	defer upstream.Close()
+	conn, err := consoleUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// the upgrader already responded
+		m.logger.Warn("failed to upgrade console request", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	m.logger.Info("VM console opened", "name", name, "namespace", namespace, "subresource", subresource, "user", username)
+	done := make(chan error, 2)
+	go pipeWebsockets(upstream, conn, done)
+	go pipeWebsockets(conn, upstream, done)
+	err = <-done
+	m.logger.Info("VM console closed", "name", name, "namespace", namespace, "subresource", subresource, "reason", err)
+}
+
+// VMConsoleHandler proxies the serial console of a VM over a websocket
+func VMConsoleHandler(c *gin.Context) {
+	proxyVMI(c, "console")
+}
+
+// VMVNCHandler proxies the VNC display of a VM over a websocket
+func VMVNCHandler(c *gin.Context) {
+	proxyVMI(c, "vnc")
+}
@@ -1,26 +1,39 @@
 package server
 
 import (
-	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rusik69/govnocloud2/pkg/types"
-	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ContainerManager handles container operations
 type ContainerManager struct {
 	kubectl KubectlRunner
+	logger  *slog.Logger
 }
 
 // NewContainerManager creates a new container manager
 func NewContainerManager() *ContainerManager {
 	return &ContainerManager{
 		kubectl: &DefaultKubectlRunner{},
+		logger:  slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *ContainerManager) forRequest(c *gin.Context) *ContainerManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *ContainerManager) withLogger(logger *slog.Logger) *ContainerManager {
+	return &ContainerManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		logger:  logger,
 	}
 }
 
@@ -44,12 +57,12 @@ func ListContainersHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	containers, err := containerManager.ListContainers(namespace)
+	containers, err := containerManager.forRequest(c).ListContainers(namespace)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list containers: %v", err))
 		return
 	}
-	log.Printf("containers: %+v", containers)
+	requestLogger(c).Debug("listed containers", "namespace", namespace, "containers", containers)
 	c.JSON(http.StatusOK, containers)
 }
 
@@ -85,7 +98,16 @@ func CreateContainerHandler(c *gin.Context) {
 	}
 	container.Namespace = namespace
 	container.Name = name
-	if err := containerManager.CreateContainer(&container); err != nil {
+	if isDryRun(c) {
+		manifest, err := resourceManifest(containerManager.generateResource(&container))
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondWithDryRun(c, containerManager.forRequest(c).kubectl, manifest, namespace)
+		return
+	}
+	if err := containerManager.forRequest(c).CreateContainer(&container); err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create container: %v", err))
 		return
 	}
@@ -118,12 +140,12 @@ func GetContainerHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	container, err := containerManager.GetContainer(name, namespace)
+	container, err := containerManager.forRequest(c).GetContainer(name, namespace)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get container: %v", err))
 		return
 	}
-	log.Printf("%+v", container)
+	requestLogger(c).Debug("got container", "container", container)
 	if container == nil {
 		respondWithError(c, http.StatusNotFound, "container not found")
 		return
@@ -157,7 +179,7 @@ func DeleteContainerHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	if err := containerManager.DeleteContainer(name, namespace); err != nil {
+	if err := containerManager.forRequest(c).DeleteContainer(name, namespace); err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete container: %v", err))
 		return
 	}
@@ -194,93 +216,202 @@ spec:
 	return pod, nil
 }
 
-func (m *ContainerManager) ListContainers(namespace string) ([]types.Container, error) {
-	out, err := m.kubectl.Run("get", "pods", "-l", "type=container", "-o", "json", "-n", namespace)
-	if err != nil {
-		return nil, fmt.Errorf("failed to list containers: %s %w", out, err)
+// generateResource generates the GovnoContainer custom resource for the container
+func (m *ContainerManager) generateResource(container *types.Container) types.GovnoContainer {
+	return types.GovnoContainer{
+		TypeMeta:   typeMeta(govnoContainerKind),
+		ObjectMeta: metav1.ObjectMeta{Name: container.Name, Namespace: container.Namespace},
+		Spec: types.GovnoContainerSpec{
+			Image:     container.Image,
+			Port:      container.Port,
+			CPU:       container.CPU,
+			RAM:       container.RAM,
+			Disk:      container.Disk,
+			Volume:    container.Volume,
+			MountPath: container.MountPath,
+			Env:       container.Env,
+			Stopped:   container.Stopped,
+		},
+	}
+}
+
+// containerFromResource converts a GovnoContainer to a container
+func containerFromResource(resource *types.GovnoContainer) *types.Container {
+	return &types.Container{
+		Name:      resource.Name,
+		Namespace: resource.Namespace,
+		Image:     resource.Spec.Image,
+		Port:      resource.Spec.Port,
+		CPU:       resource.Spec.CPU,
+		RAM:       resource.Spec.RAM,
+		Disk:      resource.Spec.Disk,
+		Volume:    resource.Spec.Volume,
+		MountPath: resource.Spec.MountPath,
+		Env:       resource.Spec.Env,
+		Stopped:   resource.Spec.Stopped,
 	}
+}
 
-	var podList corev1.PodList
-	if err := json.Unmarshal([]byte(out), &podList); err != nil {
-		return nil, fmt.Errorf("failed to parse pod list: %w", err)
+func (m *ContainerManager) ListContainers(namespace string) ([]types.Container, error) {
+	var list types.GovnoContainerList
+	if err := listObjects(m.kubectl, govnoContainerResource, namespace, &list); err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
 	}
 
 	containers := []types.Container{}
-	for _, pod := range podList.Items {
-		container := podToContainer(&pod)
-		containers = append(containers, *container)
+	for i := range list.Items {
+		containers = append(containers, *containerFromResource(&list.Items[i]))
 	}
 
 	return containers, nil
 }
 
+// CreateContainer creates a container by writing its GovnoContainer, the controller starts its pod
 func (m *ContainerManager) CreateContainer(container *types.Container) error {
-	pod, err := m.generatePodManifest(container)
+	resource := m.generateResource(container)
+	m.logger.Debug("generated container resource", "resource", resource)
+	if err := applyResource(m.kubectl, resource); err != nil {
+		return fmt.Errorf("failed to create container: %w", err)
+	}
+	return nil
+}
+
+// applyPod applies the pod of a GovnoContainer, or deletes it while the container is stopped
+func (m *ContainerManager) applyPod(resource *types.GovnoContainer) error {
+	if resource.Spec.Stopped {
+		if out, err := m.kubectl.Run("delete", "pod", resource.Name, "-n", resource.Namespace, "--ignore-not-found", "--wait=false"); err != nil {
+			return fmt.Errorf("failed to delete pod of stopped container %s: %s %w", resource.Name, out, err)
+		}
+		return nil
+	}
+	pod, err := m.generatePodManifest(containerFromResource(resource))
 	if err != nil {
 		return fmt.Errorf("failed to generate pod manifest: %w", err)
 	}
+	m.logger.Debug("generated pod manifest", "manifest", pod)
+	if out, err := applyManifest(m.kubectl, pod, "-n", resource.Namespace); err != nil {
+		return fmt.Errorf("failed to create container pod: %s, %w", out, err)
+	}
+	return setOwner(m.kubectl, "pods", resource.Namespace, resource.Name, govnoContainerKind, resource.ObjectMeta)
+}
+
+// podState returns the state of the pod of a GovnoContainer
+func (m *ContainerManager) podState(namespace, name string) (resourceState, error) {
+	var pod struct {
+		Status struct {
+			Phase      string            `json:"phase"`
+			Conditions []objectCondition `json:"conditions"`
+		} `json:"status"`
+	}
+	found, err := getObject(m.kubectl, "pods", namespace, name, &pod)
+	if err != nil || !found {
+		return resourceState{}, err
+	}
+	ready, message := conditionTrue(pod.Status.Conditions, "Ready")
+	return resourceState{Found: true, Phase: pod.Status.Phase, Ready: ready, Message: message}, nil
+}
 
-	log.Printf("pod manifest: %v", string(pod))
-	tmpFile, err := os.CreateTemp("", "container-*.yaml")
+// GetContainer retrieves a container, returning nil when it does not exist
+func (m *ContainerManager) GetContainer(name, namespace string) (*types.Container, error) {
+	var resource types.GovnoContainer
+	found, err := getObject(m.kubectl, govnoContainerResource, namespace, name, &resource)
 	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+		return nil, fmt.Errorf("failed to get container: %w", err)
 	}
-	defer os.Remove(tmpFile.Name())
-
-	if err := os.WriteFile(tmpFile.Name(), []byte(pod), 0644); err != nil {
-		return fmt.Errorf("failed to write manifest: %w", err)
+	if !found {
+		return nil, nil
 	}
+	return containerFromResource(&resource), nil
+}
 
-	if out, err := m.kubectl.Run("apply", "-f", tmpFile.Name(), "-n", container.Namespace); err != nil {
-		return fmt.Errorf("failed to create container pod: %s, %w", out, err)
+// setStopped marks a container as stopped or started, the controller deletes or recreates its pod
+func (m *ContainerManager) setStopped(name, namespace string, stopped bool) error {
+	patch := map[string]any{"stopped": nil}
+	if stopped {
+		patch["stopped"] = true
 	}
-
-	// wait for pod to be ready
-	if out, err := m.kubectl.Run("wait", "--for=condition=ready", "pod", container.Name, "-n", container.Namespace, "--timeout=120s"); err != nil {
-		return fmt.Errorf("failed to wait for pod to be ready: %s, %w", out, err)
+	if err := patchSpec(m.kubectl, govnoContainerResource, namespace, name, patch); err != nil {
+		return fmt.Errorf("failed to update container %s in namespace %s: %w", name, namespace, err)
 	}
-
 	return nil
 }
 
-func (m *ContainerManager) GetContainer(name, namespace string) (*types.Container, error) {
-	out, err := m.kubectl.Run("get", "pod", name, "-o", "json", "-n", namespace)
+// StartContainer starts a stopped container
+func (m *ContainerManager) StartContainer(name, namespace string) error {
+	m.logger.Info("starting container", "name", name, "namespace", namespace)
+	return m.setStopped(name, namespace, false)
+}
+
+// StopContainer stops a container, deleting its pod until it is started again
+func (m *ContainerManager) StopContainer(name, namespace string) error {
+	m.logger.Info("stopping container", "name", name, "namespace", namespace)
+	return m.setStopped(name, namespace, true)
+}
+
+// RestartContainer recreates the pod of a running container
+func (m *ContainerManager) RestartContainer(name, namespace string) error {
+	var resource types.GovnoContainer
+	found, err := getObject(m.kubectl, govnoContainerResource, namespace, name, &resource)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get container pod: %s, %w", out, err)
+		return err
 	}
+	if !found {
+		return fmt.Errorf("container %s not found in namespace %s", name, namespace)
+	}
+	if resource.Spec.Stopped {
+		return fmt.Errorf("container %s is stopped", name)
+	}
+	m.logger.Info("restarting container", "name", name, "namespace", namespace)
+	if out, err := m.kubectl.Run("delete", "pod", name, "-n", namespace, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete pod of container %s: %s %w", name, out, err)
+	}
+	return m.applyPod(&resource)
+}
 
-	var pod corev1.Pod
-	if err := json.Unmarshal([]byte(out), &pod); err != nil {
-		return nil, fmt.Errorf("failed to parse pod details: %w", err)
+// containerPower runs a power action on the container of a request
+func containerPower(c *gin.Context, action string, power func(m *ContainerManager, name, namespace string) error) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
 	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	if err := power(containerManager.forRequest(c), name, namespace); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to %s container: %v", action, err))
+		return
+	}
+	requestLogger(c).Info("container power action", "action", action, "name", name, "namespace", namespace)
+	c.JSON(http.StatusOK, gin.H{"message": fmt.Sprintf("Container %s successful", action)})
+}
 
-	return podToContainer(&pod), nil
+// StartContainerHandler handles requests to start a stopped container
+func StartContainerHandler(c *gin.Context) {
+	containerPower(c, "start", (*ContainerManager).StartContainer)
 }
 
-func (m *ContainerManager) DeleteContainer(name, namespace string) error {
-	if out, err := m.kubectl.Run("delete", "pod", name, "-n", namespace, "--grace-period=0", "--force"); err != nil {
-		return fmt.Errorf("failed to delete container pod: %s, %w", out, err)
-	}
-	return nil
+// StopContainerHandler handles requests to stop a container
+func StopContainerHandler(c *gin.Context) {
+	containerPower(c, "stop", (*ContainerManager).StopContainer)
 }
 
-func podToContainer(pod *corev1.Pod) *types.Container {
-	container := pod.Spec.Containers[0]
-	return &types.Container{
-		Name:      pod.Name,
-		Namespace: pod.Namespace,
-		Image:     container.Image,
-		Port:      int(container.Ports[0].ContainerPort),
-		CPU:       int(container.Resources.Requests.Cpu().MilliValue()),
-		RAM:       int(container.Resources.Requests.Memory().Value() / 1024 / 1024), // Convert to Mi
-		Env:       envVarsToStrings(container.Env),
-	}
+// RestartContainerHandler handles requests to restart a container
+func RestartContainerHandler(c *gin.Context) {
+	containerPower(c, "restart", (*ContainerManager).RestartContainer)
 }
 
-func envVarsToStrings(envVars []corev1.EnvVar) []string {
-	var result []string
-	for _, env := range envVars {
-		result = append(result, fmt.Sprintf("%s=%s", env.Name, env.Value))
+// DeleteContainer deletes a container, its pod is garbage collected with the GovnoContainer
+func (m *ContainerManager) DeleteContainer(name, namespace string) error {
+	if err := deleteResource(m.kubectl, govnoContainerResource, namespace, name); err != nil {
+		return fmt.Errorf("failed to delete container: %w", err)
 	}
-	return result
+	return nil
 }
@@ -0,0 +1,275 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"time"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// controllerInterval is how often the controller reconciles all custom resources
+const controllerInterval = 10 * time.Second
+
+// resourceState is the observed state of the objects generated for a custom resource
+type resourceState struct {
+	// Found is whether the generated objects exist
+	Found bool
+	// Phase is the phase reported by the underlying operator
+	Phase string
+	// Ready is whether the generated objects are up and serving
+	Ready bool
+	// Message explains why the objects are not ready
+	Message string
+}
+
+// databaseEngine is implemented by the managers backing a GovnoDatabase engine
+type databaseEngine interface {
+	applyDatabase(db *types.GovnoDatabase) error
+	databaseState(namespace, name string) (resourceState, error)
+}
+
+// Controller reconciles govnocloud custom resources into KubeVirt, database operator and pod objects
+type Controller struct {
+	kubectl  KubectlRunner
+	logger   *slog.Logger
+	interval time.Duration
+}
+
+// NewController creates a new controller instance
+func NewController() *Controller {
+	logger := slog.Default().With("component", "controller")
+	return &Controller{
+		kubectl:  kubectlWithLogger(&DefaultKubectlRunner{}, logger),
+		logger:   logger,
+		interval: controllerInterval,
+	}
+}
+
+// Run reconciles all custom resources every interval until ctx is done
+func (c *Controller) Run(ctx context.Context) {
+	c.logger.Info("starting controller", "interval", c.interval)
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		c.ReconcileAll()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ReconcileAll reconciles every GovnoVM, GovnoDatabase and GovnoContainer in the cluster
+func (c *Controller) ReconcileAll() {
+	var vms types.GovnoVMList
+	if err := listObjects(c.kubectl, govnoVMResource, "", &vms); err != nil {
+		c.logger.Error("failed to list VMs", "error", err)
+	}
+	for i := range vms.Items {
+		if err := c.reconcileVM(&vms.Items[i]); err != nil {
+			c.logger.Warn("failed to reconcile VM", "name", vms.Items[i].Name, "namespace", vms.Items[i].Namespace, "error", err)
+		}
+	}
+
+	var databases types.GovnoDatabaseList
+	if err := listObjects(c.kubectl, govnoDatabaseResource, "", &databases); err != nil {
+		c.logger.Error("failed to list databases", "error", err)
+	}
+	for i := range databases.Items {
+		if err := c.reconcileDatabase(&databases.Items[i]); err != nil {
+			c.logger.Warn("failed to reconcile database", "name", databases.Items[i].Name, "namespace", databases.Items[i].Namespace, "error", err)
+		}
+	}
+
+	var containers types.GovnoContainerList
+	if err := listObjects(c.kubectl, govnoContainerResource, "", &containers); err != nil {
+		c.logger.Error("failed to list containers", "error", err)
+	}
+	for i := range containers.Items {
+		if err := c.reconcileContainer(&containers.Items[i]); err != nil {
+			c.logger.Warn("failed to reconcile container", "name", containers.Items[i].Name, "namespace", containers.Items[i].Namespace, "error", err)
+		}
+	}
+}
+
+// reconcileFuncs observe and apply the objects generated for a custom resource
+type reconcileFuncs struct {
+	state func() (resourceState, error)
+	apply func() error
+}
+
+// vmFuncs returns the reconcile funcs of a GovnoVM, generating a KubeVirt VirtualMachine
+func (c *Controller) vmFuncs(vm *types.GovnoVM) reconcileFuncs {
+	m := vmManager.withLogger(c.logger)
+	return reconcileFuncs{
+		state: func() (resourceState, error) { return m.virtualMachineState(vm.Namespace, vm.Name) },
+		apply: func() error { return m.applyVirtualMachine(vm) },
+	}
+}
+
+// databaseFuncs returns the reconcile funcs of a GovnoDatabase, generating the cluster of its engine's operator
+func (c *Controller) databaseFuncs(db *types.GovnoDatabase) (reconcileFuncs, error) {
+	var engine databaseEngine
+	switch db.Spec.Engine {
+	case types.DatabaseEnginePostgres:
+		engine = postgresManager.withLogger(c.logger)
+	case types.DatabaseEngineMysql:
+		engine = mysqlManager.withLogger(c.logger)
+	case types.DatabaseEngineClickhouse:
+		engine = clickhouseManager.withLogger(c.logger)
+	default:
+		return reconcileFuncs{}, fmt.Errorf("unknown database engine: %s", db.Spec.Engine)
+	}
+	return reconcileFuncs{
+		state: func() (resourceState, error) { return engine.databaseState(db.Namespace, db.Name) },
+		apply: func() error { return engine.applyDatabase(db) },
+	}, nil
+}
+
+// containerFuncs returns the reconcile funcs of a GovnoContainer, generating a Pod
+func (c *Controller) containerFuncs(container *types.GovnoContainer) reconcileFuncs {
+	m := containerManager.withLogger(c.logger)
+	return reconcileFuncs{
+		state: func() (resourceState, error) {
+			// the pod of a stopped container is deleted on purpose
+			if container.Spec.Stopped {
+				return resourceState{Found: true, Phase: "Stopped"}, nil
+			}
+			return m.podState(container.Namespace, container.Name)
+		},
+		apply: func() error { return m.applyPod(container) },
+	}
+}
+
+// funcsFor decodes a custom resource of the given kind and returns its reconcile funcs
+func (c *Controller) funcsFor(kind string, data []byte) (reconcileFuncs, error) {
+	switch kind {
+	case govnoVMKind:
+		var vm types.GovnoVM
+		if err := json.Unmarshal(data, &vm); err != nil {
+			return reconcileFuncs{}, err
+		}
+		return c.vmFuncs(&vm), nil
+	case govnoDatabaseKind:
+		var db types.GovnoDatabase
+		if err := json.Unmarshal(data, &db); err != nil {
+			return reconcileFuncs{}, err
+		}
+		return c.databaseFuncs(&db)
+	case govnoContainerKind:
+		var container types.GovnoContainer
+		if err := json.Unmarshal(data, &container); err != nil {
+			return reconcileFuncs{}, err
+		}
+		return c.containerFuncs(&container), nil
+	}
+	return reconcileFuncs{}, fmt.Errorf("unknown kind: %s", kind)
+}
+
+// reconcileVM reconciles a GovnoVM into a KubeVirt VirtualMachine
+func (c *Controller) reconcileVM(vm *types.GovnoVM) error {
+	return c.reconcile(govnoVMResource, vm.ObjectMeta, vm.Status, c.vmFuncs(vm))
+}
+
+// reconcileDatabase reconciles a GovnoDatabase into the cluster of its engine's operator
+func (c *Controller) reconcileDatabase(db *types.GovnoDatabase) error {
+	funcs, err := c.databaseFuncs(db)
+	if err != nil {
+		return err
+	}
+	return c.reconcile(govnoDatabaseResource, db.ObjectMeta, db.Status, funcs)
+}
+
+// reconcileContainer reconciles a GovnoContainer into a Pod
+func (c *Controller) reconcileContainer(container *types.GovnoContainer) error {
+	return c.reconcile(govnoContainerResource, container.ObjectMeta, container.Status, c.containerFuncs(container))
+}
+
+// reconcile applies the generated objects of a custom resource when its spec changed, then reports their state
+// in the resource's status. Objects that went missing after being applied are drift, repaired by the DriftDetector.
+func (c *Controller) reconcile(resource string, obj metav1.ObjectMeta, current types.ResourceStatus, funcs reconcileFuncs) error {
+	if obj.DeletionTimestamp != nil {
+		return nil
+	}
+	observed, err := funcs.state()
+	if err != nil {
+		return err
+	}
+	status := current
+	status.Conditions = append([]metav1.Condition(nil), current.Conditions...)
+
+	var applyErr error
+	if current.ObservedGeneration != obj.Generation {
+		c.logger.Info("applying resource", "resource", resource, "name", obj.Name, "namespace", obj.Namespace, "generation", obj.Generation)
+		if applyErr = funcs.apply(); applyErr != nil {
+			setCondition(&status, obj.Generation, types.ConditionApplied, false, "ApplyFailed", applyErr.Error())
+		} else {
+			setCondition(&status, obj.Generation, types.ConditionApplied, true, "Applied", "")
+			status.ObservedGeneration = obj.Generation
+			if observed, err = funcs.state(); err != nil {
+				return err
+			}
+		}
+	}
+
+	status.Phase = observed.Phase
+	if !observed.Found {
+		status.Phase = "Provisioning"
+		if status.ObservedGeneration == obj.Generation {
+			status.Phase = "Missing"
+			observed.Message = "generated objects are missing"
+		}
+	}
+	if observed.Ready {
+		setCondition(&status, obj.Generation, types.ConditionReady, true, "Ready", "")
+	} else {
+		setCondition(&status, obj.Generation, types.ConditionReady, false, "NotReady", observed.Message)
+	}
+
+	if !reflect.DeepEqual(status, current) {
+		if err := patchStatus(c.kubectl, resource, obj.Namespace, obj.Name, status); err != nil {
+			return err
+		}
+	}
+	return applyErr
+}
+
+// setCondition sets a condition on the status, keeping its transition time when the status is unchanged
+func setCondition(status *types.ResourceStatus, generation int64, conditionType string, ok bool, reason, message string) {
+	conditionStatus := metav1.ConditionFalse
+	if ok {
+		conditionStatus = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             conditionStatus,
+		ObservedGeneration: generation,
+		Reason:             reason,
+		Message:            message,
+	})
+}
+
+// objectCondition is a condition as reported by KubeVirt, operators and pods
+type objectCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// conditionTrue reports whether the condition is set to True, and its message
+func conditionTrue(conditions []objectCondition, conditionType string) (bool, string) {
+	for _, condition := range conditions {
+		if condition.Type == conditionType {
+			return condition.Status == string(metav1.ConditionTrue), condition.Message
+		}
+	}
+	return false, ""
+}
@@ -0,0 +1,475 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Resource names of the govnocloud custom resources as understood by kubectl
+const (
+	govnoVMResource        = "govnovms." + types.CRDGroup
+	govnoDatabaseResource  = "govnodatabases." + types.CRDGroup
+	govnoContainerResource = "govnocontainers." + types.CRDGroup
+)
+
+// Kinds of the govnocloud custom resources
+const (
+	govnoVMKind        = "GovnoVM"
+	govnoDatabaseKind  = "GovnoDatabase"
+	govnoContainerKind = "GovnoContainer"
+)
+
+// resourceKinds maps the resource names of the govnocloud custom resources to their kinds
+var resourceKinds = map[string]string{
+	govnoVMResource:        govnoVMKind,
+	govnoDatabaseResource:  govnoDatabaseKind,
+	govnoContainerResource: govnoContainerKind,
+}
+
+// engineLabel labels GovnoDatabases with their engine so they can be listed per engine
+const engineLabel = types.CRDGroup + "/engine"
+
+// crdPrinterColumns are the kubectl get columns shared by all govnocloud custom resources
+const crdPrinterColumns = `    additionalPrinterColumns:
+    - name: Phase
+      type: string
+      jsonPath: .status.phase
+    - name: Ready
+      type: string
+      jsonPath: .status.conditions[?(@.type=="Ready")].status
+    - name: Age
+      type: date
+      jsonPath: .metadata.creationTimestamp
+`
+
+// crdStatusSchema is the status schema shared by all govnocloud custom resources
+const crdStatusSchema = `          status:
+            type: object
+            properties:
+              phase:
+                type: string
+              observedGeneration:
+                type: integer
+                format: int64
+              conditions:
+                type: array
+                items:
+                  type: object
+                  x-kubernetes-preserve-unknown-fields: true
+`
+
+// crdManifest defines the GovnoVM, GovnoDatabase and GovnoContainer custom resources
+var crdManifest = fmt.Sprintf(`apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: %[1]s
+spec:
+  group: %[4]s
+  scope: Namespaced
+  names:
+    kind: GovnoVM
+    listKind: GovnoVMList
+    plural: govnovms
+    singular: govnovm
+    shortNames: [gvm]
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    subresources:
+      status: {}
+%[5]s    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [image, size]
+            properties:
+              image:
+                type: string
+              size:
+                type: string
+              cpu:
+                type: integer
+              ram:
+                type: integer
+              disk:
+                type: string
+              running:
+                type: boolean
+              runStrategy:
+                type: string
+                enum: [Always, RerunOnFailure, Manual]
+              idleTimeout:
+                type: string
+              restartedAt:
+                type: string
+              cloudInitSecret:
+                type: string
+              networkData:
+                type: boolean
+              sysprepSecret:
+                type: string
+              devices:
+                type: object
+                properties:
+                  firmware:
+                    type: string
+                    enum: [bios, uefi, secureboot]
+                  tpm:
+                    type: boolean
+                  diskBus:
+                    type: string
+                    enum: [virtio, sata]
+                  interfaceModel:
+                    type: string
+                    enum: [virtio, e1000e]
+                  provisioning:
+                    type: string
+                    enum: [cloud-init, cloudbase-init, sysprep, none]
+              serviceType:
+                type: string
+                enum: [NodePort, LoadBalancer]
+              ports:
+                type: array
+                items:
+                  type: object
+                  required: [port, destinationPort]
+                  properties:
+                    name:
+                      type: string
+                    port:
+                      type: integer
+                    destinationPort:
+                      type: integer
+                    protocol:
+                      type: string
+                      enum: [TCP, UDP]
+              volumes:
+                type: array
+                items:
+                  type: string
+              networks:
+                type: array
+                items:
+                  type: object
+                  required: [name, type]
+                  properties:
+                    name:
+                      type: string
+                    type:
+                      type: string
+                      enum: [pod, bridge, nad]
+                    nad:
+                      type: string
+                    mac:
+                      type: string
+                    addressing:
+                      type: string
+                      enum: [dhcp, static]
+                    address:
+                      type: string
+                    gateway:
+                      type: string
+                    dns:
+                      type: array
+                      items:
+                        type: string
+              placement:
+                type: object
+                properties:
+                  nodeSelector:
+                    type: object
+                    additionalProperties:
+                      type: string
+                  affinityGroups:
+                    type: array
+                    items:
+                      type: string
+                  antiAffinityGroups:
+                    type: array
+                    items:
+                      type: string
+                  preferred:
+                    type: boolean
+                  tolerations:
+                    type: array
+                    items:
+                      type: object
+                      properties:
+                        key:
+                          type: string
+                        operator:
+                          type: string
+                          enum: [Equal, Exists]
+                        value:
+                          type: string
+                        effect:
+                          type: string
+                          enum: [NoSchedule, PreferNoSchedule, NoExecute]
+                  dedicatedCpu:
+                    type: boolean
+                  hugepages:
+                    type: string
+                    enum: [2Mi, 1Gi]
+%[6]s---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: %[2]s
+spec:
+  group: %[4]s
+  scope: Namespaced
+  names:
+    kind: GovnoDatabase
+    listKind: GovnoDatabaseList
+    plural: govnodatabases
+    singular: govnodatabase
+    shortNames: [gdb]
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    subresources:
+      status: {}
+%[5]s    - name: Engine
+      type: string
+      jsonPath: .spec.engine
+    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [engine]
+            properties:
+              engine:
+                type: string
+                enum: [postgres, mysql, clickhouse]
+              size:
+                type: string
+              replicas:
+                type: integer
+              storage:
+                type: integer
+              routerReplicas:
+                type: integer
+              shards:
+                type: integer
+%[6]s---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: %[3]s
+spec:
+  group: %[4]s
+  scope: Namespaced
+  names:
+    kind: GovnoContainer
+    listKind: GovnoContainerList
+    plural: govnocontainers
+    singular: govnocontainer
+    shortNames: [gct]
+  versions:
+  - name: v1alpha1
+    served: true
+    storage: true
+    subresources:
+      status: {}
+%[5]s    schema:
+      openAPIV3Schema:
+        type: object
+        properties:
+          spec:
+            type: object
+            required: [image]
+            properties:
+              image:
+                type: string
+              port:
+                type: integer
+              cpu:
+                type: integer
+              ram:
+                type: integer
+              disk:
+                type: integer
+              volume:
+                type: string
+              mountPath:
+                type: string
+              env:
+                type: array
+                items:
+                  type: string
+              stopped:
+                type: boolean
+%[6]s`, govnoVMResource, govnoDatabaseResource, govnoContainerResource, types.CRDGroup, crdPrinterColumns, crdStatusSchema)
+
+// EnsureCRDs installs the govnocloud custom resource definitions and waits for them to be served
+func EnsureCRDs(kubectl KubectlRunner) error {
+	if out, err := applyManifest(kubectl, crdManifest); err != nil {
+		return fmt.Errorf("failed to apply custom resource definitions: %s %w", out, err)
+	}
+	for _, crd := range []string{govnoVMResource, govnoDatabaseResource, govnoContainerResource} {
+		if out, err := kubectl.Run("wait", "--for=condition=Established", "crd/"+crd, "--timeout=60s"); err != nil {
+			return fmt.Errorf("failed waiting for %s: %s %w", crd, out, err)
+		}
+	}
+	return nil
+}
+
+// applyManifest writes the manifest to a temporary file and applies it
+func applyManifest(kubectl KubectlRunner, manifest string, args ...string) ([]byte, error) {
+	tmpFile, err := os.CreateTemp("", "manifest-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(manifest); err != nil {
+		return nil, fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close temp file: %w", err)
+	}
+	return kubectl.Run(append([]string{"apply", "-f", tmpFile.Name()}, args...)...)
+}
+
+// resourceManifest renders a custom resource as a manifest, JSON being valid YAML
+func resourceManifest(resource any) (string, error) {
+	manifest, err := json.Marshal(resource)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal resource: %w", err)
+	}
+	return string(manifest), nil
+}
+
+// applyResource creates or updates a custom resource and records it as the desired state
+func applyResource(kubectl KubectlRunner, resource any) error {
+	manifest, err := resourceManifest(resource)
+	if err != nil {
+		return err
+	}
+	if out, err := applyManifest(kubectl, manifest); err != nil {
+		return fmt.Errorf("failed to apply resource: %s %w", out, err)
+	}
+	var desired desiredResource
+	if err := json.Unmarshal([]byte(manifest), &desired); err != nil {
+		return fmt.Errorf("failed to parse resource: %w", err)
+	}
+	return desiredStateManager.Put(desired)
+}
+
+// recordDesiredState stores the current spec of a custom resource as its desired state
+func recordDesiredState(kubectl KubectlRunner, resource, namespace, name string) error {
+	var desired desiredResource
+	found, err := getObject(kubectl, resource, namespace, name, &desired)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%s %s not found in namespace %s", resource, name, namespace)
+	}
+	return desiredStateManager.Put(desired)
+}
+
+// getObject reads an object into obj, reporting false when it does not exist
+func getObject(kubectl KubectlRunner, resource, namespace, name string, obj any) (bool, error) {
+	out, err := kubectl.Run("get", resource, name, "-n", namespace, "-o", "json", "--ignore-not-found")
+	if err != nil {
+		return false, fmt.Errorf("failed to get %s %s: %s %w", resource, name, out, err)
+	}
+	if len(out) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(out, obj); err != nil {
+		return false, fmt.Errorf("failed to parse %s %s: %w", resource, name, err)
+	}
+	return true, nil
+}
+
+// listObjects reads a list of objects into list, across all namespaces when namespace is empty
+func listObjects(kubectl KubectlRunner, resource, namespace string, list any, args ...string) error {
+	scope := []string{"-A"}
+	if namespace != "" {
+		scope = []string{"-n", namespace}
+	}
+	out, err := kubectl.Run(append(append([]string{"get", resource, "-o", "json"}, scope...), args...)...)
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %s %w", resource, out, err)
+	}
+	if err := json.Unmarshal(out, list); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", resource, err)
+	}
+	return nil
+}
+
+// setOwner makes a generated object owned by its custom resource, so deleting the resource garbage collects it
+func setOwner(kubectl KubectlRunner, resource, namespace, name string, ownerKind string, owner metav1.ObjectMeta) error {
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"ownerReferences": []metav1.OwnerReference{{
+				APIVersion:         types.CRDAPIVersion,
+				Kind:               ownerKind,
+				Name:               owner.Name,
+				UID:                owner.UID,
+				Controller:         boolPtr(true),
+				BlockOwnerDeletion: boolPtr(true),
+			}},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal owner reference: %w", err)
+	}
+	if out, err := kubectl.Run("patch", resource, name, "-n", namespace, "--type=merge", "-p", string(patch)); err != nil {
+		return fmt.Errorf("failed to set owner of %s %s: %s %w", resource, name, out, err)
+	}
+	return nil
+}
+
+// patchStatus replaces the status of a custom resource through its status subresource
+func patchStatus(kubectl KubectlRunner, resource, namespace, name string, status types.ResourceStatus) error {
+	patch, err := json.Marshal(map[string]any{"status": status})
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+	if out, err := kubectl.Run("patch", resource, name, "-n", namespace, "--subresource=status", "--type=merge", "-p", string(patch)); err != nil {
+		return fmt.Errorf("failed to update status of %s %s: %s %w", resource, name, out, err)
+	}
+	return nil
+}
+
+// patchSpec merges fields into the spec of a custom resource and records the result as the desired state
+func patchSpec(kubectl KubectlRunner, resource, namespace, name string, spec map[string]any) error {
+	patch, err := json.Marshal(map[string]any{"spec": spec})
+	if err != nil {
+		return fmt.Errorf("failed to marshal spec: %w", err)
+	}
+	if out, err := kubectl.Run("patch", resource, name, "-n", namespace, "--type=merge", "-p", string(patch)); err != nil {
+		return fmt.Errorf("failed to update %s %s: %s %w", resource, name, out, err)
+	}
+	return recordDesiredState(kubectl, resource, namespace, name)
+}
+
+// deleteResource deletes a custom resource and its desired state, its generated objects are garbage collected by Kubernetes
+func deleteResource(kubectl KubectlRunner, resource, namespace, name string) error {
+	if out, err := kubectl.Run("delete", resource, name, "-n", namespace, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete %s %s: %s %w", resource, name, out, err)
+	}
+	return desiredStateManager.Delete(resourceKinds[resource], namespace, name)
+}
+
+// typeMeta returns the TypeMeta of a govnocloud custom resource kind
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{APIVersion: types.CRDAPIVersion, Kind: kind}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
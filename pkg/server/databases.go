@@ -0,0 +1,68 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// databaseResource builds the GovnoDatabase custom resource of a database
+func databaseResource(namespace, name string, spec types.GovnoDatabaseSpec) types.GovnoDatabase {
+	return types.GovnoDatabase{
+		TypeMeta: typeMeta(govnoDatabaseKind),
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{engineLabel: spec.Engine},
+		},
+		Spec: spec,
+	}
+}
+
+// createDatabase writes a GovnoDatabase, refusing to replace a database of another engine with the same name
+func createDatabase(kubectl KubectlRunner, db types.GovnoDatabase) error {
+	var existing types.GovnoDatabase
+	found, err := getObject(kubectl, govnoDatabaseResource, db.Namespace, db.Name, &existing)
+	if err != nil {
+		return err
+	}
+	if found && existing.Spec.Engine != db.Spec.Engine {
+		return fmt.Errorf("database %s already exists with engine %s", db.Name, existing.Spec.Engine)
+	}
+	return applyResource(kubectl, db)
+}
+
+// getDatabase reads a GovnoDatabase of the given engine, returning nil when it does not exist
+func getDatabase(kubectl KubectlRunner, namespace, name, engine string) (*types.GovnoDatabase, error) {
+	var db types.GovnoDatabase
+	found, err := getObject(kubectl, govnoDatabaseResource, namespace, name, &db)
+	if err != nil {
+		return nil, err
+	}
+	if !found || db.Spec.Engine != engine {
+		return nil, nil
+	}
+	return &db, nil
+}
+
+// listDatabases lists the GovnoDatabases of the given engine in a namespace
+func listDatabases(kubectl KubectlRunner, namespace, engine string) ([]types.GovnoDatabase, error) {
+	var list types.GovnoDatabaseList
+	if err := listObjects(kubectl, govnoDatabaseResource, namespace, &list, "-l", engineLabel+"="+engine); err != nil {
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// deleteDatabase deletes a GovnoDatabase of the given engine, its operator cluster is garbage collected with it
+func deleteDatabase(kubectl KubectlRunner, namespace, name, engine string) error {
+	db, err := getDatabase(kubectl, namespace, name, engine)
+	if err != nil {
+		return err
+	}
+	if db == nil {
+		return fmt.Errorf("%s database %s not found in namespace %s", engine, name, namespace)
+	}
+	return deleteResource(kubectl, govnoDatabaseResource, namespace, name)
+}
@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// desiredStatePrefix is the etcd prefix the desired state of govnocloud resources is stored under
+const desiredStatePrefix = "/desired/"
+
+// DesiredStateManager stores the desired state of govnocloud custom resources in etcd
+type DesiredStateManager struct {
+	etcdClient *clientv3.Client
+}
+
+// desiredResource is the kind independent desired state of a govnocloud custom resource
+type desiredResource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              json.RawMessage `json:"spec"`
+}
+
+// liveResource is a govnocloud custom resource as read from the cluster
+type liveResource struct {
+	desiredResource
+	Status struct {
+		ObservedGeneration int64 `json:"observedGeneration"`
+	} `json:"status"`
+}
+
+// liveResourceList is a list of govnocloud custom resources as read from the cluster
+type liveResourceList struct {
+	Items []liveResource `json:"items"`
+}
+
+// newDesiredResource keeps only the parts of a custom resource that make up its desired state
+func newDesiredResource(resource desiredResource) desiredResource {
+	return desiredResource{
+		TypeMeta: resource.TypeMeta,
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resource.Name,
+			Namespace: resource.Namespace,
+			Labels:    resource.Labels,
+		},
+		Spec: resource.Spec,
+	}
+}
+
+// NewDesiredStateManager creates a new desired state manager
+func NewDesiredStateManager(etcdClient *clientv3.Client) *DesiredStateManager {
+	return &DesiredStateManager{etcdClient: etcdClient}
+}
+
+// etcdKey returns the etcd key of a resource
+func (m *DesiredStateManager) etcdKey(kind, namespace, name string) string {
+	return fmt.Sprintf("%s%s/%s/%s", desiredStatePrefix, kind, namespace, name)
+}
+
+// Put stores the desired state of a resource
+func (m *DesiredStateManager) Put(resource desiredResource) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(newDesiredResource(resource))
+	if err != nil {
+		return fmt.Errorf("failed to marshal desired state: %w", err)
+	}
+	if _, err := m.etcdClient.Put(ctx, m.etcdKey(resource.Kind, resource.Namespace, resource.Name), string(data)); err != nil {
+		return fmt.Errorf("failed to store desired state: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the desired state of a resource
+func (m *DesiredStateManager) Delete(kind, namespace, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := m.etcdClient.Delete(ctx, m.etcdKey(kind, namespace, name)); err != nil {
+		return fmt.Errorf("failed to delete desired state: %w", err)
+	}
+	return nil
+}
+
+// List returns the desired state of all resources of a kind
+func (m *DesiredStateManager) List(kind string) ([]desiredResource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, desiredStatePrefix+kind+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list desired state: %w", err)
+	}
+	resources := make([]desiredResource, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var resource desiredResource
+		if err := json.Unmarshal(kv.Value, &resource); err != nil {
+			return nil, fmt.Errorf("failed to parse desired state %s: %w", kv.Key, err)
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
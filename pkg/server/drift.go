@@ -0,0 +1,244 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// driftInterval is how often the drift detector compares the desired state with the cluster
+const driftInterval = time.Minute
+
+// driftKinds are the custom resources checked for drift, with their resource names
+var driftKinds = []struct {
+	kind     string
+	resource string
+}{
+	{govnoVMKind, govnoVMResource},
+	{govnoDatabaseKind, govnoDatabaseResource},
+	{govnoContainerKind, govnoContainerResource},
+}
+
+// DriftDetector compares the desired state stored in etcd with the cluster and optionally heals drift
+type DriftDetector struct {
+	kubectl    KubectlRunner
+	logger     *slog.Logger
+	controller *Controller
+	autoHeal   bool
+	interval   time.Duration
+
+	// checkMu serializes checks so a manual heal does not race the background loop
+	checkMu sync.Mutex
+	mu      sync.Mutex
+	report  types.DriftReport
+}
+
+// NewDriftDetector creates a new drift detector healing through the controller's apply funcs
+func NewDriftDetector(controller *Controller, autoHeal bool) *DriftDetector {
+	logger := slog.Default().With("component", "drift")
+	return &DriftDetector{
+		kubectl:    kubectlWithLogger(&DefaultKubectlRunner{}, logger),
+		logger:     logger,
+		controller: controller,
+		autoHeal:   autoHeal,
+		interval:   driftInterval,
+		report:     types.DriftReport{AutoHeal: autoHeal, Drifts: []types.Drift{}},
+	}
+}
+
+// Run checks for drift every interval until ctx is done, healing it when auto-heal is enabled
+func (d *DriftDetector) Run(ctx context.Context) {
+	d.logger.Info("starting drift detector", "interval", d.interval, "autoHeal", d.autoHeal)
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if _, err := d.Check(d.autoHeal); err != nil {
+			d.logger.Error("failed to check drift", "error", err)
+		}
+	}
+}
+
+// Report returns the result of the last check
+func (d *DriftDetector) Report() types.DriftReport {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.report
+}
+
+// Check compares the desired state of every resource with the cluster, healing drift when heal is set
+func (d *DriftDetector) Check(heal bool) (types.DriftReport, error) {
+	d.checkMu.Lock()
+	defer d.checkMu.Unlock()
+
+	report := types.DriftReport{CheckedAt: time.Now().UTC(), AutoHeal: d.autoHeal, Drifts: []types.Drift{}}
+	for _, kind := range driftKinds {
+		drifts, err := d.checkKind(kind.kind, kind.resource, heal)
+		if err != nil {
+			return report, err
+		}
+		report.Drifts = append(report.Drifts, drifts...)
+	}
+	for _, drift := range report.Drifts {
+		d.logger.Warn("drift detected", "kind", drift.Kind, "name", drift.Name, "namespace", drift.Namespace,
+			"reason", drift.Reason, "healed", drift.Healed, "error", drift.Error)
+	}
+
+	d.mu.Lock()
+	d.report = report
+	d.mu.Unlock()
+	return report, nil
+}
+
+// checkKind compares the desired state of the resources of one kind with the cluster
+func (d *DriftDetector) checkKind(kind, resource string, heal bool) ([]types.Drift, error) {
+	desired, err := desiredStateManager.List(kind)
+	if err != nil {
+		return nil, err
+	}
+	var live liveResourceList
+	if err := listObjects(d.kubectl, resource, "", &live); err != nil {
+		return nil, err
+	}
+	liveByKey := make(map[string]liveResource, len(live.Items))
+	for _, item := range live.Items {
+		liveByKey[item.Namespace+"/"+item.Name] = item
+	}
+
+	drifts := []types.Drift{}
+	for _, want := range desired {
+		drift := types.Drift{Kind: kind, Namespace: want.Namespace, Name: want.Name}
+		var repair func() error
+		got, ok := liveByKey[want.Namespace+"/"+want.Name]
+		switch {
+		case !ok:
+			drift.Reason = types.DriftResourceMissing
+			drift.Message = fmt.Sprintf("%s was deleted outside govnocloud", kind)
+			repair = func() error { return applyResource(d.kubectl, want) }
+		case !sameSpec(want.Spec, got.Spec):
+			drift.Reason = types.DriftSpecChanged
+			drift.Message = fmt.Sprintf("%s spec was changed outside govnocloud", kind)
+			repair = func() error { return applyResource(d.kubectl, want) }
+		default:
+			// objects of a resource the controller has not applied yet are not drift
+			if got.Status.ObservedGeneration != got.Generation {
+				continue
+			}
+			data, err := json.Marshal(got)
+			if err != nil {
+				return nil, err
+			}
+			funcs, err := d.controller.funcsFor(kind, data)
+			if err != nil {
+				return nil, err
+			}
+			state, err := funcs.state()
+			if err != nil {
+				return nil, err
+			}
+			if state.Found {
+				continue
+			}
+			drift.Reason = types.DriftObjectsMissing
+			drift.Message = "generated objects were deleted outside govnocloud"
+			repair = funcs.apply
+		}
+		if heal {
+			if err := repair(); err != nil {
+				drift.Error = err.Error()
+			} else {
+				drift.Healed = true
+			}
+		}
+		drifts = append(drifts, drift)
+	}
+	return drifts, nil
+}
+
+// sameSpec reports whether two specs are equal regardless of field order
+func sameSpec(a, b json.RawMessage) bool {
+	var specA, specB any
+	if err := json.Unmarshal(a, &specA); err != nil {
+		return false
+	}
+	if err := json.Unmarshal(b, &specB); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(specA, specB)
+}
+
+// filterDrift returns the drifts of a namespace
+func filterDrift(report types.DriftReport, namespace string) types.DriftReport {
+	drifts := []types.Drift{}
+	for _, drift := range report.Drifts {
+		if drift.Namespace == namespace {
+			drifts = append(drifts, drift)
+		}
+	}
+	report.Drifts = drifts
+	return report
+}
+
+// GetDriftHandler handles requests for the last drift report, admins see all namespaces
+func GetDriftHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	report := driftDetector.Report()
+	namespace := c.Query("namespace")
+	if namespace == "" {
+		if !CheckAdminAccess(username) {
+			respondWithError(c, http.StatusForbidden, "user does not have admin access")
+			return
+		}
+		c.JSON(http.StatusOK, report)
+		return
+	}
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	c.JSON(http.StatusOK, filterDrift(report, namespace))
+}
+
+// HealDriftHandler handles requests to check for drift now and heal it
+func HealDriftHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if !CheckAdminAccess(username) {
+		respondWithError(c, http.StatusForbidden, "user does not have admin access")
+		return
+	}
+	report, err := driftDetector.Check(true)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to heal drift: %v", err))
+		return
+	}
+	requestLogger(c).Info("drift healed", "drifts", len(report.Drifts))
+	c.JSON(http.StatusOK, report)
+}
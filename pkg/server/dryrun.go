@@ -0,0 +1,48 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// isDryRun reports whether the request asked for validation only via ?dryRun=true
+func isDryRun(c *gin.Context) bool {
+	dryRun, err := strconv.ParseBool(c.DefaultQuery("dryRun", "false"))
+	return err == nil && dryRun
+}
+
+// dryRunManifest validates a manifest with a server-side dry run and returns the objects as the API server would store them
+func dryRunManifest(kubectl KubectlRunner, manifest, namespace string) (string, error) {
+	tmpFile, err := os.CreateTemp("", "dryrun-*.yaml")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(manifest); err != nil {
+		return "", fmt.Errorf("failed to write manifest: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return "", fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	out, err := kubectl.Run("apply", "--dry-run=server", "-f", tmpFile.Name(), "-n", namespace, "-o", "yaml")
+	if err != nil {
+		return "", fmt.Errorf("dry run failed: %s %w", out, err)
+	}
+	return string(out), nil
+}
+
+// respondWithDryRun validates the manifest against the cluster and responds with the result instead of applying it
+func respondWithDryRun(c *gin.Context, kubectl KubectlRunner, manifest, namespace string) {
+	out, err := dryRunManifest(kubectl, manifest, namespace)
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	respondWithSuccess(c, gin.H{"dryRun": true, "manifest": out})
+}
@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// grpcRequestIDKey is the metadata key carrying the request ID, the gRPC counterpart of RequestIDHeader
+const grpcRequestIDKey = "x-request-id"
+
+// watchInterval is how often watch RPCs poll for changes
+var watchInterval = 5 * time.Second
+
+type grpcUserKey struct{}
+
+// newGRPCServer creates a gRPC server with all govnocloud services registered
+func newGRPCServer() *grpc.Server {
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unaryInterceptor),
+		grpc.ChainStreamInterceptor(streamInterceptor),
+	)
+	api.RegisterVMServiceServer(srv, &vmService{})
+	api.RegisterContainerServiceServer(srv, &containerService{})
+	api.RegisterVolumeServiceServer(srv, &volumeService{})
+	api.RegisterPostgresServiceServer(srv, &postgresService{})
+	api.RegisterMysqlServiceServer(srv, &mysqlService{})
+	api.RegisterClickhouseServiceServer(srv, &clickhouseService{})
+	api.RegisterLLMServiceServer(srv, &llmService{})
+	api.RegisterNamespaceServiceServer(srv, &namespaceService{})
+	api.RegisterNodeServiceServer(srv, &nodeService{})
+	api.RegisterUserServiceServer(srv, &userService{})
+	reflection.Register(srv)
+	return srv
+}
+
+// startGRPC listens on the gRPC port and serves the gRPC API in the background
+func (s *Server) startGRPC() error {
+	addr := fmt.Sprintf("%s:%s", s.config.Host, s.config.GRPCPort)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+
+	s.grpcServer = newGRPCServer()
+	slog.Info("starting grpc server", "addr", addr)
+	go func() {
+		if err := s.grpcServer.Serve(listener); err != nil {
+			slog.Error("grpc server failed", "error", err)
+		}
+	}()
+	return nil
+}
+
+// grpcRequestContext tags the call with a request ID and stores a logger for it in the context
+func grpcRequestContext(ctx context.Context) (context.Context, string) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(grpcRequestIDKey); len(values) > 0 {
+			requestID = values[0]
+		}
+	}
+	if !validRequestID.MatchString(requestID) {
+		requestID = logging.NewRequestID()
+	}
+	logger := slog.Default().With("request_id", requestID)
+	return logging.WithLogger(ctx, logger), requestID
+}
+
+// grpcAuthenticate checks the basic auth credentials in the call metadata and stores the username in the context
+func grpcAuthenticate(ctx context.Context) (context.Context, error) {
+	logger := logging.FromContext(ctx)
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		logger.Info("authentication failed: missing basic auth credentials")
+		return ctx, status.Error(codes.Unauthenticated, "missing basic auth credentials")
+	}
+
+	// Reuse net/http's parser so both APIs accept exactly the same header
+	req := http.Request{Header: http.Header{"Authorization": values[:1]}}
+	username, password, ok := req.BasicAuth()
+	if !ok {
+		logger.Info("authentication failed: malformed basic auth credentials")
+		return ctx, status.Error(codes.Unauthenticated, "malformed basic auth credentials")
+	}
+	if err := authenticate(logger, username, password); err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return context.WithValue(ctx, grpcUserKey{}, username), nil
+}
+
+// grpcUsername returns the authenticated user of the call
+func grpcUsername(ctx context.Context) string {
+	username, _ := ctx.Value(grpcUserKey{}).(string)
+	return username
+}
+
+// unaryInterceptor logs, authenticates and recovers unary calls
+func unaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	start := time.Now()
+	ctx, requestID := grpcRequestContext(ctx)
+	logger := logging.FromContext(ctx)
+	if err := grpc.SetHeader(ctx, metadata.Pairs(grpcRequestIDKey, requestID)); err != nil {
+		logger.Warn("failed to set request id header", "error", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("panic recovered", "panic", r)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+		logger.Info("grpc request",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration", time.Since(start).String(),
+		)
+	}()
+
+	ctx, err = grpcAuthenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// contextServerStream overrides the context of a server stream
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// streamInterceptor logs, authenticates and recovers streaming calls
+func streamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	start := time.Now()
+	ctx, requestID := grpcRequestContext(ss.Context())
+	logger := logging.FromContext(ctx)
+	if err := ss.SetHeader(metadata.Pairs(grpcRequestIDKey, requestID)); err != nil {
+		logger.Warn("failed to set request id header", "error", err)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error("panic recovered", "panic", r)
+			err = status.Error(codes.Internal, "internal server error")
+		}
+		logger.Info("grpc stream",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration", time.Since(start).String(),
+		)
+	}()
+
+	ctx, err = grpcAuthenticate(ctx)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+}
+
+// authorizeNamespace checks that the caller may use resources in the namespace
+func authorizeNamespace(ctx context.Context, namespace string) error {
+	if namespace == "" {
+		return status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	if !CheckNamespaceAccess(grpcUsername(ctx), namespace) {
+		return status.Error(codes.PermissionDenied, "user does not have access to this namespace")
+	}
+	return nil
+}
+
+// authorizeResource checks that the caller may use the named resource in the namespace
+func authorizeResource(ctx context.Context, namespace, name string) error {
+	if err := authorizeNamespace(ctx, namespace); err != nil {
+		return err
+	}
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	return nil
+}
+
+// authorizeAdmin checks that the caller is an admin
+func authorizeAdmin(ctx context.Context) error {
+	if !CheckAdminAccess(grpcUsername(ctx)) {
+		return status.Error(codes.PermissionDenied, "user does not have admin access")
+	}
+	return nil
+}
+
+// requireName checks that a cluster scoped resource name was given
+func requireName(name string) error {
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "name is required")
+	}
+	return nil
+}
+
+// grpcError logs a failed manager call and converts it to an internal gRPC error
+func grpcError(ctx context.Context, msg string, err error) error {
+	logging.FromContext(ctx).Error(msg, "error", err)
+	return status.Errorf(codes.Internal, "%s: %v", msg, err)
+}
+
+// sortedValues returns the values of m ordered by key
+func sortedValues[T any](m map[string]T) []T {
+	values := make([]T, 0, len(m))
+	for _, key := range slices.Sorted(maps.Keys(m)) {
+		values = append(values, m[key])
+	}
+	return values
+}
+
+// watchResources polls list until the stream ends and calls send for every resource that was added, modified or deleted.
+// Resources that exist when the watch starts are sent as added.
+func watchResources[T proto.Message](ctx context.Context, list func() (map[string]T, error), send func(api.EventType, T) error) error {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	known := map[string]T{}
+	for {
+		current, err := list()
+		if err != nil {
+			return grpcError(ctx, "failed to list resources", err)
+		}
+		for _, key := range slices.Sorted(maps.Keys(current)) {
+			previous, ok := known[key]
+			switch {
+			case !ok:
+				err = send(api.EventType_ADDED, current[key])
+			case !proto.Equal(previous, current[key]):
+				err = send(api.EventType_MODIFIED, current[key])
+			}
+			if err != nil {
+				return err
+			}
+		}
+		for _, key := range slices.Sorted(maps.Keys(known)) {
+			if _, ok := current[key]; !ok {
+				if err := send(api.EventType_DELETED, known[key]); err != nil {
+					return err
+				}
+			}
+		}
+		known = current
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+)
+
+// clickhouseService implements api.ClickhouseServiceServer on top of the clickhouse manager
+type clickhouseService struct {
+	api.UnimplementedClickhouseServiceServer
+}
+
+// clickhouseToProto converts a clickhouse cluster to its protobuf form
+func clickhouseToProto(cluster types.Clickhouse) *api.Clickhouse {
+	return &api.Clickhouse{
+		Name:      cluster.Name,
+		Namespace: cluster.Namespace,
+		Replicas:  int32(cluster.Replicas),
+		Shards:    int32(cluster.Shards),
+	}
+}
+
+// clickhouseFromProto converts a protobuf clickhouse cluster to a clickhouse cluster
+func clickhouseFromProto(cluster *api.Clickhouse) types.Clickhouse {
+	return types.Clickhouse{
+		Name:      cluster.GetName(),
+		Namespace: cluster.GetNamespace(),
+		Replicas:  int(cluster.GetReplicas()),
+		Shards:    int(cluster.GetShards()),
+	}
+}
+
+// listClickhouse returns the clickhouse clusters of a namespace keyed by name
+func listClickhouse(m *ClickhouseManager, namespace string) (map[string]*api.Clickhouse, error) {
+	list, err := m.ListClusters(namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusters := make(map[string]*api.Clickhouse, len(list))
+	for _, cluster := range list {
+		cluster.Namespace = namespace
+		clusters[cluster.Name] = clickhouseToProto(cluster)
+	}
+	return clusters, nil
+}
+
+func (s *clickhouseService) ListClickhouse(ctx context.Context, req *api.NamespaceRequest) (*api.ListClickhouseResponse, error) {
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	clusters, err := listClickhouse(clickhouseManager.withLogger(logging.FromContext(ctx)), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list clickhouse", err)
+	}
+	return &api.ListClickhouseResponse{Clusters: sortedValues(clusters)}, nil
+}
+
+func (s *clickhouseService) GetClickhouse(ctx context.Context, req *api.ResourceRequest) (*api.Clickhouse, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	cluster, err := clickhouseManager.withLogger(logging.FromContext(ctx)).GetCluster(req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get clickhouse", err)
+	}
+	cluster.Namespace = req.GetNamespace()
+	return clickhouseToProto(cluster), nil
+}
+
+func (s *clickhouseService) CreateClickhouse(ctx context.Context, req *api.Clickhouse) (*api.Clickhouse, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	cluster := clickhouseFromProto(req)
+	if err := clickhouseManager.withLogger(logging.FromContext(ctx)).CreateCluster(cluster.Namespace, cluster); err != nil {
+		return nil, grpcError(ctx, "failed to create clickhouse", err)
+	}
+	return clickhouseToProto(cluster), nil
+}
+
+func (s *clickhouseService) DeleteClickhouse(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := clickhouseManager.withLogger(logging.FromContext(ctx)).DeleteCluster(req.GetNamespace(), req.GetName()); err != nil {
+		return nil, grpcError(ctx, "failed to delete clickhouse", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *clickhouseService) WatchClickhouse(req *api.NamespaceRequest, stream grpc.ServerStreamingServer[api.ClickhouseEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return err
+	}
+	m := clickhouseManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.Clickhouse, error) {
+		return listClickhouse(m, req.GetNamespace())
+	}, func(eventType api.EventType, cluster *api.Clickhouse) error {
+		return stream.Send(&api.ClickhouseEvent{Type: eventType, Clickhouse: cluster})
+	})
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// containerService implements api.ContainerServiceServer on top of the container manager
+type containerService struct {
+	api.UnimplementedContainerServiceServer
+}
+
+// containerToProto converts a container to its protobuf form
+func containerToProto(container types.Container) *api.Container {
+	return &api.Container{
+		Name:      container.Name,
+		Namespace: container.Namespace,
+		Image:     container.Image,
+		Port:      int32(container.Port),
+		Cpu:       int32(container.CPU),
+		Ram:       int32(container.RAM),
+		Disk:      int32(container.Disk),
+		Volume:    container.Volume,
+		MountPath: container.MountPath,
+		Env:       container.Env,
+	}
+}
+
+// containerFromProto converts a protobuf container to a container
+func containerFromProto(container *api.Container) types.Container {
+	return types.Container{
+		Name:      container.GetName(),
+		Namespace: container.GetNamespace(),
+		Image:     container.GetImage(),
+		Port:      int(container.GetPort()),
+		CPU:       int(container.GetCpu()),
+		RAM:       int(container.GetRam()),
+		Disk:      int(container.GetDisk()),
+		Volume:    container.GetVolume(),
+		MountPath: container.GetMountPath(),
+		Env:       container.GetEnv(),
+	}
+}
+
+// listContainers returns the containers of a namespace keyed by name
+func listContainers(m *ContainerManager, namespace string) (map[string]*api.Container, error) {
+	list, err := m.ListContainers(namespace)
+	if err != nil {
+		return nil, err
+	}
+	containers := make(map[string]*api.Container, len(list))
+	for _, container := range list {
+		containers[container.Name] = containerToProto(container)
+	}
+	return containers, nil
+}
+
+func (s *containerService) ListContainers(ctx context.Context, req *api.NamespaceRequest) (*api.ListContainersResponse, error) {
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	containers, err := listContainers(containerManager.withLogger(logging.FromContext(ctx)), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list containers", err)
+	}
+	return &api.ListContainersResponse{Containers: sortedValues(containers)}, nil
+}
+
+func (s *containerService) GetContainer(ctx context.Context, req *api.ResourceRequest) (*api.Container, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	container, err := containerManager.withLogger(logging.FromContext(ctx)).GetContainer(req.GetName(), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get container", err)
+	}
+	if container == nil {
+		return nil, status.Error(codes.NotFound, "container not found")
+	}
+	return containerToProto(*container), nil
+}
+
+func (s *containerService) CreateContainer(ctx context.Context, req *api.Container) (*api.Container, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	container := containerFromProto(req)
+	if err := containerManager.withLogger(logging.FromContext(ctx)).CreateContainer(&container); err != nil {
+		return nil, grpcError(ctx, "failed to create container", err)
+	}
+	return containerToProto(container), nil
+}
+
+func (s *containerService) DeleteContainer(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := containerManager.withLogger(logging.FromContext(ctx)).DeleteContainer(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to delete container", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *containerService) WatchContainers(req *api.NamespaceRequest, stream grpc.ServerStreamingServer[api.ContainerEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return err
+	}
+	m := containerManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.Container, error) {
+		return listContainers(m, req.GetNamespace())
+	}, func(eventType api.EventType, container *api.Container) error {
+		return stream.Send(&api.ContainerEvent{Type: eventType, Container: container})
+	})
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// llmService implements api.LLMServiceServer on top of the LLM manager
+type llmService struct {
+	api.UnimplementedLLMServiceServer
+}
+
+// llmToProto converts an LLM to its protobuf form
+func llmToProto(llm types.LLM) *api.LLM {
+	return &api.LLM{
+		Name:      llm.Name,
+		Namespace: llm.Namespace,
+		Type:      llm.Type,
+	}
+}
+
+// listLLMs returns the LLMs of a namespace keyed by name
+func listLLMs(m *LLMManager, namespace string) (map[string]*api.LLM, error) {
+	list, err := m.ListLLMs(namespace)
+	if err != nil {
+		return nil, err
+	}
+	llms := make(map[string]*api.LLM, len(list))
+	for _, llm := range list {
+		llms[llm.Name] = llmToProto(llm)
+	}
+	return llms, nil
+}
+
+func (s *llmService) ListLLMs(ctx context.Context, req *api.NamespaceRequest) (*api.ListLLMsResponse, error) {
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	llms, err := listLLMs(llmManager.withLogger(logging.FromContext(ctx)), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list LLMs", err)
+	}
+	return &api.ListLLMsResponse{Llms: sortedValues(llms)}, nil
+}
+
+func (s *llmService) GetLLM(ctx context.Context, req *api.ResourceRequest) (*api.LLM, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	llm, err := llmManager.withLogger(logging.FromContext(ctx)).GetLLM(req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get LLM", err)
+	}
+	return llmToProto(*llm), nil
+}
+
+func (s *llmService) CreateLLM(ctx context.Context, req *api.LLM) (*api.LLM, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if req.GetType() == "" {
+		return nil, status.Error(codes.InvalidArgument, "type is required")
+	}
+	if _, ok := types.LLMTypes[req.GetType()]; !ok {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid type: %s", req.GetType()))
+	}
+	llm := types.LLM{Name: req.GetName(), Namespace: req.GetNamespace(), Type: req.GetType()}
+	if err := llmManager.withLogger(logging.FromContext(ctx)).CreateLLM(llm); err != nil {
+		return nil, grpcError(ctx, "failed to create LLM", err)
+	}
+	return llmToProto(llm), nil
+}
+
+func (s *llmService) DeleteLLM(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := llmManager.withLogger(logging.FromContext(ctx)).DeleteLLM(req.GetNamespace(), req.GetName()); err != nil {
+		return nil, grpcError(ctx, "failed to delete LLM", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *llmService) WatchLLMs(req *api.NamespaceRequest, stream grpc.ServerStreamingServer[api.LLMEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return err
+	}
+	m := llmManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.LLM, error) {
+		return listLLMs(m, req.GetNamespace())
+	}, func(eventType api.EventType, llm *api.LLM) error {
+		return stream.Send(&api.LLMEvent{Type: eventType, Llm: llm})
+	})
+}
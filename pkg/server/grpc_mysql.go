@@ -0,0 +1,106 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+)
+
+// mysqlService implements api.MysqlServiceServer on top of the mysql manager
+type mysqlService struct {
+	api.UnimplementedMysqlServiceServer
+}
+
+// mysqlToProto converts a mysql cluster to its protobuf form
+func mysqlToProto(cluster types.Mysql) *api.Mysql {
+	return &api.Mysql{
+		Name:            cluster.Name,
+		Namespace:       cluster.Namespace,
+		Instances:       int32(cluster.Instances),
+		RouterInstances: int32(cluster.RouterInstances),
+	}
+}
+
+// mysqlFromProto converts a protobuf mysql cluster to a mysql cluster
+func mysqlFromProto(cluster *api.Mysql) types.Mysql {
+	return types.Mysql{
+		Name:            cluster.GetName(),
+		Namespace:       cluster.GetNamespace(),
+		Instances:       int(cluster.GetInstances()),
+		RouterInstances: int(cluster.GetRouterInstances()),
+	}
+}
+
+// listMysql returns the mysql clusters of a namespace keyed by name
+func listMysql(m *MysqlManager, namespace string) (map[string]*api.Mysql, error) {
+	list, err := m.ListClusters(namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusters := make(map[string]*api.Mysql, len(list))
+	for _, cluster := range list {
+		cluster.Namespace = namespace
+		clusters[cluster.Name] = mysqlToProto(cluster)
+	}
+	return clusters, nil
+}
+
+func (s *mysqlService) ListMysql(ctx context.Context, req *api.NamespaceRequest) (*api.ListMysqlResponse, error) {
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	clusters, err := listMysql(mysqlManager.withLogger(logging.FromContext(ctx)), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list mysql", err)
+	}
+	return &api.ListMysqlResponse{Clusters: sortedValues(clusters)}, nil
+}
+
+func (s *mysqlService) GetMysql(ctx context.Context, req *api.ResourceRequest) (*api.Mysql, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	cluster, err := mysqlManager.withLogger(logging.FromContext(ctx)).GetCluster(req.GetNamespace(), req.GetName())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get mysql", err)
+	}
+	cluster.Namespace = req.GetNamespace()
+	return mysqlToProto(*cluster), nil
+}
+
+func (s *mysqlService) CreateMysql(ctx context.Context, req *api.Mysql) (*api.Mysql, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	cluster := mysqlFromProto(req)
+	if err := mysqlManager.withLogger(logging.FromContext(ctx)).CreateCluster(cluster.Namespace, cluster); err != nil {
+		return nil, grpcError(ctx, "failed to create mysql", err)
+	}
+	return mysqlToProto(cluster), nil
+}
+
+func (s *mysqlService) DeleteMysql(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := mysqlManager.withLogger(logging.FromContext(ctx)).DeleteCluster(req.GetNamespace(), req.GetName()); err != nil {
+		return nil, grpcError(ctx, "failed to delete mysql", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *mysqlService) WatchMysql(req *api.NamespaceRequest, stream grpc.ServerStreamingServer[api.MysqlEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return err
+	}
+	m := mysqlManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.Mysql, error) {
+		return listMysql(m, req.GetNamespace())
+	}, func(eventType api.EventType, cluster *api.Mysql) error {
+		return stream.Send(&api.MysqlEvent{Type: eventType, Mysql: cluster})
+	})
+}
@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// namespaceService implements api.NamespaceServiceServer on top of the namespace manager
+type namespaceService struct {
+	api.UnimplementedNamespaceServiceServer
+}
+
+// checkNamespaceName rejects empty and reserved namespace names
+func checkNamespaceName(name string) error {
+	if name == "" {
+		return status.Error(codes.InvalidArgument, "namespace name is required")
+	}
+	if types.ReservedNamespaces[name] {
+		return status.Error(codes.InvalidArgument, "namespace is reserved")
+	}
+	return nil
+}
+
+// listNamespaces returns the namespaces keyed by name
+func listNamespaces(m *NamespaceManager) (map[string]*api.Namespace, error) {
+	names, err := m.ListNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	namespaces := make(map[string]*api.Namespace, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		namespaces[name] = &api.Namespace{Name: name}
+	}
+	return namespaces, nil
+}
+
+func (s *namespaceService) ListNamespaces(ctx context.Context, req *api.ListRequest) (*api.ListNamespacesResponse, error) {
+	namespaces, err := listNamespaces(namespaceManager.withLogger(logging.FromContext(ctx)))
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list namespaces", err)
+	}
+	return &api.ListNamespacesResponse{Namespaces: sortedValues(namespaces)}, nil
+}
+
+func (s *namespaceService) GetNamespace(ctx context.Context, req *api.NameRequest) (*api.Namespace, error) {
+	if err := checkNamespaceName(req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := authorizeNamespace(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	namespace, err := namespaceManager.withLogger(logging.FromContext(ctx)).GetNamespace(req.GetName())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get namespace", err)
+	}
+	return &api.Namespace{Name: namespace.Name}, nil
+}
+
+func (s *namespaceService) CreateNamespace(ctx context.Context, req *api.NameRequest) (*api.Namespace, error) {
+	if err := checkNamespaceName(req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := namespaceManager.withLogger(logging.FromContext(ctx)).CreateNamespace(req.GetName()); err != nil {
+		return nil, grpcError(ctx, "failed to create namespace", err)
+	}
+	logging.FromContext(ctx).Info("namespace created successfully", "name", req.GetName())
+	return &api.Namespace{Name: req.GetName()}, nil
+}
+
+func (s *namespaceService) DeleteNamespace(ctx context.Context, req *api.NameRequest) (*api.Empty, error) {
+	if err := checkNamespaceName(req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if err := namespaceManager.withLogger(logging.FromContext(ctx)).DeleteNamespace(req.GetName()); err != nil {
+		return nil, grpcError(ctx, "failed to delete namespace", err)
+	}
+	logging.FromContext(ctx).Info("namespace deleted successfully", "name", req.GetName())
+	return &api.Empty{}, nil
+}
+
+func (s *namespaceService) WatchNamespaces(req *api.ListRequest, stream grpc.ServerStreamingServer[api.NamespaceEvent]) error {
+	ctx := stream.Context()
+	m := namespaceManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.Namespace, error) {
+		return listNamespaces(m)
+	}, func(eventType api.EventType, namespace *api.Namespace) error {
+		return stream.Send(&api.NamespaceEvent{Type: eventType, Namespace: namespace})
+	})
+}
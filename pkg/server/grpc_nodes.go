@@ -0,0 +1,175 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// nodeService implements api.NodeServiceServer on top of the node manager
+type nodeService struct {
+	api.UnimplementedNodeServiceServer
+}
+
+// nodeToProto converts a node to its protobuf form, leaving out its credentials
+func nodeToProto(node types.Node, name string) *api.Node {
+	return &api.Node{
+		Name:       name,
+		Host:       node.Host,
+		User:       node.User,
+		MasterHost: node.MasterHost,
+		Status:     node.Status,
+		MacAddress: node.MacAddress,
+	}
+}
+
+// listNodes returns the nodes keyed by name
+func listNodes(m *NodeManager) (map[string]*api.Node, error) {
+	names, err := m.ListNodes()
+	if err != nil {
+		return nil, err
+	}
+	nodes := make(map[string]*api.Node, len(names))
+	for _, name := range names {
+		node, err := m.GetNode(name)
+		if err != nil {
+			return nil, err
+		}
+		nodes[name] = nodeToProto(*node, name)
+	}
+	return nodes, nil
+}
+
+// authorizeNode checks that the caller is an admin and named a node
+func authorizeNode(ctx context.Context, name string) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+	return requireName(name)
+}
+
+func (s *nodeService) ListNodes(ctx context.Context, req *api.ListRequest) (*api.ListNodesResponse, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	nodes, err := listNodes(nodeManager.withLogger(logging.FromContext(ctx)))
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list nodes", err)
+	}
+	return &api.ListNodesResponse{Nodes: sortedValues(nodes)}, nil
+}
+
+func (s *nodeService) GetNode(ctx context.Context, req *api.NameRequest) (*api.Node, error) {
+	if err := authorizeNode(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	node, err := nodeManager.withLogger(logging.FromContext(ctx)).GetNode(req.GetName())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get node", err)
+	}
+	if node == nil {
+		return nil, status.Error(codes.NotFound, "node not found")
+	}
+	return nodeToProto(*node, req.GetName()), nil
+}
+
+func (s *nodeService) AddNode(ctx context.Context, req *api.AddNodeRequest) (*api.Node, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	if req.GetHost() == "" {
+		return nil, status.Error(codes.InvalidArgument, "host is required")
+	}
+	node := types.Node{
+		Name:       req.GetName(),
+		Host:       req.GetHost(),
+		User:       req.GetUser(),
+		Key:        req.GetKey(),
+		Password:   req.GetPassword(),
+		MasterHost: req.GetMasterHost(),
+		MacAddress: req.GetMacAddress(),
+	}
+	logging.FromContext(ctx).Debug("add node request", "node", node)
+	if err := nodeManager.withLogger(logging.FromContext(ctx)).AddNode(node); err != nil {
+		return nil, grpcError(ctx, "failed to add node", err)
+	}
+	return nodeToProto(node, node.Name), nil
+}
+
+func (s *nodeService) DeleteNode(ctx context.Context, req *api.NameRequest) (*api.Empty, error) {
+	if err := authorizeNode(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	m := nodeManager.withLogger(logging.FromContext(ctx))
+	node, err := m.GetNode(req.GetName())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get node", err)
+	}
+	if err := m.DeleteNode(node.Host); err != nil {
+		return nil, grpcError(ctx, "failed to delete node", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *nodeService) RestartNode(ctx context.Context, req *api.NameRequest) (*api.Empty, error) {
+	if err := authorizeNode(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := nodeManager.withLogger(logging.FromContext(ctx)).RestartNode(req.GetName()); err != nil {
+		return nil, grpcError(ctx, "failed to restart node", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *nodeService) SuspendNode(ctx context.Context, req *api.NameRequest) (*api.Empty, error) {
+	if err := authorizeNode(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := nodeManager.withLogger(logging.FromContext(ctx)).SuspendNode(req.GetName(), server.config.SSHUser, server.config.Key); err != nil {
+		return nil, grpcError(ctx, "failed to suspend node", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *nodeService) ResumeNode(ctx context.Context, req *api.NameRequest) (*api.Empty, error) {
+	if err := authorizeNode(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := nodeManager.withLogger(logging.FromContext(ctx)).ResumeNode(req.GetName(), server.config.SSHUser, server.config.Key); err != nil {
+		return nil, grpcError(ctx, "failed to resume node", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *nodeService) UpgradeNode(ctx context.Context, req *api.NameRequest) (*api.Empty, error) {
+	if err := authorizeNode(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	m := nodeManager.withLogger(logging.FromContext(ctx))
+	node, err := m.GetNode(req.GetName())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get node", err)
+	}
+	if err := m.UpgradeNode(req.GetName(), node.Host, node.User, node.Key); err != nil {
+		return nil, grpcError(ctx, "failed to upgrade node", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *nodeService) WatchNodes(req *api.ListRequest, stream grpc.ServerStreamingServer[api.NodeEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+	m := nodeManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.Node, error) {
+		return listNodes(m)
+	}, func(eventType api.EventType, node *api.Node) error {
+		return stream.Send(&api.NodeEvent{Type: eventType, Node: node})
+	})
+}
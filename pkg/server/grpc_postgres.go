@@ -0,0 +1,108 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+)
+
+// postgresService implements api.PostgresServiceServer on top of the postgres manager
+type postgresService struct {
+	api.UnimplementedPostgresServiceServer
+}
+
+// postgresToProto converts a postgres cluster to its protobuf form
+func postgresToProto(cluster types.Postgres) *api.Postgres {
+	return &api.Postgres{
+		Name:      cluster.Name,
+		Namespace: cluster.Namespace,
+		Size:      cluster.Size,
+		Replicas:  int32(cluster.Replicas),
+		Storage:   int32(cluster.Storage),
+	}
+}
+
+// postgresFromProto converts a protobuf postgres cluster to a postgres cluster
+func postgresFromProto(cluster *api.Postgres) types.Postgres {
+	return types.Postgres{
+		Name:      cluster.GetName(),
+		Namespace: cluster.GetNamespace(),
+		Size:      cluster.GetSize(),
+		Replicas:  int(cluster.GetReplicas()),
+		Storage:   int(cluster.GetStorage()),
+	}
+}
+
+// listPostgres returns the postgres clusters of a namespace keyed by name
+func listPostgres(m *PostgresManager, namespace string) (map[string]*api.Postgres, error) {
+	list, err := m.ListClusters(namespace)
+	if err != nil {
+		return nil, err
+	}
+	clusters := make(map[string]*api.Postgres, len(list))
+	for _, cluster := range list {
+		cluster.Namespace = namespace
+		clusters[cluster.Name] = postgresToProto(cluster)
+	}
+	return clusters, nil
+}
+
+func (s *postgresService) ListPostgres(ctx context.Context, req *api.NamespaceRequest) (*api.ListPostgresResponse, error) {
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	clusters, err := listPostgres(postgresManager.withLogger(logging.FromContext(ctx)), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list postgres", err)
+	}
+	return &api.ListPostgresResponse{Clusters: sortedValues(clusters)}, nil
+}
+
+func (s *postgresService) GetPostgres(ctx context.Context, req *api.ResourceRequest) (*api.Postgres, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	cluster, err := postgresManager.withLogger(logging.FromContext(ctx)).GetCluster(req.GetName(), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get postgres", err)
+	}
+	cluster.Namespace = req.GetNamespace()
+	return postgresToProto(*cluster), nil
+}
+
+func (s *postgresService) CreatePostgres(ctx context.Context, req *api.Postgres) (*api.Postgres, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	cluster := postgresFromProto(req)
+	if err := postgresManager.withLogger(logging.FromContext(ctx)).CreateCluster(&cluster); err != nil {
+		return nil, grpcError(ctx, "failed to create postgres", err)
+	}
+	return postgresToProto(cluster), nil
+}
+
+func (s *postgresService) DeletePostgres(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := postgresManager.withLogger(logging.FromContext(ctx)).DeleteCluster(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to delete postgres", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *postgresService) WatchPostgres(req *api.NamespaceRequest, stream grpc.ServerStreamingServer[api.PostgresEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return err
+	}
+	m := postgresManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.Postgres, error) {
+		return listPostgres(m, req.GetNamespace())
+	}, func(eventType api.EventType, cluster *api.Postgres) error {
+		return stream.Send(&api.PostgresEvent{Type: eventType, Postgres: cluster})
+	})
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// userService implements api.UserServiceServer on top of the user manager
+type userService struct {
+	api.UnimplementedUserServiceServer
+}
+
+// userToProto converts a user to its protobuf form, leaving out the password
+func userToProto(user types.User) *api.User {
+	return &api.User{
+		Name:       user.Name,
+		Namespaces: user.Namespaces,
+		IsAdmin:    user.IsAdmin,
+	}
+}
+
+// listUsers returns the users keyed by name
+func listUsers() (map[string]*api.User, error) {
+	list, err := userManager.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string]*api.User, len(list))
+	for _, user := range list {
+		users[user.Name] = userToProto(user)
+	}
+	return users, nil
+}
+
+// getUser returns a user or a NotFound error
+func getUser(ctx context.Context, name string) (*api.User, error) {
+	user, err := userManager.GetUser(name)
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get user", err)
+	}
+	if user == nil {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return userToProto(*user), nil
+}
+
+// authorizeUser checks that the caller is an admin and named a user
+func authorizeUser(ctx context.Context, name string) error {
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+	return requireName(name)
+}
+
+func (s *userService) ListUsers(ctx context.Context, req *api.ListRequest) (*api.ListUsersResponse, error) {
+	if err := authorizeAdmin(ctx); err != nil {
+		return nil, err
+	}
+	users, err := listUsers()
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list users", err)
+	}
+	return &api.ListUsersResponse{Users: sortedValues(users)}, nil
+}
+
+func (s *userService) GetUser(ctx context.Context, req *api.NameRequest) (*api.User, error) {
+	if err := authorizeUser(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	return getUser(ctx, req.GetName())
+}
+
+func (s *userService) CreateUser(ctx context.Context, req *api.CreateUserRequest) (*api.User, error) {
+	if err := authorizeUser(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	user := types.User{
+		Name:       req.GetName(),
+		Password:   req.GetPassword(),
+		Namespaces: req.GetNamespaces(),
+		IsAdmin:    req.GetIsAdmin(),
+	}
+	if err := userManager.CreateUser(req.GetName(), user); err != nil {
+		return nil, grpcError(ctx, "failed to create user", err)
+	}
+	return userToProto(user), nil
+}
+
+func (s *userService) DeleteUser(ctx context.Context, req *api.NameRequest) (*api.Empty, error) {
+	if err := authorizeUser(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := userManager.DeleteUser(req.GetName()); err != nil {
+		return nil, grpcError(ctx, "failed to delete user", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *userService) SetUserPassword(ctx context.Context, req *api.SetUserPasswordRequest) (*api.Empty, error) {
+	if err := authorizeUser(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := userManager.SetUserPassword(req.GetName(), req.GetPassword()); err != nil {
+		return nil, grpcError(ctx, "failed to set user password", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *userService) AddNamespaceToUser(ctx context.Context, req *api.UserNamespaceRequest) (*api.User, error) {
+	if err := authorizeUser(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	if req.GetNamespace() == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	if err := userManager.AddNamespaceToUser(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to add namespace to user", err)
+	}
+	return getUser(ctx, req.GetName())
+}
+
+func (s *userService) RemoveNamespaceFromUser(ctx context.Context, req *api.UserNamespaceRequest) (*api.User, error) {
+	if err := authorizeUser(ctx, req.GetName()); err != nil {
+		return nil, err
+	}
+	if req.GetNamespace() == "" {
+		return nil, status.Error(codes.InvalidArgument, "namespace is required")
+	}
+	if err := userManager.RemoveNamespaceFromUser(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to remove namespace from user", err)
+	}
+	return getUser(ctx, req.GetName())
+}
+
+func (s *userService) WatchUsers(req *api.ListRequest, stream grpc.ServerStreamingServer[api.UserEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeAdmin(ctx); err != nil {
+		return err
+	}
+	return watchResources(ctx, listUsers, func(eventType api.EventType, user *api.User) error {
+		return stream.Send(&api.UserEvent{Type: eventType, User: user})
+	})
+}
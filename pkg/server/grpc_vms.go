@@ -0,0 +1,392 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// vmService implements api.VMServiceServer on top of the VM manager
+type vmService struct {
+	api.UnimplementedVMServiceServer
+}
+
+// vmToProto converts a VM to its protobuf form
+func vmToProto(vm types.VM) *api.VM {
+	ports := make([]*api.VMPort, 0, len(vm.Ports))
+	for _, port := range vm.Ports {
+		ports = append(ports, &api.VMPort{
+			Name:            port.Name,
+			SourcePort:      int32(port.SourcePort),
+			DestinationPort: int32(port.DestinationPort),
+			Protocol:        port.Protocol,
+			NodePort:        int32(port.NodePort),
+			Endpoint:        port.Endpoint,
+		})
+	}
+	volumes := make([]*api.VMVolume, 0, len(vm.Volumes))
+	for _, volume := range vm.Volumes {
+		volumes = append(volumes, &api.VMVolume{
+			Name:   volume.Name,
+			Claim:  volume.Claim,
+			Serial: volume.Serial,
+			Bus:    volume.Bus,
+		})
+	}
+	return &api.VM{
+		Name:      vm.Name,
+		Namespace: vm.Namespace,
+		Image:     vm.Image,
+		Size:      vm.Size,
+		Disk:      vm.Disk,
+		Status:    vm.Status,
+		Ports:     ports,
+
+		User:            vm.User,
+		SshKeys:         vm.SSHKeys,
+		SshKeyNames:     vm.SSHKeyNames,
+		NetworkData:     vm.NetworkData,
+		CloudInitSecret: vm.CloudInitSecret,
+		ServiceType:     vm.ServiceType,
+		Volumes:         volumes,
+		RunStrategy:     vm.RunStrategy,
+		IdleTimeout:     vm.IdleTimeout,
+		Placement:       placementToProto(vm.Placement),
+		Networks:        networksToProto(vm.Networks),
+	}
+}
+
+// networksToProto converts the networks of a VM to their protobuf form
+func networksToProto(networks []types.VMNetwork) []*api.VMNetwork {
+	converted := make([]*api.VMNetwork, 0, len(networks))
+	for _, network := range networks {
+		converted = append(converted, &api.VMNetwork{
+			Name:       network.Name,
+			Type:       network.Type,
+			Nad:        network.NAD,
+			Mac:        network.MAC,
+			Addressing: network.Addressing,
+			Address:    network.Address,
+			Gateway:    network.Gateway,
+			Dns:        network.DNS,
+		})
+	}
+	return converted
+}
+
+// networksFromProto converts protobuf VM networks to VM networks
+func networksFromProto(networks []*api.VMNetwork) []types.VMNetwork {
+	var converted []types.VMNetwork
+	for _, network := range networks {
+		converted = append(converted, types.VMNetwork{
+			Name:       network.GetName(),
+			Type:       network.GetType(),
+			NAD:        network.GetNad(),
+			MAC:        network.GetMac(),
+			Addressing: network.GetAddressing(),
+			Address:    network.GetAddress(),
+			Gateway:    network.GetGateway(),
+			DNS:        network.GetDns(),
+		})
+	}
+	return converted
+}
+
+// placementToProto converts a VM placement to its protobuf form
+func placementToProto(placement *types.VMPlacement) *api.VMPlacement {
+	if placement == nil {
+		return nil
+	}
+	nodeSelector := make([]*api.NodeLabel, 0, len(placement.NodeSelector))
+	for _, key := range slices.Sorted(maps.Keys(placement.NodeSelector)) {
+		nodeSelector = append(nodeSelector, &api.NodeLabel{Key: key, Value: placement.NodeSelector[key]})
+	}
+	tolerations := make([]*api.VMToleration, 0, len(placement.Tolerations))
+	for _, toleration := range placement.Tolerations {
+		tolerations = append(tolerations, &api.VMToleration{
+			Key:      toleration.Key,
+			Operator: toleration.Operator,
+			Value:    toleration.Value,
+			Effect:   toleration.Effect,
+		})
+	}
+	return &api.VMPlacement{
+		NodeSelector:       nodeSelector,
+		AffinityGroups:     placement.AffinityGroups,
+		AntiAffinityGroups: placement.AntiAffinityGroups,
+		Preferred:          placement.Preferred,
+		Tolerations:        tolerations,
+		DedicatedCpu:       placement.DedicatedCPU,
+		Hugepages:          placement.Hugepages,
+	}
+}
+
+// placementFromProto converts a protobuf VM placement to a VM placement
+func placementFromProto(placement *api.VMPlacement) *types.VMPlacement {
+	if placement == nil {
+		return nil
+	}
+	var nodeSelector map[string]string
+	if len(placement.GetNodeSelector()) > 0 {
+		nodeSelector = make(map[string]string, len(placement.GetNodeSelector()))
+		for _, label := range placement.GetNodeSelector() {
+			nodeSelector[label.GetKey()] = label.GetValue()
+		}
+	}
+	var tolerations []types.VMToleration
+	for _, toleration := range placement.GetTolerations() {
+		tolerations = append(tolerations, types.VMToleration{
+			Key:      toleration.GetKey(),
+			Operator: toleration.GetOperator(),
+			Value:    toleration.GetValue(),
+			Effect:   toleration.GetEffect(),
+		})
+	}
+	return &types.VMPlacement{
+		NodeSelector:       nodeSelector,
+		AffinityGroups:     placement.GetAffinityGroups(),
+		AntiAffinityGroups: placement.GetAntiAffinityGroups(),
+		Preferred:          placement.GetPreferred(),
+		Tolerations:        tolerations,
+		DedicatedCPU:       placement.GetDedicatedCpu(),
+		Hugepages:          placement.GetHugepages(),
+	}
+}
+
+// vmFromProto converts a protobuf VM to a VM
+func vmFromProto(vm *api.VM) types.VM {
+	ports := make([]types.VMPort, 0, len(vm.GetPorts()))
+	for _, port := range vm.GetPorts() {
+		ports = append(ports, types.VMPort{
+			Name:            port.GetName(),
+			SourcePort:      int(port.GetSourcePort()),
+			DestinationPort: int(port.GetDestinationPort()),
+			Protocol:        port.GetProtocol(),
+		})
+	}
+	return types.VM{
+		Name:      vm.GetName(),
+		Namespace: vm.GetNamespace(),
+		Image:     vm.GetImage(),
+		Size:      vm.GetSize(),
+		Disk:      vm.GetDisk(),
+		Status:    vm.GetStatus(),
+		Ports:     ports,
+
+		User:            vm.GetUser(),
+		SSHKeys:         vm.GetSshKeys(),
+		SSHKeyNames:     vm.GetSshKeyNames(),
+		UserData:        vm.GetUserData(),
+		NetworkData:     vm.GetNetworkData(),
+		CloudInitSecret: vm.GetCloudInitSecret(),
+		ServiceType:     vm.GetServiceType(),
+		RunStrategy:     vm.GetRunStrategy(),
+		IdleTimeout:     vm.GetIdleTimeout(),
+		Placement:       placementFromProto(vm.GetPlacement()),
+		Networks:        networksFromProto(vm.GetNetworks()),
+		Sysprep:         vm.GetSysprep(),
+	}
+}
+
+// listVMs returns the VMs of a namespace keyed by name
+func listVMs(m *VMManager, namespace string) (map[string]*api.VM, error) {
+	list, err := m.ListVMs(namespace)
+	if err != nil {
+		return nil, err
+	}
+	vms := make(map[string]*api.VM, len(list))
+	for _, vm := range list {
+		if vm.Namespace == "" {
+			vm.Namespace = namespace
+		}
+		vms[vm.Name] = vmToProto(vm)
+	}
+	return vms, nil
+}
+
+func (s *vmService) ListVMs(ctx context.Context, req *api.NamespaceRequest) (*api.ListVMsResponse, error) {
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	vms, err := listVMs(vmManager.withLogger(logging.FromContext(ctx)), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list VMs", err)
+	}
+	return &api.ListVMsResponse{Vms: sortedValues(vms)}, nil
+}
+
+func (s *vmService) GetVM(ctx context.Context, req *api.ResourceRequest) (*api.VM, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	vm, err := vmManager.withLogger(logging.FromContext(ctx)).GetVM(req.GetName(), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get VM", err)
+	}
+	if vm.Namespace == "" {
+		vm.Namespace = req.GetNamespace()
+	}
+	return vmToProto(vm), nil
+}
+
+func (s *vmService) CreateVM(ctx context.Context, req *api.VM) (*api.VM, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	vm := vmFromProto(req)
+	if err := validateNewVMSize(vm.Size); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	image, err := lookupImage(vm.Image, vm.Namespace)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateCloudInit(vm); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateProvisioning(vm, image); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := resolveSSHKeys(grpcUsername(ctx), &vm); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if vm.Ports, vm.ServiceType, err = normalizeVMPorts(vm.Ports, vm.ServiceType); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if vm.Disk, err = normalizeDiskSize(vm.Disk); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if vm.Networks, err = normalizeVMNetworks(vm.Networks, vm.Namespace); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateRunPolicy(vm.RunStrategy, vm.IdleTimeout); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := validateVMPlacement(vm.Placement); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := vmManager.withLogger(logging.FromContext(ctx)).CreateVM(vm.Namespace, vm); err != nil {
+		return nil, grpcError(ctx, "failed to create VM", err)
+	}
+	logging.FromContext(ctx).Info("VM created successfully", "name", vm.Name, "namespace", vm.Namespace)
+	return vmToProto(vm), nil
+}
+
+func (s *vmService) DeleteVM(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := vmManager.withLogger(logging.FromContext(ctx)).DeleteVM(req.GetName(), req.GetNamespace(), false); err != nil {
+		return nil, grpcError(ctx, "failed to delete VM", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *vmService) StartVM(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := vmManager.withLogger(logging.FromContext(ctx)).StartVM(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to start VM", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *vmService) StopVM(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := vmManager.withLogger(logging.FromContext(ctx)).StopVM(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to stop VM", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *vmService) RestartVM(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := vmManager.withLogger(logging.FromContext(ctx)).RestartVM(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to restart VM", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *vmService) SetVMPorts(ctx context.Context, req *api.VM) (*api.VM, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	vm := vmFromProto(req)
+	if _, _, err := normalizeVMPorts(vm.Ports, vm.ServiceType); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	m := vmManager.withLogger(logging.FromContext(ctx))
+	if err := m.SetPorts(vm.Name, vm.Namespace, types.VMPorts{ServiceType: vm.ServiceType, Ports: vm.Ports}); err != nil {
+		return nil, grpcError(ctx, "failed to set VM ports", err)
+	}
+	updated, err := m.GetVM(vm.Name, vm.Namespace)
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get VM", err)
+	}
+	return vmToProto(updated), nil
+}
+
+func (s *vmService) AttachVMVolume(ctx context.Context, req *api.VMVolumeRequest) (*api.VM, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	m := vmManager.withLogger(logging.FromContext(ctx))
+	if err := m.AttachVolume(req.GetName(), req.GetNamespace(), req.GetVolume()); err != nil {
+		return nil, grpcError(ctx, "failed to attach volume", err)
+	}
+	vm, err := m.GetVM(req.GetName(), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get VM", err)
+	}
+	return vmToProto(vm), nil
+}
+
+func (s *vmService) DetachVMVolume(ctx context.Context, req *api.VMVolumeRequest) (*api.VM, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	m := vmManager.withLogger(logging.FromContext(ctx))
+	if err := m.DetachVolume(req.GetName(), req.GetNamespace(), req.GetVolume()); err != nil {
+		return nil, grpcError(ctx, "failed to detach volume", err)
+	}
+	vm, err := m.GetVM(req.GetName(), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get VM", err)
+	}
+	return vmToProto(vm), nil
+}
+
+func (s *vmService) WaitVM(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if err := vmManager.withLogger(logging.FromContext(ctx)).WaitVM(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to wait for VM", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *vmService) WatchVMs(req *api.NamespaceRequest, stream grpc.ServerStreamingServer[api.VMEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return err
+	}
+	m := vmManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.VM, error) {
+		return listVMs(m, req.GetNamespace())
+	}, func(eventType api.EventType, vm *api.VM) error {
+		return stream.Send(&api.VMEvent{Type: eventType, Vm: vm})
+	})
+}
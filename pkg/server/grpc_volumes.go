@@ -0,0 +1,98 @@
+package server
+
+import (
+	"context"
+
+	"github.com/rusik69/govnocloud2/pkg/api"
+	"github.com/rusik69/govnocloud2/pkg/logging"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"google.golang.org/grpc"
+)
+
+// volumeService implements api.VolumeServiceServer on top of the volume manager
+type volumeService struct {
+	api.UnimplementedVolumeServiceServer
+}
+
+// volumeToProto converts a volume to its protobuf form
+func volumeToProto(volume types.Volume, namespace string) *api.Volume {
+	return &api.Volume{
+		Name:      volume.Name,
+		Namespace: namespace,
+		Size:      volume.Size,
+		Status:    volume.Status,
+	}
+}
+
+// listVolumes returns the volumes of a namespace keyed by name
+func listVolumes(m *VolumeManager, namespace string) (map[string]*api.Volume, error) {
+	names, err := m.ListVolumes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	volumes := make(map[string]*api.Volume, len(names))
+	for _, name := range names {
+		volume, err := m.GetVolume(name, namespace)
+		if err != nil {
+			return nil, err
+		}
+		volumes[name] = volumeToProto(volume, namespace)
+	}
+	return volumes, nil
+}
+
+func (s *volumeService) ListVolumes(ctx context.Context, req *api.NamespaceRequest) (*api.ListVolumesResponse, error) {
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return nil, err
+	}
+	volumes, err := listVolumes(volumeManager.withLogger(logging.FromContext(ctx)), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to list volumes", err)
+	}
+	return &api.ListVolumesResponse{Volumes: sortedValues(volumes)}, nil
+}
+
+func (s *volumeService) GetVolume(ctx context.Context, req *api.ResourceRequest) (*api.Volume, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	volume, err := volumeManager.withLogger(logging.FromContext(ctx)).GetVolume(req.GetName(), req.GetNamespace())
+	if err != nil {
+		return nil, grpcError(ctx, "failed to get volume", err)
+	}
+	return volumeToProto(volume, req.GetNamespace()), nil
+}
+
+func (s *volumeService) CreateVolume(ctx context.Context, req *api.Volume) (*api.Volume, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	volume := types.Volume{Name: req.GetName(), Size: req.GetSize()}
+	if _, err := volumeManager.withLogger(logging.FromContext(ctx)).CreateVolume(volume, req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to create volume", err)
+	}
+	return volumeToProto(volume, req.GetNamespace()), nil
+}
+
+func (s *volumeService) DeleteVolume(ctx context.Context, req *api.ResourceRequest) (*api.Empty, error) {
+	if err := authorizeResource(ctx, req.GetNamespace(), req.GetName()); err != nil {
+		return nil, err
+	}
+	if _, err := volumeManager.withLogger(logging.FromContext(ctx)).DeleteVolume(req.GetName(), req.GetNamespace()); err != nil {
+		return nil, grpcError(ctx, "failed to delete volume", err)
+	}
+	return &api.Empty{}, nil
+}
+
+func (s *volumeService) WatchVolumes(req *api.NamespaceRequest, stream grpc.ServerStreamingServer[api.VolumeEvent]) error {
+	ctx := stream.Context()
+	if err := authorizeNamespace(ctx, req.GetNamespace()); err != nil {
+		return err
+	}
+	m := volumeManager.withLogger(logging.FromContext(ctx))
+	return watchResources(ctx, func() (map[string]*api.Volume, error) {
+		return listVolumes(m, req.GetNamespace())
+	}, func(eventType api.EventType, volume *api.Volume) error {
+		return stream.Send(&api.VolumeEvent{Type: eventType, Volume: volume})
+	})
+}
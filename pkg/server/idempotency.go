@@ -0,0 +1,246 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+const (
+	// IdempotencyKeyHeader is the request header carrying the client supplied idempotency key
+	IdempotencyKeyHeader = "Idempotency-Key"
+	// IdempotentReplayedHeader is set on responses replayed from a stored result
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	// idempotencyTTL is how long a completed response is kept for replay
+	idempotencyTTL = 24 * time.Hour
+	// idempotencyPendingTTL bounds how long an in-flight request holds its key, so a crash doesn't block retries for a day
+	idempotencyPendingTTL = 15 * time.Minute
+	// maxIdempotencyKeyLength is the longest key accepted from clients
+	maxIdempotencyKeyLength = 255
+	// maxIdempotentBodySize bounds the request body read into memory to fingerprint it
+	maxIdempotentBodySize = 1 << 20
+)
+
+// IdempotencyManager stores responses of mutating requests in etcd keyed by their Idempotency-Key
+type IdempotencyManager struct {
+	etcdClient *clientv3.Client
+}
+
+// IdempotencyRecord is a stored response. Status is zero while the original request is still running.
+type IdempotencyRecord struct {
+	Fingerprint string `json:"fingerprint"`
+	Status      int    `json:"status"`
+	ContentType string `json:"contentType"`
+	Body        []byte `json:"body"`
+}
+
+// NewIdempotencyManager creates a new idempotency manager
+func NewIdempotencyManager(etcdClient *clientv3.Client) *IdempotencyManager {
+	return &IdempotencyManager{etcdClient: etcdClient}
+}
+
+// etcdKey returns the etcd key for a user's idempotency key
+func (m *IdempotencyManager) etcdKey(username, key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return fmt.Sprintf("/idempotency/%s/%s", username, hex.EncodeToString(sum[:]))
+}
+
+// Reserve claims the key for a new request. If the key is already taken the existing record is returned with reserved set to false.
+func (m *IdempotencyManager) Reserve(username, key, fingerprint string) (*IdempotencyRecord, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(IdempotencyRecord{Fingerprint: fingerprint})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	lease, err := m.etcdClient.Grant(ctx, int64(idempotencyPendingTTL.Seconds()))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to grant lease: %w", err)
+	}
+
+	etcdKey := m.etcdKey(username, key)
+	resp, err := m.etcdClient.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(etcdKey), "=", 0)).
+		Then(clientv3.OpPut(etcdKey, string(data), clientv3.WithLease(lease.ID))).
+		Else(clientv3.OpGet(etcdKey)).
+		Commit()
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+	if resp.Succeeded {
+		return nil, true, nil
+	}
+
+	// The lease was not attached to anything, drop it
+	if _, err := m.etcdClient.Revoke(ctx, lease.ID); err != nil {
+		slog.Warn("failed to revoke unused lease", "error", err)
+	}
+
+	kvs := resp.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		return nil, false, fmt.Errorf("idempotency key disappeared while reserving it")
+	}
+	var record IdempotencyRecord
+	if err := json.Unmarshal(kvs[0].Value, &record); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal idempotency record: %w", err)
+	}
+	return &record, false, nil
+}
+
+// Complete stores the final response for the key so it can be replayed for the next 24 hours
+func (m *IdempotencyManager) Complete(username, key string, record IdempotencyRecord) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal idempotency record: %w", err)
+	}
+
+	lease, err := m.etcdClient.Grant(ctx, int64(idempotencyTTL.Seconds()))
+	if err != nil {
+		return fmt.Errorf("failed to grant lease: %w", err)
+	}
+
+	if _, err := m.etcdClient.Put(ctx, m.etcdKey(username, key), string(data), clientv3.WithLease(lease.ID)); err != nil {
+		return fmt.Errorf("failed to store idempotency record: %w", err)
+	}
+	return nil
+}
+
+// Release frees the key so the request can be retried
+func (m *IdempotencyManager) Release(username, key string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := m.etcdClient.Delete(ctx, m.etcdKey(username, key)); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// requestFingerprint identifies a request by method, path and body so a key can't be reused for a different request
+func requestFingerprint(method, path string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method))
+	h.Write([]byte{0})
+	h.Write([]byte(path))
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder captures the response body while passing it through to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware replays the stored response for POST requests with a JSON or empty body that repeat an
+// Idempotency-Key. Other bodies can't carry a key, they would go unprotected.
+func IdempotencyMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if c.Request.Method != http.MethodPost || key == "" || isDryRun(c) {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("%s must be at most %d characters", IdempotencyKeyHeader, maxIdempotencyKeyLength))
+			c.Abort()
+			return
+		}
+
+		// Only authenticated users get replays; the handler rejects everyone else
+		auth, username, err := CheckAuth(c)
+		if err != nil || !auth {
+			c.Next()
+			return
+		}
+
+		// streamed bodies such as image uploads and VM archives are not fingerprinted, they don't fit in memory
+		if contentType := c.ContentType(); contentType != "" && contentType != gin.MIMEJSON {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("%s is only supported for JSON requests", IdempotencyKeyHeader))
+			c.Abort()
+			return
+		}
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxIdempotentBodySize+1))
+		if err != nil {
+			respondWithError(c, http.StatusBadRequest, "failed to read request body")
+			c.Abort()
+			return
+		}
+		if len(body) > maxIdempotentBodySize {
+			respondWithError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("request bodies with an %s must be at most %d bytes",
+				IdempotencyKeyHeader, maxIdempotentBodySize))
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		fingerprint := requestFingerprint(c.Request.Method, c.Request.URL.Path, body)
+
+		record, reserved, err := idempotencyManager.Reserve(username, key, fingerprint)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check idempotency key: %v", err))
+			c.Abort()
+			return
+		}
+		if !reserved {
+			switch {
+			case record.Fingerprint != fingerprint:
+				respondWithError(c, http.StatusUnprocessableEntity, fmt.Sprintf("%s was already used for a different request", IdempotencyKeyHeader))
+			case record.Status == 0:
+				respondWithError(c, http.StatusConflict, fmt.Sprintf("a request with this %s is still in progress", IdempotencyKeyHeader))
+			default:
+				requestLogger(c).Info("replaying stored response", "method", c.Request.Method, "path", c.Request.URL.Path)
+				c.Header(IdempotentReplayedHeader, "true")
+				c.Data(record.Status, record.ContentType, record.Body)
+			}
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			// Server side failures are not final, let the client retry with the same key
+			if err := idempotencyManager.Release(username, key); err != nil {
+				requestLogger(c).Error("failed to release idempotency key", "error", err)
+			}
+			return
+		}
+		if err := idempotencyManager.Complete(username, key, IdempotencyRecord{
+			Fingerprint: fingerprint,
+			Status:      status,
+			ContentType: recorder.Header().Get("Content-Type"),
+			Body:        recorder.body.Bytes(),
+		}); err != nil {
+			requestLogger(c).Error("failed to store idempotency record", "error", err)
+		}
+	}
+}
@@ -0,0 +1,775 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// imagesSeededKey marks the image catalog as seeded with the default images
+const imagesSeededKey = "/images-seeded"
+
+// publicImagesNamespace holds the PVCs of public images
+const publicImagesNamespace = "govnocloud-images"
+
+// defaultImageSize is the size of the PVC an image file is imported into unless the image names one
+const defaultImageSize = "20Gi"
+
+// Timeouts of image downloads. A download may take long, but one that stalls is given up.
+const (
+	imageDownloadTimeout       = 2 * time.Hour
+	imageDownloadHeaderTimeout = time.Minute
+)
+
+// blockedDownloadNetworks are the shared address space of carrier-grade NAT, which image URLs can't point into
+// besides the private networks
+var blockedDownloadNetworks = []netip.Prefix{netip.MustParsePrefix("100.64.0.0/10")}
+
+// ImageManager stores the catalog of VM images in etcd and the uploaded image files in a directory
+type ImageManager struct {
+	etcdClient *clientv3.Client
+	dir        string
+	maxSize    int64
+	httpClient *http.Client
+}
+
+// NewImageManager creates a new image manager keeping image files in dir
+func NewImageManager(etcdClient *clientv3.Client, dir, maxSize string) *ImageManager {
+	size, err := resource.ParseQuantity(maxSize)
+	if err != nil {
+		slog.Error("invalid maximum image size", "size", maxSize, "error", err)
+		os.Exit(1)
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, Control: checkDownloadAddress}
+	transport := &http.Transport{
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   30 * time.Second,
+		ResponseHeaderTimeout: imageDownloadHeaderTimeout,
+	}
+	return &ImageManager{
+		etcdClient: etcdClient,
+		dir:        dir,
+		maxSize:    size.Value(),
+		httpClient: &http.Client{Transport: transport, Timeout: imageDownloadTimeout},
+	}
+}
+
+// checkDownloadAddress refuses connections of image downloads to the addresses of the server's host, link-local
+// addresses such as cloud metadata services and private networks, the LAN and the cluster included. It runs on the
+// resolved address of every connection, redirects included.
+func checkDownloadAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("invalid download address %s: %w", address, err)
+	}
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() || addr.IsMulticast() ||
+		addr.IsUnspecified() || addr.IsPrivate() {
+		return fmt.Errorf("image downloads from %s are not allowed", addr)
+	}
+	for _, prefix := range blockedDownloadNetworks {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("image downloads from %s are not allowed", addr)
+		}
+	}
+	hostAddrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return fmt.Errorf("failed to list host addresses: %w", err)
+	}
+	for _, hostAddr := range hostAddrs {
+		prefix, err := netip.ParsePrefix(hostAddr.String())
+		if err == nil && prefix.Addr().Unmap() == addr {
+			return fmt.Errorf("image downloads from the server's host %s are not allowed", addr)
+		}
+	}
+	return nil
+}
+
+// etcdKey returns the etcd key of an image
+func (m *ImageManager) etcdKey(name string) string {
+	return "/images/" + name
+}
+
+// SeedDefaults adds the default images missing from the catalog, images that were changed or deleted stay so
+func (m *ImageManager) SeedDefaults() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, imagesSeededKey)
+	if err != nil {
+		return fmt.Errorf("failed to check image catalog: %w", err)
+	}
+	if len(resp.Kvs) > 0 {
+		return nil
+	}
+	ops := []clientv3.Op{clientv3.OpPut(imagesSeededKey, "true")}
+	for name, image := range types.DefaultVMImages {
+		data, err := json.Marshal(image)
+		if err != nil {
+			return fmt.Errorf("failed to marshal image %s: %w", name, err)
+		}
+		ops = append(ops, clientv3.OpPut(m.etcdKey(name), string(data)))
+	}
+	if _, err := m.etcdClient.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(imagesSeededKey), "=", 0)).
+		Then(ops...).
+		Commit(); err != nil {
+		return fmt.Errorf("failed to seed image catalog: %w", err)
+	}
+	return nil
+}
+
+// AddImage stores an image, failing when an image with the same name exists
+func (m *ImageManager) AddImage(image types.VMImage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(image)
+	if err != nil {
+		return fmt.Errorf("failed to marshal image: %w", err)
+	}
+	key := m.etcdKey(image.Name)
+	resp, err := m.etcdClient.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, string(data))).
+		Commit()
+	if err != nil {
+		return fmt.Errorf("failed to store image: %w", err)
+	}
+	if !resp.Succeeded {
+		return fmt.Errorf("image %s already exists", image.Name)
+	}
+	return nil
+}
+
+// PutImage stores an image, replacing the image with the same name
+func (m *ImageManager) PutImage(image types.VMImage) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(image)
+	if err != nil {
+		return fmt.Errorf("failed to marshal image: %w", err)
+	}
+	if _, err := m.etcdClient.Put(ctx, m.etcdKey(image.Name), string(data)); err != nil {
+		return fmt.Errorf("failed to store image: %w", err)
+	}
+	return nil
+}
+
+// GetImage returns an image, nil when it does not exist
+func (m *ImageManager) GetImage(name string) (*types.VMImage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	var image types.VMImage
+	if err := json.Unmarshal(resp.Kvs[0].Value, &image); err != nil {
+		return nil, fmt.Errorf("failed to parse image: %w", err)
+	}
+	return &image, nil
+}
+
+// ListImages returns the images of the catalog
+func (m *ImageManager) ListImages() ([]types.VMImage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(""), clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", err)
+	}
+	images := make([]types.VMImage, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var image types.VMImage
+		if err := json.Unmarshal(kv.Value, &image); err != nil {
+			return nil, fmt.Errorf("failed to parse image %s: %w", kv.Key, err)
+		}
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// DeleteImage removes an image from the catalog, the PVC of an exported image is left to the caller
+func (m *ImageManager) DeleteImage(name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Delete(ctx, m.etcdKey(name))
+	if err != nil {
+		return fmt.Errorf("failed to delete image: %w", err)
+	}
+	if resp.Deleted == 0 {
+		return fmt.Errorf("image %s not found", name)
+	}
+	return nil
+}
+
+// imageVisible reports whether an image can be used in a namespace
+func imageVisible(image types.VMImage, namespace string) bool {
+	return image.Namespace == "" || image.Namespace == namespace
+}
+
+// imagePVCNamespace returns the namespace holding the PVC of an image
+func imagePVCNamespace(image *types.VMImage) string {
+	if image.Namespace == "" {
+		return publicImagesNamespace
+	}
+	return image.Namespace
+}
+
+// lookupImage returns an image of the catalog VMs in a namespace can boot from
+func lookupImage(name, namespace string) (*types.VMImage, error) {
+	image, err := imageManager.GetImage(name)
+	if err != nil {
+		return nil, err
+	}
+	if image == nil || !imageVisible(*image, namespace) {
+		return nil, fmt.Errorf("invalid VM image: %s", name)
+	}
+	switch {
+	case image.Phase == types.ImagePhaseImporting:
+		return nil, fmt.Errorf("image %s is still being imported", name)
+	case image.Phase == types.ImagePhaseFailed:
+		return nil, fmt.Errorf("image %s failed to import: %s", name, image.Error)
+	case image.Format == types.ImageFormatISO:
+		return nil, fmt.Errorf("image %s is an ISO, VMs can't boot from it as a root disk", name)
+	}
+	return image, nil
+}
+
+// parseChecksum returns the hash and expected digest of a checksum, sha256:<hex>, sha512:<hex> or a bare sha256 digest
+func parseChecksum(checksum string) (hash.Hash, string, error) {
+	algorithm, digest, found := strings.Cut(checksum, ":")
+	if !found {
+		algorithm, digest = "sha256", checksum
+	}
+	digest = strings.ToLower(digest)
+	if _, err := hex.DecodeString(digest); err != nil {
+		return nil, "", fmt.Errorf("invalid checksum %s: %w", checksum, err)
+	}
+	switch algorithm {
+	case "sha256":
+		if len(digest) != sha256.Size*2 {
+			return nil, "", fmt.Errorf("invalid sha256 checksum: %s", checksum)
+		}
+		return sha256.New(), digest, nil
+	case "sha512":
+		if len(digest) != sha512.Size*2 {
+			return nil, "", fmt.Errorf("invalid sha512 checksum: %s", checksum)
+		}
+		return sha512.New(), digest, nil
+	}
+	return nil, "", fmt.Errorf("unsupported checksum algorithm %s, use sha256 or sha512", algorithm)
+}
+
+// validateImageName checks an image name, which ends up in the names of its PVC and file
+func validateImageName(name string) error {
+	if errs := validation.IsDNS1123Label(imagePVCName(name)); len(errs) > 0 {
+		return fmt.Errorf("invalid image name %s: %s", name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// validateImage checks the metadata of an image registered by containerdisk, URL or upload
+func validateImage(image *types.VMImage, upload bool) error {
+	if err := validateImageName(image.Name); err != nil {
+		return err
+	}
+	sources := 0
+	for _, source := range []string{image.Image, image.URL} {
+		if source != "" {
+			sources++
+		}
+	}
+	switch {
+	case upload && sources > 0:
+		return fmt.Errorf("an uploaded image can't have an image or url")
+	case !upload && sources != 1:
+		return fmt.Errorf("image needs exactly one of image (a containerdisk) or url")
+	case image.URL != "" && !strings.HasPrefix(image.URL, "http://") && !strings.HasPrefix(image.URL, "https://"):
+		return fmt.Errorf("invalid image url: %s", image.URL)
+	}
+	if err := normalizeImageDevices(image); err != nil {
+		return err
+	}
+	if image.Image != "" {
+		if image.Format != "" || image.Checksum != "" || image.Size != "" {
+			return fmt.Errorf("a containerdisk image has no format, checksum or size")
+		}
+		return nil
+	}
+	if image.Format == "" {
+		image.Format = types.ImageFormatQcow2
+	}
+	if !slices.Contains([]string{types.ImageFormatQcow2, types.ImageFormatRaw, types.ImageFormatISO}, image.Format) {
+		return fmt.Errorf("invalid image format %s, use qcow2, raw or iso", image.Format)
+	}
+	if image.Checksum != "" {
+		if _, _, err := parseChecksum(image.Checksum); err != nil {
+			return err
+		}
+	}
+	size, err := normalizeDiskSize(image.Size)
+	if err != nil {
+		return err
+	}
+	image.Size = size
+	if image.Size == "" {
+		image.Size = defaultImageSize
+	}
+	return nil
+}
+
+// filePath returns the path an image file is kept at
+func (m *ImageManager) filePath(image types.VMImage) string {
+	return filepath.Join(m.dir, image.Name+"."+image.Format)
+}
+
+// writeFile writes an image file from r into the images directory, verifying its checksum and refusing files over
+// the maximum image size
+func (m *ImageManager) writeFile(image types.VMImage, r io.Reader) (string, error) {
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create images directory: %w", err)
+	}
+	path := m.filePath(image)
+	file, err := os.Create(path + ".part")
+	if err != nil {
+		return "", fmt.Errorf("failed to create image file: %w", err)
+	}
+	defer file.Close()
+	var w io.Writer = file
+	var sum hash.Hash
+	var expected string
+	if image.Checksum != "" {
+		if sum, expected, err = parseChecksum(image.Checksum); err != nil {
+			return "", err
+		}
+		w = io.MultiWriter(file, sum)
+	}
+	written, err := io.Copy(w, io.LimitReader(r, m.maxSize+1))
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write image file: %w", err)
+	}
+	if written > m.maxSize {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("image file is larger than the maximum image size of %d bytes", m.maxSize)
+	}
+	if sum != nil {
+		if actual := hex.EncodeToString(sum.Sum(nil)); actual != expected {
+			os.Remove(file.Name())
+			return "", fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+		}
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write image file: %w", err)
+	}
+	if err := os.Rename(file.Name(), path); err != nil {
+		return "", fmt.Errorf("failed to store image file: %w", err)
+	}
+	return path, nil
+}
+
+// download fetches the image file of an image registered by URL into the images directory
+func (m *ImageManager) download(image types.VMImage) (string, error) {
+	resp, err := m.httpClient.Get(image.URL)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", image.URL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: %s", image.URL, resp.Status)
+	}
+	if resp.ContentLength > m.maxSize {
+		return "", fmt.Errorf("image at %s is larger than the maximum image size of %d bytes", image.URL, m.maxSize)
+	}
+	return m.writeFile(image, resp.Body)
+}
+
+// uploadProxyURL returns the URL of the CDI upload proxy, reachable from the master through its cluster IP
+func uploadProxyURL(kubectl KubectlRunner) (string, error) {
+	out, err := kubectl.Run("get", "service", "cdi-uploadproxy", "-n", "cdi", "-o", "jsonpath={.spec.clusterIP}")
+	if err != nil {
+		return "", fmt.Errorf("failed to find the CDI upload proxy: %s %w", out, err)
+	}
+	return "https://" + strings.TrimSpace(string(out)), nil
+}
+
+// ensureNamespace creates a namespace unless it exists
+func ensureNamespace(kubectl KubectlRunner, namespace string) error {
+	manifest := fmt.Sprintf(`{"apiVersion":"v1","kind":"Namespace","metadata":{"name":%q}}`, namespace)
+	if out, err := applyManifest(kubectl, manifest); err != nil {
+		return fmt.Errorf("failed to create namespace %s: %s %w", namespace, out, err)
+	}
+	return nil
+}
+
+// ImportImageFile uploads an image file into the PVC of its image through the CDI upload proxy
+func (m *VMManager) ImportImageFile(image types.VMImage, path string) error {
+	namespace := imagePVCNamespace(&image)
+	if image.Namespace == "" {
+		if err := ensureNamespace(m.kubectl, namespace); err != nil {
+			return err
+		}
+	}
+	proxy, err := uploadProxyURL(m.kubectl)
+	if err != nil {
+		return err
+	}
+	m.logger.Info("importing image", "image", image.Name, "path", path, "namespace", namespace, "pvc", image.PVC)
+	if out, err := m.virtctl.Run("image-upload", "dv", image.PVC, "-n", namespace,
+		"--size="+image.Size, "--image-path="+path, "--storage-class="+rootDiskStorageClass,
+		"--access-mode=ReadWriteMany", "--volume-mode=block", "--uploadproxy-url="+proxy, "--insecure"); err != nil {
+		return fmt.Errorf("failed to import image %s: %s %w", image.Name, out, err)
+	}
+	return nil
+}
+
+// importImage fetches and imports the file of an image in the background, recording the outcome in the catalog
+func (m *VMManager) importImage(image types.VMImage, fetch func() (string, error)) {
+	path, err := fetch()
+	if err == nil {
+		err = m.ImportImageFile(image, path)
+	}
+	image.Phase = types.ImagePhaseReady
+	if err != nil {
+		m.logger.Error("failed to import image", "image", image.Name, "error", err)
+		image.Phase, image.Error = types.ImagePhaseFailed, err.Error()
+	}
+	if err := imageManager.PutImage(image); err != nil {
+		m.logger.Error("failed to record image import", "image", image.Name, "error", err)
+	}
+}
+
+// RegisterImage adds an image to the catalog. A containerdisk is ready at once, the file at a URL
+// or in r for an upload is verified and imported into a PVC in the background.
+func (m *VMManager) RegisterImage(image types.VMImage, r io.Reader) (types.VMImage, error) {
+	now := time.Now().UTC()
+	image.CreatedAt = &now
+	image.SourceVM, image.Error = "", ""
+	if image.Image != "" {
+		image.Phase = types.ImagePhaseReady
+		return image, imageManager.AddImage(image)
+	}
+	image.PVC = imagePVCName(image.Name)
+	image.Phase = types.ImagePhaseImporting
+	if err := imageManager.AddImage(image); err != nil {
+		return types.VMImage{}, err
+	}
+	if r == nil {
+		go m.importImage(image, func() (string, error) { return imageManager.download(image) })
+		return image, nil
+	}
+	// an upload is stored while the request is open, only the import runs in the background
+	path, err := imageManager.writeFile(image, r)
+	if err != nil {
+		if err := imageManager.DeleteImage(image.Name); err != nil {
+			m.logger.Warn("failed to unregister image", "image", image.Name, "error", err)
+		}
+		return types.VMImage{}, err
+	}
+	go m.importImage(image, func() (string, error) { return path, nil })
+	return image, nil
+}
+
+// RemoveImage deletes an image with its PVC and its file
+func (m *VMManager) RemoveImage(image types.VMImage) error {
+	if image.PVC != "" {
+		namespace := imagePVCNamespace(&image)
+		if out, err := m.kubectl.Run("delete", dataVolumeResource, image.PVC, "-n", namespace, "--ignore-not-found"); err != nil {
+			return fmt.Errorf("failed to delete disk of image %s: %s %w", image.Name, out, err)
+		}
+	}
+	if image.Format != "" {
+		if err := os.Remove(imageManager.filePath(image)); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to delete file of image %s: %w", image.Name, err)
+		}
+	}
+	return imageManager.DeleteImage(image.Name)
+}
+
+// imagePVCName returns the name of the PVC an image exported from a VM is stored in
+func imagePVCName(image string) string {
+	return "image-" + image
+}
+
+// ExportImage clones the root disk of a VM into a PVC and registers it as an image VMs can be created from.
+// The clone of a running VM is crash-consistent, stop the VM first for a clean image.
+func (m *VMManager) ExportImage(name, namespace, image string) (types.VMImage, error) {
+	if err := validateImageName(image); err != nil {
+		return types.VMImage{}, err
+	}
+	resource, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return types.VMImage{}, err
+	}
+	rootDisk, err := m.currentRootDisk(resource)
+	if err != nil {
+		return types.VMImage{}, err
+	}
+	size, err := rootDiskSize(resource.Spec)
+	if err != nil {
+		return types.VMImage{}, err
+	}
+	now := time.Now().UTC()
+	exported := types.VMImage{
+		Name:      image,
+		PVC:       imagePVCName(image),
+		Namespace: namespace,
+		SourceVM:  name,
+		Size:      size,
+		Phase:     types.ImagePhaseReady,
+		CreatedAt: &now,
+	}
+	if source, err := imageManager.GetImage(resource.Spec.Image); err == nil && source != nil {
+		exported.OS, exported.OSVersion = source.OS, source.OSVersion
+	}
+	exported.Devices = resource.Spec.Devices
+	manifest, err := dataVolumeManifest(exported.PVC, namespace, map[string]string{imageLabel: image},
+		pvcSource(namespace, rootDisk), exported.Size)
+	if err != nil {
+		return types.VMImage{}, err
+	}
+	// registering first reserves the name, the image goes away again when its disk can't be cloned
+	if err := imageManager.AddImage(exported); err != nil {
+		return types.VMImage{}, err
+	}
+	if out, err := applyManifest(m.kubectl, manifest); err != nil {
+		if err := imageManager.DeleteImage(image); err != nil {
+			m.logger.Warn("failed to unregister image", "image", image, "error", err)
+		}
+		return types.VMImage{}, fmt.Errorf("failed to clone root disk of VM %s: %s %w", name, out, err)
+	}
+	return exported, nil
+}
+
+// ExportVMImageHandler handles requests to export the root disk of a VM as an image
+func ExportVMImageHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	image := c.Param("image")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	exported, err := vmManager.forRequest(c).ExportImage(name, namespace, image)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to export image: %v", err))
+		return
+	}
+	requestLogger(c).Info("image exported", "name", name, "namespace", namespace, "image", image)
+	c.JSON(http.StatusOK, exported)
+}
+
+// imageRequest checks auth of an image request and returns the username
+func imageRequest(c *gin.Context) (string, bool) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return "", false
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return "", false
+	}
+	return username, true
+}
+
+// checkImageAccess checks that a user may manage an image, public images being managed by admins
+func checkImageAccess(c *gin.Context, username string, image types.VMImage) bool {
+	if image.Namespace == "" {
+		if !CheckAdminAccess(username) {
+			respondWithError(c, http.StatusForbidden, "user does not have admin access")
+			return false
+		}
+		return true
+	}
+	if !CheckNamespaceAccess(username, image.Namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return false
+	}
+	return true
+}
+
+// ListImagesHandler handles requests to list the images visible to the user, or in the namespace query parameter
+func ListImagesHandler(c *gin.Context) {
+	username, ok := imageRequest(c)
+	if !ok {
+		return
+	}
+	namespace := c.Query("namespace")
+	images, err := imageManager.ListImages()
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list images: %v", err))
+		return
+	}
+	visible := make([]types.VMImage, 0, len(images))
+	for _, image := range images {
+		if namespace != "" && !imageVisible(image, namespace) {
+			continue
+		}
+		if image.Namespace == "" || CheckNamespaceAccess(username, image.Namespace) {
+			visible = append(visible, image)
+		}
+	}
+	c.JSON(http.StatusOK, visible)
+}
+
+// GetImageHandler handles requests for an image
+func GetImageHandler(c *gin.Context) {
+	username, ok := imageRequest(c)
+	if !ok {
+		return
+	}
+	name := c.Param("name")
+	image, err := imageManager.GetImage(name)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get image: %v", err))
+		return
+	}
+	if image == nil || (image.Namespace != "" && !CheckNamespaceAccess(username, image.Namespace)) {
+		respondWithError(c, http.StatusNotFound, fmt.Sprintf("image %s not found", name))
+		return
+	}
+	c.JSON(http.StatusOK, image)
+}
+
+// CreateImageHandler handles requests to register an image by containerdisk or URL
+func CreateImageHandler(c *gin.Context) {
+	username, ok := imageRequest(c)
+	if !ok {
+		return
+	}
+	var image types.VMImage
+	if err := c.ShouldBindJSON(&image); err != nil {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	image.Name = c.Param("name")
+	if !checkImageAccess(c, username, image) {
+		return
+	}
+	if err := validateImage(&image, false); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	registered, err := vmManager.forRequest(c).RegisterImage(image, nil)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to register image: %v", err))
+		return
+	}
+	requestLogger(c).Info("image registered", "name", image.Name, "namespace", image.Namespace)
+	c.JSON(http.StatusOK, registered)
+}
+
+// UploadImageHandler handles requests to register an image from the file in the request body, its metadata given
+// as the namespace, format, checksum, size, os, osVersion, description, firmware, tpm, diskBus, interfaceModel and
+// provisioning query parameters
+func UploadImageHandler(c *gin.Context) {
+	username, ok := imageRequest(c)
+	if !ok {
+		return
+	}
+	image := types.VMImage{
+		Name:        c.Param("name"),
+		Namespace:   c.Query("namespace"),
+		Format:      c.Query("format"),
+		Checksum:    c.Query("checksum"),
+		Size:        c.Query("size"),
+		OS:          c.Query("os"),
+		OSVersion:   c.Query("osVersion"),
+		Description: c.Query("description"),
+	}
+	if devices := (types.VMDevices{
+		Firmware:       c.Query("firmware"),
+		TPM:            c.Query("tpm") == "true",
+		DiskBus:        c.Query("diskBus"),
+		InterfaceModel: c.Query("interfaceModel"),
+		Provisioning:   c.Query("provisioning"),
+	}); devices != (types.VMDevices{}) {
+		image.Devices = &devices
+	}
+	if !checkImageAccess(c, username, image) {
+		return
+	}
+	if err := validateImage(&image, true); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if c.Request.ContentLength > imageManager.maxSize {
+		respondWithError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("image file is larger than the maximum image size of %d bytes", imageManager.maxSize))
+		return
+	}
+	registered, err := vmManager.forRequest(c).RegisterImage(image, c.Request.Body)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to upload image: %v", err))
+		return
+	}
+	requestLogger(c).Info("image uploaded", "name", image.Name, "namespace", image.Namespace)
+	c.JSON(http.StatusOK, registered)
+}
+
+// DeleteImageHandler handles requests to delete an image
+func DeleteImageHandler(c *gin.Context) {
+	username, ok := imageRequest(c)
+	if !ok {
+		return
+	}
+	name := c.Param("name")
+	image, err := imageManager.GetImage(name)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get image: %v", err))
+		return
+	}
+	if image == nil {
+		respondWithError(c, http.StatusNotFound, fmt.Sprintf("image %s not found", name))
+		return
+	}
+	if !checkImageAccess(c, username, *image) {
+		return
+	}
+	if err := vmManager.forRequest(c).RemoveImage(*image); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete image: %v", err))
+		return
+	}
+	requestLogger(c).Info("image deleted", "name", name)
+	respondWithSuccess(c, gin.H{"message": "image deleted successfully"})
+}
@@ -3,7 +3,7 @@ package server
 import (
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
@@ -15,12 +15,27 @@ import (
 // LLMManager handles LLM operations
 type LLMManager struct {
 	kubectl KubectlRunner
+	logger  *slog.Logger
 }
 
 // NewLLMManager creates a new LLM manager instance
 func NewLLMManager() *LLMManager {
 	return &LLMManager{
 		kubectl: &DefaultKubectlRunner{},
+		logger:  slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *LLMManager) forRequest(c *gin.Context) *LLMManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *LLMManager) withLogger(logger *slog.Logger) *LLMManager {
+	return &LLMManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		logger:  logger,
 	}
 }
 
@@ -69,8 +84,13 @@ func CreateLLMHandler(c *gin.Context) {
 	llm.Name = name
 	llm.Namespace = namespace
 
-	if err := llmManager.CreateLLM(llm); err != nil {
-		log.Printf("failed to create LLM: %v", err)
+	if isDryRun(c) {
+		respondWithDryRun(c, llmManager.forRequest(c).kubectl, llmManager.generateManifest(llm), namespace)
+		return
+	}
+
+	if err := llmManager.forRequest(c).CreateLLM(llm); err != nil {
+		requestLogger(c).Error("failed to create LLM", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create LLM: %v", err))
 		return
 	}
@@ -105,7 +125,7 @@ func GetLLMHandler(c *gin.Context) {
 		return
 	}
 
-	llm, err := llmManager.GetLLM(namespace, name)
+	llm, err := llmManager.forRequest(c).GetLLM(namespace, name)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get LLM: %v", err))
 		return
@@ -141,7 +161,7 @@ func DeleteLLMHandler(c *gin.Context) {
 		return
 	}
 
-	if err := llmManager.DeleteLLM(namespace, name); err != nil {
+	if err := llmManager.forRequest(c).DeleteLLM(namespace, name); err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete LLM: %v", err))
 		return
 	}
@@ -149,10 +169,10 @@ func DeleteLLMHandler(c *gin.Context) {
 	respondWithSuccess(c, gin.H{"message": "LLM deleted successfully"})
 }
 
-// CreateLLM creates a new LLM deployment
-func (m *LLMManager) CreateLLM(llm types.LLM) error {
+// generateManifest generates an ollama Model manifest for the LLM
+func (m *LLMManager) generateManifest(llm types.LLM) string {
 	llmType := types.LLMTypes[llm.Type]
-	llmConfig := fmt.Sprintf(`apiVersion: ollama.ayaka.io/v1
+	return fmt.Sprintf(`apiVersion: ollama.ayaka.io/v1
 kind: Model
 metadata:
   name: %s
@@ -160,7 +180,12 @@ metadata:
 spec:
   image: %s`,
 		llm.Name, llm.Namespace, llmType.Type)
-	log.Printf("llmConfig: %s", llmConfig)
+}
+
+// CreateLLM creates a new LLM deployment
+func (m *LLMManager) CreateLLM(llm types.LLM) error {
+	llmConfig := m.generateManifest(llm)
+	m.logger.Debug("generated LLM manifest", "manifest", llmConfig)
 	tmpfile, err := os.CreateTemp("", "llm-*.yaml")
 	if err != nil {
 		return fmt.Errorf("failed to create temp file: %w", err)
@@ -205,7 +230,7 @@ func (m *LLMManager) GetLLM(namespace, name string) (*types.LLM, error) {
 		Namespace: namespace,
 		Type:      model.Spec.Image,
 	}
-	log.Printf("llm: %+v", llm)
+	m.logger.Debug("got LLM", "llm", llm)
 	return llm, nil
 }
 
@@ -240,9 +265,9 @@ func ListLLMsHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	llms, err := llmManager.ListLLMs(namespace)
+	llms, err := llmManager.forRequest(c).ListLLMs(namespace)
 	if err != nil {
-		log.Printf("failed to list LLMs: %v", err)
+		requestLogger(c).Error("failed to list LLMs", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list LLMs: %v", err))
 		return
 	}
@@ -281,6 +306,6 @@ func (m *LLMManager) ListLLMs(namespace string) ([]types.LLM, error) {
 			Type:      images[i],
 		}
 	}
-	log.Printf("models: %+v", models)
+	m.logger.Debug("listed LLMs", "namespace", namespace, "models", models)
 	return models, nil
 }
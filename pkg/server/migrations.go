@@ -0,0 +1,348 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KubeVirt resources live migration works with as understood by kubectl
+const (
+	vmiResource          = "virtualmachineinstances.kubevirt.io"
+	vmiMigrationResource = "virtualmachineinstancemigrations.kubevirt.io"
+)
+
+// Labels KubeVirt puts on VirtualMachineInstances and their migrations
+const (
+	vmiNameLabel = "kubevirt.io/vmi-name"
+	vmiNodeLabel = "kubevirt.io/nodeName"
+)
+
+// nodeHostnameLabel is the label a migration target node is selected by
+const nodeHostnameLabel = "kubernetes.io/hostname"
+
+// evacuationTimeout bounds how long node maintenance waits for the VMs of the node to migrate away
+const evacuationTimeout = 10 * time.Minute
+
+// vmiObject is the part of a KubeVirt VirtualMachineInstance the server reads
+type vmiObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Domain struct {
+			CPU *struct {
+				Sockets    int `json:"sockets"`
+				MaxSockets int `json:"maxSockets"`
+			} `json:"cpu"`
+			Memory *struct {
+				Guest    string `json:"guest"`
+				MaxGuest string `json:"maxGuest"`
+			} `json:"memory"`
+		} `json:"domain"`
+	} `json:"spec"`
+	Status struct {
+		NodeName   string            `json:"nodeName"`
+		Phase      string            `json:"phase"`
+		Conditions []objectCondition `json:"conditions"`
+		Interfaces []struct {
+			Name          string   `json:"name"`
+			InterfaceName string   `json:"interfaceName"`
+			MAC           string   `json:"mac"`
+			IPAddress     string   `json:"ipAddress"`
+			IPAddresses   []string `json:"ipAddresses"`
+		} `json:"interfaces"`
+		PhaseTransitionTimestamps []struct {
+			Phase                    string      `json:"phase"`
+			PhaseTransitionTimestamp metav1.Time `json:"phaseTransitionTimestamp"`
+		} `json:"phaseTransitionTimestamps"`
+		GuestOSInfo struct {
+			Name          string `json:"name"`
+			PrettyName    string `json:"prettyName"`
+			Version       string `json:"version"`
+			KernelRelease string `json:"kernelRelease"`
+			Machine       string `json:"machine"`
+		} `json:"guestOSInfo"`
+	} `json:"status"`
+}
+
+// vmiList is a list of KubeVirt VirtualMachineInstances
+type vmiList struct {
+	Items []vmiObject `json:"items"`
+}
+
+// migrationObject is the part of a KubeVirt VirtualMachineInstanceMigration the server reads
+type migrationObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		VMIName           string            `json:"vmiName"`
+		AddedNodeSelector map[string]string `json:"addedNodeSelector"`
+	} `json:"spec"`
+	Status struct {
+		Phase          string `json:"phase"`
+		MigrationState *struct {
+			SourceNode     string       `json:"sourceNode"`
+			TargetNode     string       `json:"targetNode"`
+			StartTimestamp *metav1.Time `json:"startTimestamp"`
+			EndTimestamp   *metav1.Time `json:"endTimestamp"`
+			FailureReason  string       `json:"failureReason"`
+		} `json:"migrationState"`
+	} `json:"status"`
+}
+
+// migrationList is a list of KubeVirt VirtualMachineInstanceMigrations
+type migrationList struct {
+	Items []migrationObject `json:"items"`
+}
+
+// migrationFromObject converts a VirtualMachineInstanceMigration
+func migrationFromObject(object migrationObject) types.VMMigration {
+	migration := types.VMMigration{
+		Name:  object.Name,
+		VM:    object.Spec.VMIName,
+		Phase: object.Status.Phase,
+		Node:  object.Spec.AddedNodeSelector[nodeHostnameLabel],
+	}
+	if state := object.Status.MigrationState; state != nil {
+		migration.SourceNode, migration.TargetNode = state.SourceNode, state.TargetNode
+		migration.Error = state.FailureReason
+		if state.StartTimestamp != nil {
+			migration.StartedAt = &state.StartTimestamp.Time
+		}
+		if state.EndTimestamp != nil {
+			migration.EndedAt = &state.EndTimestamp.Time
+		}
+	}
+	return migration
+}
+
+// migrationFinished reports whether a migration phase is final
+func migrationFinished(phase string) bool {
+	return phase == "Succeeded" || phase == "Failed"
+}
+
+// liveMigratable reports whether a VirtualMachineInstance can live-migrate, and why not
+func liveMigratable(vmi vmiObject) (bool, string) {
+	return conditionTrue(vmi.Status.Conditions, "LiveMigratable")
+}
+
+// createMigration starts the live migration of a VirtualMachineInstance, to node when it is not empty
+func createMigration(kubectl KubectlRunner, namespace, vmi, node string) (types.VMMigration, error) {
+	migration := types.VMMigration{
+		Name:  fmt.Sprintf("%s-migration-%d", vmi, time.Now().Unix()),
+		VM:    vmi,
+		Phase: "Pending",
+		Node:  node,
+	}
+	spec := map[string]any{"vmiName": vmi}
+	if node != "" {
+		spec["addedNodeSelector"] = map[string]string{nodeHostnameLabel: node}
+	}
+	manifest, err := json.Marshal(map[string]any{
+		"apiVersion": "kubevirt.io/v1",
+		"kind":       "VirtualMachineInstanceMigration",
+		"metadata": map[string]any{
+			"name":      migration.Name,
+			"namespace": namespace,
+			"labels":    map[string]string{vmLabel: vmi},
+		},
+		"spec": spec,
+	})
+	if err != nil {
+		return types.VMMigration{}, fmt.Errorf("failed to marshal migration: %w", err)
+	}
+	if out, err := applyManifest(kubectl, string(manifest)); err != nil {
+		return types.VMMigration{}, fmt.Errorf("failed to migrate VM %s: %s %w", vmi, out, err)
+	}
+	return migration, nil
+}
+
+// MigrateVM live-migrates a running VM, to node when it is not empty
+func (m *VMManager) MigrateVM(name, namespace, node string) (types.VMMigration, error) {
+	var vmi vmiObject
+	found, err := getObject(m.kubectl, vmiResource, namespace, name, &vmi)
+	if err != nil {
+		return types.VMMigration{}, err
+	}
+	if !found || vmi.Status.Phase != "Running" {
+		return types.VMMigration{}, fmt.Errorf("VM %s is not running in namespace %s", name, namespace)
+	}
+	if migratable, message := liveMigratable(vmi); !migratable {
+		return types.VMMigration{}, fmt.Errorf("VM %s is not live-migratable: %s", name, message)
+	}
+	if node != "" {
+		if node == vmi.Status.NodeName {
+			return types.VMMigration{}, fmt.Errorf("VM %s already runs on node %s", name, node)
+		}
+		out, err := m.kubectl.Run("get", "node", node, "-o", "name", "--ignore-not-found")
+		if err != nil {
+			return types.VMMigration{}, fmt.Errorf("failed to get node %s: %s %w", node, out, err)
+		}
+		if len(out) == 0 {
+			return types.VMMigration{}, fmt.Errorf("node %s not found", node)
+		}
+	}
+	migration, err := createMigration(m.kubectl, namespace, name, node)
+	if err != nil {
+		return types.VMMigration{}, err
+	}
+	migration.SourceNode = vmi.Status.NodeName
+	return migration, nil
+}
+
+// ListMigrations lists the migrations of a VM, including those started by node maintenance and KubeVirt
+func (m *VMManager) ListMigrations(name, namespace string) ([]types.VMMigration, error) {
+	var list migrationList
+	if err := listObjects(m.kubectl, vmiMigrationResource, namespace, &list, "-l", vmiNameLabel+"="+name); err != nil {
+		return nil, err
+	}
+	migrations := make([]types.VMMigration, 0, len(list.Items))
+	for _, item := range list.Items {
+		migrations = append(migrations, migrationFromObject(item))
+	}
+	return migrations, nil
+}
+
+// GetMigration returns a migration of a VM
+func (m *VMManager) GetMigration(name, namespace, migration string) (types.VMMigration, error) {
+	var object migrationObject
+	found, err := getObject(m.kubectl, vmiMigrationResource, namespace, migration, &object)
+	if err != nil {
+		return types.VMMigration{}, err
+	}
+	if !found || object.Spec.VMIName != name {
+		return types.VMMigration{}, fmt.Errorf("migration %s of VM %s not found in namespace %s", migration, name, namespace)
+	}
+	return migrationFromObject(object), nil
+}
+
+// evacuateNode live-migrates the migratable VMs off a cordoned node and waits for the migrations to finish. VMs
+// that can't live-migrate are left to the drain, but a migration that fails fails the evacuation, the drain would
+// kill a VM that should have moved.
+func (m *NodeManager) evacuateNode(name string) error {
+	var vmis vmiList
+	if err := listObjects(m.kubectl, vmiResource, "", &vmis, "-l", vmiNodeLabel+"="+name); err != nil {
+		return err
+	}
+	type pendingMigration struct{ namespace, name string }
+	pending := make(map[pendingMigration]bool)
+	for _, vmi := range vmis.Items {
+		if vmi.Status.Phase != "Running" {
+			continue
+		}
+		if migratable, message := liveMigratable(vmi); !migratable {
+			m.logger.Warn("VM can't live-migrate, leaving it to the drain", "node", name, "vm", vmi.Name, "namespace", vmi.Namespace, "reason", message)
+			continue
+		}
+		migration, err := createMigration(m.kubectl, vmi.Namespace, vmi.Name, "")
+		if err != nil {
+			return err
+		}
+		m.logger.Info("migrating VM off node", "node", name, "vm", vmi.Name, "namespace", vmi.Namespace, "migration", migration.Name)
+		pending[pendingMigration{vmi.Namespace, migration.Name}] = true
+	}
+
+	failed := 0
+	deadline := time.Now().Add(evacuationTimeout)
+	for len(pending) > 0 {
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for %d VM migrations off node %s", len(pending), name)
+		}
+		time.Sleep(5 * time.Second)
+		for key := range pending {
+			var object migrationObject
+			found, err := getObject(m.kubectl, vmiMigrationResource, key.namespace, key.name, &object)
+			if err != nil {
+				return err
+			}
+			if !found {
+				m.logger.Warn("VM migration disappeared", "node", name, "namespace", key.namespace, "migration", key.name)
+				failed++
+				delete(pending, key)
+				continue
+			}
+			if !migrationFinished(object.Status.Phase) {
+				continue
+			}
+			migration := migrationFromObject(object)
+			if migration.Phase == "Failed" {
+				m.logger.Warn("VM migration failed", "node", name, "namespace", key.namespace, "migration", key.name,
+					"vm", migration.VM, "error", migration.Error)
+				failed++
+			} else {
+				m.logger.Info("VM migration finished", "node", name, "namespace", key.namespace, "migration", key.name,
+					"target", migration.TargetNode)
+			}
+			delete(pending, key)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d VM migrations off node %s failed, not draining it", failed, name)
+	}
+	return nil
+}
+
+// migrationRequest checks auth and namespace access of a migration request and returns the manager, VM name and namespace
+func migrationRequest(c *gin.Context) (*VMManager, string, string, bool) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return nil, "", "", false
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return nil, "", "", false
+	}
+	namespace := c.Param("namespace")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return nil, "", "", false
+	}
+	return vmManager.forRequest(c), c.Param("name"), namespace, true
+}
+
+// MigrateVMHandler handles requests to live-migrate a VM, to the node query parameter when given
+func MigrateVMHandler(c *gin.Context) {
+	m, name, namespace, ok := migrationRequest(c)
+	if !ok {
+		return
+	}
+	migration, err := m.MigrateVM(name, namespace, c.Query("node"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to migrate VM: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM migration started", "name", name, "namespace", namespace, "migration", migration.Name, "node", migration.Node)
+	c.JSON(http.StatusOK, migration)
+}
+
+// ListVMMigrationsHandler handles requests to list the migrations of a VM
+func ListVMMigrationsHandler(c *gin.Context) {
+	m, name, namespace, ok := migrationRequest(c)
+	if !ok {
+		return
+	}
+	migrations, err := m.ListMigrations(name, namespace)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list migrations: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, migrations)
+}
+
+// GetVMMigrationHandler handles requests for a migration of a VM
+func GetVMMigrationHandler(c *gin.Context) {
+	m, name, namespace, ok := migrationRequest(c)
+	if !ok {
+		return
+	}
+	migration, err := m.GetMigration(name, namespace, c.Param("migration"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get migration: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, migration)
+}
@@ -1,10 +1,9 @@
 package server
 
 import (
-	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
-	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rusik69/govnocloud2/pkg/types"
@@ -13,12 +12,27 @@ import (
 // MysqlManager handles mysql operations
 type MysqlManager struct {
 	kubectl KubectlRunner
+	logger  *slog.Logger
 }
 
 // NewMysqlManager creates a new mysql manager
 func NewMysqlManager() *MysqlManager {
 	return &MysqlManager{
 		kubectl: &DefaultKubectlRunner{},
+		logger:  slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *MysqlManager) forRequest(c *gin.Context) *MysqlManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *MysqlManager) withLogger(logger *slog.Logger) *MysqlManager {
+	return &MysqlManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		logger:  logger,
 	}
 }
 
@@ -42,7 +56,7 @@ func ListMysqlHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	mysql, err := mysqlManager.ListClusters(namespace)
+	mysql, err := mysqlManager.forRequest(c).ListClusters(namespace)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list mysql: %v", err))
 		return
@@ -77,7 +91,16 @@ func CreateMysqlHandler(c *gin.Context) {
 	}
 
 	mysql.Namespace = namespace
-	if err := mysqlManager.CreateCluster(namespace, mysql); err != nil {
+	if isDryRun(c) {
+		manifest, err := resourceManifest(mysqlManager.generateResource(namespace, mysql))
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondWithDryRun(c, mysqlManager.forRequest(c).kubectl, manifest, namespace)
+		return
+	}
+	if err := mysqlManager.forRequest(c).CreateCluster(namespace, mysql); err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create mysql: %v", err))
 		return
 	}
@@ -104,7 +127,7 @@ func GetMysqlHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	mysql, err := mysqlManager.GetCluster(namespace, c.Param("name"))
+	mysql, err := mysqlManager.forRequest(c).GetCluster(namespace, c.Param("name"))
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get mysql: %v", err))
 		return
@@ -132,16 +155,16 @@ func DeleteMysqlHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	if err := mysqlManager.DeleteCluster(namespace, c.Param("name")); err != nil {
+	if err := mysqlManager.forRequest(c).DeleteCluster(namespace, c.Param("name")); err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete mysql: %v", err))
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
-// CreateCluster creates a new mysql cluster
-func (m *MysqlManager) CreateCluster(namespace string, mysql types.Mysql) error {
-	secretBody := `apiVersion: v1
+// generateSecretManifest generates the credentials Secret for the mysql cluster
+func (m *MysqlManager) generateSecretManifest(mysql types.Mysql) string {
+	return `apiVersion: v1
 kind: Secret
 metadata:
   name: ` + mysql.Name + `-mypwds
@@ -150,19 +173,11 @@ stringData:
   rootHost: '%'
   rootPassword: password
 `
-	tempSecretFile, err := os.CreateTemp("", "mysql-secret.yaml")
-	if err != nil {
-		return err
-	}
-	defer tempSecretFile.Close()
-	defer os.Remove(tempSecretFile.Name())
-	if _, err := tempSecretFile.WriteString(secretBody); err != nil {
-		return err
-	}
-	if out, err := m.kubectl.Run("apply", "-f", tempSecretFile.Name(), "-n", namespace, "--wait=true", "--timeout=300s"); err != nil {
-		return fmt.Errorf("failed to create mysql secret: %w %s", err, out)
-	}
-	manifestBody := fmt.Sprintf(`apiVersion: mysql.oracle.com/v2
+}
+
+// generateManifest generates an InnoDBCluster manifest for the mysql cluster
+func (m *MysqlManager) generateManifest(mysql types.Mysql) string {
+	return fmt.Sprintf(`apiVersion: mysql.oracle.com/v2
 kind: InnoDBCluster
 metadata:
   name: %s
@@ -173,66 +188,107 @@ spec:
     instances: %d
   tlsUseSelfSigned: true
 `, mysql.Name, mysql.Name, mysql.Instances, mysql.RouterInstances)
-	tempFile, err := os.CreateTemp("", "mysql-manifest.yaml")
-	if err != nil {
-		return err
+}
+
+// generateResource generates the GovnoDatabase custom resource for the mysql cluster
+func (m *MysqlManager) generateResource(namespace string, mysql types.Mysql) types.GovnoDatabase {
+	return databaseResource(namespace, mysql.Name, types.GovnoDatabaseSpec{
+		Engine:         types.DatabaseEngineMysql,
+		Replicas:       mysql.Instances,
+		RouterReplicas: mysql.RouterInstances,
+	})
+}
+
+// mysqlFromResource converts a GovnoDatabase to a mysql cluster
+func mysqlFromResource(db *types.GovnoDatabase) types.Mysql {
+	return types.Mysql{
+		Name:            db.Name,
+		Namespace:       db.Namespace,
+		Instances:       db.Spec.Replicas,
+		RouterInstances: db.Spec.RouterReplicas,
+	}
+}
+
+// CreateCluster creates a new mysql cluster by writing its GovnoDatabase, the controller brings it up
+func (m *MysqlManager) CreateCluster(namespace string, mysql types.Mysql) error {
+	resource := m.generateResource(namespace, mysql)
+	m.logger.Debug("generated mysql resource", "resource", resource)
+	if err := createDatabase(m.kubectl, resource); err != nil {
+		return fmt.Errorf("failed to create mysql cluster: %w", err)
 	}
-	defer tempFile.Close()
-	defer os.Remove(tempFile.Name())
-	if _, err := tempFile.WriteString(manifestBody); err != nil {
+	return nil
+}
+
+// innoDBClusterResource is the MySQL operator InnoDBCluster resource as understood by kubectl
+const innoDBClusterResource = "innodbclusters.mysql.oracle.com"
+
+// applyDatabase applies the credentials Secret and InnoDBCluster of a mysql GovnoDatabase
+func (m *MysqlManager) applyDatabase(db *types.GovnoDatabase) error {
+	mysql := mysqlFromResource(db)
+	if out, err := applyManifest(m.kubectl, m.generateSecretManifest(mysql), "-n", db.Namespace); err != nil {
+		return fmt.Errorf("failed to apply mysql secret: %w %s", err, out)
+	}
+	if err := setOwner(m.kubectl, "secrets", db.Namespace, mysql.Name+"-mypwds", govnoDatabaseKind, db.ObjectMeta); err != nil {
 		return err
 	}
-	if out, err := m.kubectl.Run("apply", "-f", tempFile.Name(), "-n", namespace, "--wait=true", "--timeout=300s"); err != nil {
-		return fmt.Errorf("failed to create mysql cluster: %w %s", err, out)
+	if out, err := applyManifest(m.kubectl, m.generateManifest(mysql), "-n", db.Namespace); err != nil {
+		return fmt.Errorf("failed to apply mysql cluster: %w %s", err, out)
 	}
-	return nil
+	return setOwner(m.kubectl, innoDBClusterResource, db.Namespace, db.Name, govnoDatabaseKind, db.ObjectMeta)
+}
+
+// databaseState returns the state of the InnoDBCluster of a mysql GovnoDatabase
+func (m *MysqlManager) databaseState(namespace, name string) (resourceState, error) {
+	var cluster struct {
+		Status struct {
+			Cluster struct {
+				Status          string `json:"status"`
+				OnlineInstances int    `json:"onlineInstances"`
+			} `json:"cluster"`
+		} `json:"status"`
+	}
+	found, err := getObject(m.kubectl, innoDBClusterResource, namespace, name, &cluster)
+	if err != nil || !found {
+		return resourceState{}, err
+	}
+	return resourceState{
+		Found:   true,
+		Phase:   cluster.Status.Cluster.Status,
+		Ready:   cluster.Status.Cluster.Status == "ONLINE",
+		Message: fmt.Sprintf("%d instances online", cluster.Status.Cluster.OnlineInstances),
+	}, nil
 }
 
 // GetCluster retrieves mysql cluster details
 func (m *MysqlManager) GetCluster(namespace, name string) (*types.Mysql, error) {
-	out, err := m.kubectl.Run("get", "InnoDBCluster", name, "-o", "json", "-n", namespace)
+	db, err := getDatabase(m.kubectl, namespace, name, types.DatabaseEngineMysql)
 	if err != nil {
 		return nil, err
 	}
-	var mysqlCluster types.MysqlCluster
-	if err := json.Unmarshal([]byte(out), &mysqlCluster); err != nil {
-		return nil, err
-	}
-	mysql := &types.Mysql{
-		Name:            mysqlCluster.Metadata.Name,
-		Namespace:       namespace,
-		Instances:       mysqlCluster.Spec.Instances,
-		RouterInstances: mysqlCluster.Spec.RouterInstances,
+	if db == nil {
+		return nil, fmt.Errorf("mysql cluster %s not found in namespace %s", name, namespace)
 	}
-	return mysql, nil
+	mysql := mysqlFromResource(db)
+	return &mysql, nil
 }
 
 // DeleteCluster removes a mysql cluster
 func (m *MysqlManager) DeleteCluster(namespace, name string) error {
-	if out, err := m.kubectl.Run("delete", "InnoDBCluster", name, "-n", namespace, "--force", "--grace-period=0"); err != nil {
-		return fmt.Errorf("failed to delete mysql cluster: %w\nOutput: %s", err, out)
+	if err := deleteDatabase(m.kubectl, namespace, name, types.DatabaseEngineMysql); err != nil {
+		return fmt.Errorf("failed to delete mysql cluster: %w", err)
 	}
 	return nil
 }
 
 // ListClusters lists all mysql clusters in a namespace
 func (m *MysqlManager) ListClusters(namespace string) ([]types.Mysql, error) {
-	out, err := m.kubectl.Run("get", "InnoDBCluster", "-o", "json", "-n", namespace)
+	databases, err := listDatabases(m.kubectl, namespace, types.DatabaseEngineMysql)
 	if err != nil {
 		return nil, err
 	}
-	var mysqlClusterList types.MysqlClusterList
-	if err := json.Unmarshal([]byte(out), &mysqlClusterList); err != nil {
-		return nil, err
-	}
-	res := make([]types.Mysql, 0, len(mysqlClusterList.Items))
-	for _, mysqlCluster := range mysqlClusterList.Items {
-		res = append(res, types.Mysql{
-			Name:            mysqlCluster.Metadata.Name,
-			Namespace:       namespace,
-			Instances:       mysqlCluster.Spec.Instances,
-			RouterInstances: mysqlCluster.Spec.RouterInstances,
-		})
+	res := make([]types.Mysql, 0, len(databases))
+	for i := range databases {
+		res = append(res, mysqlFromResource(&databases[i]))
 	}
 	return res, nil
 }
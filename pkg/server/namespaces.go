@@ -2,7 +2,7 @@ package server
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -13,6 +13,7 @@ import (
 // NamespaceManager handles namespace operations
 type NamespaceManager struct {
 	kubectl KubectlRunner
+	logger  *slog.Logger
 }
 
 // CreateNamespace creates a new namespace
@@ -57,7 +58,22 @@ func (m *NamespaceManager) GetNamespace(name string) (types.Namespace, error) {
 // NewNamespaceManager creates a new namespace manager
 func NewNamespaceManager() *NamespaceManager {
 	return &NamespaceManager{
-		kubectl: &DefaultKubectlRunner{}}
+		kubectl: &DefaultKubectlRunner{},
+		logger:  slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *NamespaceManager) forRequest(c *gin.Context) *NamespaceManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *NamespaceManager) withLogger(logger *slog.Logger) *NamespaceManager {
+	return &NamespaceManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		logger:  logger,
+	}
 }
 
 // CreateNamespaceHandler creates a new namespace
@@ -85,12 +101,12 @@ func CreateNamespaceHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user is not an admin")
 		return
 	}
-	err = namespaceManager.CreateNamespace(name)
+	err = namespaceManager.forRequest(c).CreateNamespace(name)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	log.Println("namespace created successfully")
+	requestLogger(c).Info("namespace created successfully", "name", name)
 	c.JSON(http.StatusOK, gin.H{"message": "namespace created successfully"})
 }
 
@@ -119,12 +135,12 @@ func DeleteNamespaceHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user is not an admin")
 		return
 	}
-	err = namespaceManager.DeleteNamespace(name)
+	err = namespaceManager.forRequest(c).DeleteNamespace(name)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	log.Println("namespace deleted successfully")
+	requestLogger(c).Info("namespace deleted successfully", "name", name)
 	c.JSON(http.StatusOK, gin.H{"message": "namespace deleted successfully"})
 }
 
@@ -139,12 +155,12 @@ func ListNamespacesHandler(c *gin.Context) {
 		respondWithError(c, http.StatusUnauthorized, "unauthorized")
 		return
 	}
-	namespaces, err := namespaceManager.ListNamespaces()
+	namespaces, err := namespaceManager.forRequest(c).ListNamespaces()
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	log.Println("namespaces listed successfully")
+	requestLogger(c).Debug("namespaces listed successfully", "namespaces", namespaces)
 	c.JSON(http.StatusOK, gin.H{"namespaces": namespaces})
 }
 
@@ -174,11 +190,11 @@ func GetNamespaceHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	namespace, err := namespaceManager.GetNamespace(name)
+	namespace, err := namespaceManager.forRequest(c).GetNamespace(name)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	log.Println("namespace retrieved successfully")
+	requestLogger(c).Debug("namespace retrieved successfully", "name", name)
 	c.JSON(http.StatusOK, gin.H{"namespace": namespace})
 }
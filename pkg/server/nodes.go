@@ -9,10 +9,11 @@ import (
 	"os/exec"
 	"strings"
 
-	"log"
+	"log/slog"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rusik69/govnocloud2/pkg/k8s"
+	"github.com/rusik69/govnocloud2/pkg/logging"
 	"github.com/rusik69/govnocloud2/pkg/ssh"
 	"github.com/rusik69/govnocloud2/pkg/types"
 )
@@ -20,6 +21,7 @@ import (
 // NodeManager handles node operations
 type NodeManager struct {
 	kubectl KubectlRunner
+	logger  *slog.Logger
 }
 
 // KubectlRunner interface for executing kubectl commands
@@ -38,27 +40,71 @@ type K3supRunner interface {
 }
 
 // DefaultKubectlRunner implements KubectlRunner using exec.Command
-type DefaultKubectlRunner struct{}
+type DefaultKubectlRunner struct {
+	logger *slog.Logger
+}
 
 // DefaultVirtctlRunner implements VirtctlRunner using exec.Command
-type DefaultVirtctlRunner struct{}
+type DefaultVirtctlRunner struct {
+	logger *slog.Logger
+}
 
 func (k *DefaultKubectlRunner) Run(args ...string) ([]byte, error) {
-	log.Printf("running kubectl command: %v", args)
-	return exec.Command("kubectl", args...).CombinedOutput()
+	logger := logging.OrDefault(k.logger)
+	logger.Debug("running kubectl command", "args", args)
+	out, err := exec.Command("kubectl", args...).CombinedOutput()
+	if err != nil {
+		logger.Warn("kubectl command failed", "args", args, "error", err, "output", out)
+	}
+	return out, err
 }
 
 func (k *DefaultVirtctlRunner) Run(args ...string) ([]byte, error) {
-	log.Printf("running virtctl command: %v", args)
+	logger := logging.OrDefault(k.logger)
+	logger.Debug("running virtctl command", "args", args)
 	cmd := exec.Command("virtctl", args...)
 	cmd.Env = append(os.Environ(), "KUBECONFIG=/etc/rancher/k3s/k3s.yaml")
-	return cmd.CombinedOutput()
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		logger.Warn("virtctl command failed", "args", args, "error", err, "output", out)
+	}
+	return out, err
+}
+
+// kubectlWithLogger returns a default runner logging with logger, custom runners are kept as they are
+func kubectlWithLogger(r KubectlRunner, logger *slog.Logger) KubectlRunner {
+	if _, ok := r.(*DefaultKubectlRunner); ok {
+		return &DefaultKubectlRunner{logger: logger}
+	}
+	return r
+}
+
+// virtctlWithLogger returns a default runner logging with logger, custom runners are kept as they are
+func virtctlWithLogger(r VirtctlRunner, logger *slog.Logger) VirtctlRunner {
+	if _, ok := r.(*DefaultVirtctlRunner); ok {
+		return &DefaultVirtctlRunner{logger: logger}
+	}
+	return r
 }
 
 // NewNodeManager creates a new NodeManager instance
 func NewNodeManager() *NodeManager {
 	return &NodeManager{
 		kubectl: &DefaultKubectlRunner{},
+		logger:  slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *NodeManager) forRequest(c *gin.Context) *NodeManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *NodeManager) withLogger(logger *slog.Logger) *NodeManager {
+	return &NodeManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		logger:  logger,
 	}
 }
 
@@ -73,15 +119,15 @@ func ListNodesHandler(c *gin.Context) {
 		respondWithError(c, http.StatusUnauthorized, "unauthorized")
 		return
 	}
-	nodes, err := nodeManager.ListNodes()
+	nodes, err := nodeManager.forRequest(c).ListNodes()
 	if err != nil {
-		log.Printf("failed to list nodes: %v", err)
+		requestLogger(c).Error("failed to list nodes", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": fmt.Sprintf("failed to list nodes: %v", err),
 		})
 		return
 	}
-	log.Printf("nodes: %v", nodes)
+	requestLogger(c).Debug("listed nodes", "nodes", nodes)
 	c.JSON(http.StatusOK, nodes)
 }
 
@@ -89,13 +135,13 @@ func ListNodesHandler(c *gin.Context) {
 func (m *NodeManager) ListNodes() ([]string, error) {
 	out, err := m.kubectl.Run("get", "nodes", "-o", "jsonpath={.items[*].metadata.name}")
 	if err != nil {
-		log.Printf("failed to get nodes: %v", err)
+		m.logger.Error("failed to get nodes", "error", err)
 		return nil, fmt.Errorf("failed to get nodes: %w", err)
 	}
 
 	nodes := strings.Fields(string(out))
 	if len(nodes) == 0 {
-		log.Printf("no nodes found")
+		m.logger.Info("no nodes found")
 		return []string{}, nil
 	}
 
@@ -115,7 +161,7 @@ func GetNodeHandler(c *gin.Context) {
 	}
 	nodeName := c.Param("name")
 	if nodeName == "" {
-		log.Printf("node name is required")
+		requestLogger(c).Warn("node name is required")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "node name is required"})
 		return
 	}
@@ -123,16 +169,16 @@ func GetNodeHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have admin access")
 		return
 	}
-	node, err := nodeManager.GetNode(nodeName)
+	node, err := nodeManager.forRequest(c).GetNode(nodeName)
 	if err != nil {
-		log.Printf("failed to get node %s: %v", nodeName, err)
+		requestLogger(c).Error("failed to get node", "node_name", nodeName, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": fmt.Sprintf("failed to get node %s: %v", nodeName, err),
 		})
 		return
 	}
 
-	log.Printf("node: %+v", node)
+	requestLogger(c).Debug("got node", "node", node)
 
 	if node == nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "node not found"})
@@ -194,7 +240,7 @@ func DeleteNodeHandler(c *gin.Context) {
 	}
 	nodeName := c.Param("name")
 	if nodeName == "" {
-		log.Printf("node name is required")
+		requestLogger(c).Warn("node name is required")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "node name is required"})
 		return
 	}
@@ -202,16 +248,16 @@ func DeleteNodeHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have admin access")
 		return
 	}
-	node, err := nodeManager.GetNode(nodeName)
+	node, err := nodeManager.forRequest(c).GetNode(nodeName)
 	if err != nil {
-		log.Printf("failed to get node %s: %v", nodeName, err)
+		requestLogger(c).Error("failed to get node", "node_name", nodeName, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": fmt.Sprintf("failed to get node %s: %v", nodeName, err),
 		})
 	}
 
-	if err := nodeManager.DeleteNode(node.Host); err != nil {
-		log.Printf("failed to delete node %s: %v", nodeName, err)
+	if err := nodeManager.forRequest(c).DeleteNode(node.Host); err != nil {
+		requestLogger(c).Error("failed to delete node", "node_name", nodeName, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{
 			"error": fmt.Sprintf("failed to delete node %s: %v", nodeName, err),
 		})
@@ -224,7 +270,7 @@ func DeleteNodeHandler(c *gin.Context) {
 // DeleteNode removes a node from the cluster
 func (m *NodeManager) DeleteNode(name string) error {
 	cmd := "sudo /usr/local/bin/k3s-agent-uninstall.sh"
-	out, err := ssh.Run(cmd, name, server.config.Key, server.config.SSHUser, "", true, 600)
+	out, err := ssh.RunWithLogger(m.logger, cmd, name, server.config.Key, server.config.SSHUser, "", true, 600)
 	if err != nil {
 		return fmt.Errorf("failed to uninstall k3s node: %w %s", err, out)
 	}
@@ -251,14 +297,14 @@ func AddNodeHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read request body"})
 		return
 	}
-	log.Println(string(body))
 	var node types.Node
 	if err := json.Unmarshal(body, &node); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to parse request body"})
 		return
 	}
+	requestLogger(c).Debug("add node request", "node", node)
 
-	if err := nodeManager.AddNode(node); err != nil {
+	if err := nodeManager.forRequest(c).AddNode(node); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Errorf("failed to add node: %v", err)})
 		return
 	}
@@ -290,13 +336,13 @@ func RestartNodeHandler(c *gin.Context) {
 	}
 	nodeName := c.Param("name")
 	if nodeName == "" {
-		log.Printf("node name is required")
+		requestLogger(c).Warn("node name is required")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "node name is required"})
 		return
 	}
 
-	if err := nodeManager.RestartNode(nodeName); err != nil {
-		log.Printf("failed to restart node %s: %v", nodeName, err)
+	if err := nodeManager.forRequest(c).RestartNode(nodeName); err != nil {
+		requestLogger(c).Error("failed to restart node", "node_name", nodeName, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Errorf("failed to restart node: %v", err)})
 		return
 	}
@@ -324,13 +370,8 @@ func (m *NodeManager) RestartNode(name string) error {
 	if password == "" {
 		return fmt.Errorf("password is required")
 	}
-	_, err = m.kubectl.Run("cordon", "node", name)
-	if err != nil {
-		return fmt.Errorf("failed to cordon node: %w", err)
-	}
-	_, err = m.kubectl.Run("drain", "node", name, "--ignore-daemonsets", "--delete-emptydir-data")
-	if err != nil {
-		return fmt.Errorf("failed to drain node: %w", err)
+	if err := m.prepareMaintenance(name); err != nil {
+		return err
 	}
 	rebootCmd := fmt.Sprintf("ssh -i %s %s@%s 'sudo reboot'", key, user, host)
 	_, err = m.kubectl.Run(rebootCmd)
@@ -344,6 +385,20 @@ func (m *NodeManager) RestartNode(name string) error {
 	return nil
 }
 
+// prepareMaintenance cordons a node, live-migrates its migratable VMs away and drains the rest
+func (m *NodeManager) prepareMaintenance(name string) error {
+	if _, err := m.kubectl.Run("cordon", "node", name); err != nil {
+		return fmt.Errorf("failed to cordon node: %w", err)
+	}
+	if err := m.evacuateNode(name); err != nil {
+		return fmt.Errorf("failed to migrate VMs off node: %w", err)
+	}
+	if _, err := m.kubectl.Run("drain", "node", name, "--ignore-daemonsets", "--delete-emptydir-data"); err != nil {
+		return fmt.Errorf("failed to drain node: %w", err)
+	}
+	return nil
+}
+
 // SuspendNodeHandler handles HTTP requests to suspend a node
 func SuspendNodeHandler(c *gin.Context) {
 	auth, username, err := CheckAuth(c)
@@ -364,7 +419,7 @@ func SuspendNodeHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "host name is required"})
 		return
 	}
-	if err := nodeManager.SuspendNode(hostName, server.config.SSHUser, server.config.Key); err != nil {
+	if err := nodeManager.forRequest(c).SuspendNode(hostName, server.config.SSHUser, server.config.Key); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Errorf("failed to suspend node: %v", err)})
 		return
 	}
@@ -373,7 +428,7 @@ func SuspendNodeHandler(c *gin.Context) {
 // SuspendNode suspends a node
 func (m *NodeManager) SuspendNode(host, user, key string) error {
 	cmd := fmt.Sprintf("ssh -i %s %s@%s 'sudo systemctl suspend'", key, user, host)
-	_, err := ssh.Run(cmd, host, key, user, "", true, 10)
+	_, err := ssh.RunWithLogger(m.logger, cmd, host, key, user, "", true, 10)
 	if err != nil {
 		return fmt.Errorf("failed to suspend node: %w", err)
 	}
@@ -400,7 +455,7 @@ func ResumeNodeHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "host name is required"})
 		return
 	}
-	if err := nodeManager.ResumeNode(hostName, server.config.SSHUser, server.config.Key); err != nil {
+	if err := nodeManager.forRequest(c).ResumeNode(hostName, server.config.SSHUser, server.config.Key); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Errorf("failed to resume node: %v", err)})
 		return
 	}
@@ -428,31 +483,37 @@ func UpgradeNodeHandler(c *gin.Context) {
 	}
 	hostName := c.Param("name")
 	if hostName == "" {
-		log.Printf("host name is required")
+		requestLogger(c).Warn("host name is required")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "host name is required"})
 		return
 	}
-	node, err := nodeManager.GetNode(hostName)
+	node, err := nodeManager.forRequest(c).GetNode(hostName)
 	if err != nil {
-		log.Printf("failed to get node %s: %v", hostName, err)
+		requestLogger(c).Error("failed to get node", "host_name", hostName, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Errorf("failed to get node: %v", err)})
 		return
 	}
-	if err := nodeManager.UpgradeNode(node.Host, node.User, node.Key); err != nil {
-		log.Printf("failed to upgrade node %s@%s:%s %v", node.User, node.Host, node.Key, err)
+	if err := nodeManager.forRequest(c).UpgradeNode(hostName, node.Host, node.User, node.Key); err != nil {
+		requestLogger(c).Error("failed to upgrade node", "user", node.User, "host", node.Host, "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Errorf("failed to upgrade node: %v", err)})
 		return
 	}
 }
 
-// UpgradeNode upgrades a node
-func (m *NodeManager) UpgradeNode(host, user, key string) error {
+// UpgradeNode upgrades a node, moving its workloads away for the upgrade
+func (m *NodeManager) UpgradeNode(name, host, user, key string) error {
+	if err := m.prepareMaintenance(name); err != nil {
+		return err
+	}
 	cmd := "sudo apt-get update && sudo apt-get upgrade -y"
-	log.Printf("upgrading node %s with command %s", host, cmd)
-	out, err := ssh.Run(cmd, host, key, user, "", false, 600)
+	m.logger.Info("upgrading node", "host", host, "cmd", cmd)
+	out, err := ssh.RunWithLogger(m.logger, cmd, host, key, user, "", false, 600)
 	if err != nil {
 		return fmt.Errorf("failed to upgrade node: %w", err)
 	}
-	log.Printf("upgrade node %s output: %s", host, out)
+	m.logger.Info("upgrade node output", "host", host, "output", out)
+	if _, err := m.kubectl.Run("uncordon", "node", name); err != nil {
+		return fmt.Errorf("failed to uncordon node: %w", err)
+	}
 	return nil
 }
@@ -1,14 +1,10 @@
 package server
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
-	"os"
-	"strconv"
-	"strings"
 
-	"log"
+	"log/slog"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rusik69/govnocloud2/pkg/types"
@@ -17,12 +13,27 @@ import (
 // PostgresManager handles postgres operations
 type PostgresManager struct {
 	kubectl KubectlRunner
+	logger  *slog.Logger
 }
 
 // NewPostgresManager creates a new postgres manager instance
 func NewPostgresManager() *PostgresManager {
 	return &PostgresManager{
 		kubectl: &DefaultKubectlRunner{},
+		logger:  slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *PostgresManager) forRequest(c *gin.Context) *PostgresManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *PostgresManager) withLogger(logger *slog.Logger) *PostgresManager {
+	return &PostgresManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		logger:  logger,
 	}
 }
 
@@ -46,7 +57,7 @@ func ListPostgresHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	postgres, err := postgresManager.ListClusters(namespace)
+	postgres, err := postgresManager.forRequest(c).ListClusters(namespace)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to list databases: %v", err)})
 		return
@@ -79,8 +90,23 @@ func CreatePostgresHandler(c *gin.Context) {
 		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
 		return
 	}
+	postgres.Namespace = namespace
+
+	if isDryRun(c) {
+		if _, err := postgresManager.generateManifest(&postgres); err != nil {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+			return
+		}
+		manifest, err := resourceManifest(postgresManager.generateResource(&postgres))
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondWithDryRun(c, postgresManager.forRequest(c).kubectl, manifest, namespace)
+		return
+	}
 
-	if err := postgresManager.CreateCluster(&postgres); err != nil {
+	if err := postgresManager.forRequest(c).CreateCluster(&postgres); err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create postgres: %v", err))
 		return
 	}
@@ -107,7 +133,7 @@ func GetPostgresHandler(c *gin.Context) {
 
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		respondWithError(c, http.StatusBadRequest, "namespace is required")
 		return
 	}
@@ -117,7 +143,7 @@ func GetPostgresHandler(c *gin.Context) {
 		return
 	}
 
-	postgres, err := postgresManager.GetCluster(name, namespace)
+	postgres, err := postgresManager.forRequest(c).GetCluster(name, namespace)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get postgres: %v", err))
 		return
@@ -159,20 +185,20 @@ func DeletePostgresHandler(c *gin.Context) {
 		return
 	}
 
-	if err := postgresManager.DeleteCluster(name, namespace); err != nil {
+	if err := postgresManager.forRequest(c).DeleteCluster(name, namespace); err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete postgres: %v", err))
 		return
 	}
 
-	log.Printf("postgres %s deleted successfully", name)
+	requestLogger(c).Info("postgres deleted successfully", "name", name)
 	c.JSON(http.StatusOK, gin.H{"message": "Postgres deleted successfully"})
 }
 
 // generateManifest generates a Pod manifest for the postgres
 func (m *PostgresManager) generateManifest(postgres *types.Postgres) (string, error) {
-	size, ok := types.PostgresSizes[postgres.Size]
-	if !ok {
-		return "", fmt.Errorf("failed to get postgres size: %s", postgres.Size)
+	size, err := lookupPostgresSize(postgres.Size)
+	if err != nil {
+		return "", fmt.Errorf("failed to get postgres size: %w", err)
 	}
 	pod := fmt.Sprintf(`apiVersion: postgresql.cnpg.io/v1
 kind: Cluster
@@ -196,98 +222,114 @@ spec:
 	return pod, nil
 }
 
+// generateResource generates the GovnoDatabase custom resource for the postgres
+func (m *PostgresManager) generateResource(postgres *types.Postgres) types.GovnoDatabase {
+	return databaseResource(postgres.Namespace, postgres.Name, types.GovnoDatabaseSpec{
+		Engine:   types.DatabaseEnginePostgres,
+		Size:     postgres.Size,
+		Replicas: postgres.Replicas,
+		Storage:  postgres.Storage,
+	})
+}
+
+// postgresFromResource converts a GovnoDatabase to a postgres
+func postgresFromResource(db *types.GovnoDatabase) *types.Postgres {
+	return &types.Postgres{
+		Name:      db.Name,
+		Namespace: db.Namespace,
+		Size:      db.Spec.Size,
+		Replicas:  db.Spec.Replicas,
+		Storage:   db.Spec.Storage,
+	}
+}
+
 // ListClusters returns a list of postgres clusters
 func (m *PostgresManager) ListClusters(namespace string) ([]types.Postgres, error) {
-	out, err := m.kubectl.Run("get", "clusters", "-o", "json", "-n", namespace)
+	databases, err := listDatabases(m.kubectl, namespace, types.DatabaseEnginePostgres)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list postgres: %w", err)
 	}
-
-	var clusterList types.PostgresClusterList
-	if err := json.Unmarshal([]byte(out), &clusterList); err != nil {
-		return nil, fmt.Errorf("failed to parse postgres cluster list: %w", err)
-	}
-	postgresClusters := make([]types.Postgres, 0, len(clusterList.Items))
-	for _, cluster := range clusterList.Items {
-		// cut Gi from the end of the storage size and convert to int
-		storageSize, err := strconv.Atoi(strings.TrimSuffix(cluster.Spec.Storage.Size, "Gi"))
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse storage size: %w", err)
-		}
-		postgresClusters = append(postgresClusters, types.Postgres{
-			Name:      cluster.Metadata.Name,
-			Size:      cluster.Metadata.Labels.Size,
-			Namespace: namespace,
-			Replicas:  cluster.Spec.Instances,
-			Storage:   storageSize,
-		})
+	postgresClusters := make([]types.Postgres, 0, len(databases))
+	for i := range databases {
+		postgresClusters = append(postgresClusters, *postgresFromResource(&databases[i]))
 	}
-
 	return postgresClusters, nil
 }
 
-// CreateCluster creates a new postgres cluster
+// CreateCluster creates a new postgres cluster by writing its GovnoDatabase, the controller brings it up
 func (m *PostgresManager) CreateCluster(postgres *types.Postgres) error {
-	// Validate DB size exists
-	if _, ok := types.PostgresSizes[postgres.Size]; !ok {
-		return fmt.Errorf("invalid database size: %s", postgres.Size)
-	}
-
-	cluster, err := m.generateManifest(postgres)
+	// Validate DB size exists and can be picked
+	size, err := lookupPostgresSize(postgres.Size)
 	if err != nil {
-		return fmt.Errorf("failed to generate pod manifest: %w", err)
+		return err
 	}
-	log.Println(cluster)
-	tmpFile, err := os.CreateTemp("", "postgres-*.yaml")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+	if size.Retired {
+		return fmt.Errorf("database size %s is retired", postgres.Size)
 	}
-	defer tmpFile.Close()
-	defer os.Remove(tmpFile.Name())
-
-	if err := os.WriteFile(tmpFile.Name(), []byte(cluster), 0644); err != nil {
-		return fmt.Errorf("failed to write manifest: %w", err)
+	resource := m.generateResource(postgres)
+	m.logger.Debug("generated postgres resource", "resource", resource)
+	if err := createDatabase(m.kubectl, resource); err != nil {
+		return fmt.Errorf("failed to create postgres cluster: %w", err)
 	}
+	return nil
+}
 
-	if out, err := m.kubectl.Run("apply", "-f", tmpFile.Name(), "-n", postgres.Namespace, "--wait=true", "--timeout=300s"); err != nil {
-		return fmt.Errorf("failed to create database pod: %w %s", err, out)
+// applyDatabase applies the CNPG Cluster of a postgres GovnoDatabase
+func (m *PostgresManager) applyDatabase(db *types.GovnoDatabase) error {
+	cluster, err := m.generateManifest(postgresFromResource(db))
+	if err != nil {
+		return fmt.Errorf("failed to generate cluster manifest: %w", err)
 	}
+	m.logger.Debug("got postgres cluster", "cluster", cluster)
+	if out, err := applyManifest(m.kubectl, cluster, "-n", db.Namespace); err != nil {
+		return fmt.Errorf("failed to apply postgres cluster: %w %s", err, out)
+	}
+	return setOwner(m.kubectl, postgresClusterResource, db.Namespace, db.Name, govnoDatabaseKind, db.ObjectMeta)
+}
 
-	return nil
+// postgresClusterResource is the CNPG Cluster resource as understood by kubectl
+const postgresClusterResource = "clusters.postgresql.cnpg.io"
+
+// databaseState returns the state of the CNPG Cluster of a postgres GovnoDatabase
+func (m *PostgresManager) databaseState(namespace, name string) (resourceState, error) {
+	var cluster struct {
+		Spec struct {
+			Instances int `json:"instances"`
+		} `json:"spec"`
+		Status struct {
+			Phase          string `json:"phase"`
+			ReadyInstances int    `json:"readyInstances"`
+		} `json:"status"`
+	}
+	found, err := getObject(m.kubectl, postgresClusterResource, namespace, name, &cluster)
+	if err != nil || !found {
+		return resourceState{}, err
+	}
+	ready := cluster.Spec.Instances > 0 && cluster.Status.ReadyInstances >= cluster.Spec.Instances
+	return resourceState{
+		Found:   true,
+		Phase:   cluster.Status.Phase,
+		Ready:   ready,
+		Message: fmt.Sprintf("%d/%d instances ready", cluster.Status.ReadyInstances, cluster.Spec.Instances),
+	}, nil
 }
 
 // GetCluster retrieves postgres cluster details
 func (m *PostgresManager) GetCluster(name, namespace string) (*types.Postgres, error) {
-	out, err := m.kubectl.Run("get", "cluster", name, "-o", "json", "-n", namespace)
+	db, err := getDatabase(m.kubectl, namespace, name, types.DatabaseEnginePostgres)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get postgres cluster: %w", err)
 	}
-
-	var cluster types.PostgresCluster
-	if err := json.Unmarshal([]byte(out), &cluster); err != nil {
-		return nil, fmt.Errorf("failed to parse cluster details: %w", err)
-	}
-
-	storageSize, err := strconv.Atoi(strings.TrimSuffix(cluster.Spec.Storage.Size, "Gi"))
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse storage size: %w", err)
+	if db == nil {
+		return nil, fmt.Errorf("postgres cluster %s not found in namespace %s", name, namespace)
 	}
-
-	postgres := &types.Postgres{
-		Name:      cluster.Metadata.Name,
-		Size:      cluster.Metadata.Labels.Size,
-		Namespace: namespace,
-		Replicas:  cluster.Spec.Instances,
-		Storage:   storageSize,
-	}
-
-	return postgres, nil
+	return postgresFromResource(db), nil
 }
 
 // DeleteCluster removes a postgres cluster
 func (m *PostgresManager) DeleteCluster(name, namespace string) error {
-	if out, err := m.kubectl.Run("delete", "Cluster", name, "-n", namespace, "--force", "--grace-period=0"); err != nil {
-		return fmt.Errorf("failed to delete postgres cluster: %w\nOutput: %s", err, out)
+	if err := deleteDatabase(m.kubectl, namespace, name, types.DatabaseEnginePostgres); err != nil {
+		return fmt.Errorf("failed to delete postgres cluster: %w", err)
 	}
 	return nil
 }
@@ -0,0 +1,423 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+	// timezones of schedules resolve without the host's zoneinfo
+	_ "time/tzdata"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// scheduleInterval is how often the scheduler looks for due schedules, the resolution of cron expressions
+const scheduleInterval = time.Minute
+
+// ScheduleManager stores cron-style power schedules in etcd and runs them on VMs and containers
+type ScheduleManager struct {
+	etcdClient *clientv3.Client
+	logger     *slog.Logger
+}
+
+// NewScheduleManager creates a new schedule manager
+func NewScheduleManager(etcdClient *clientv3.Client) *ScheduleManager {
+	return &ScheduleManager{
+		etcdClient: etcdClient,
+		logger:     slog.Default().With("component", "scheduler"),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *ScheduleManager) forRequest(c *gin.Context) *ScheduleManager {
+	return &ScheduleManager{etcdClient: m.etcdClient, logger: requestLogger(c)}
+}
+
+// etcdKey returns the etcd key of a schedule, or the prefix of the schedules of a namespace when name is empty
+func (m *ScheduleManager) etcdKey(namespace, name string) string {
+	if namespace == "" {
+		return "/schedules/"
+	}
+	return "/schedules/" + namespace + "/" + name
+}
+
+// validateSchedule validates a schedule before it is stored
+func validateSchedule(schedule types.Schedule) error {
+	if errs := validation.IsDNS1123Label(schedule.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid schedule name %s: %s", schedule.Name, strings.Join(errs, ", "))
+	}
+	if schedule.Kind != types.ScheduleKindVM && schedule.Kind != types.ScheduleKindContainer {
+		return fmt.Errorf("invalid kind %q, must be %s or %s", schedule.Kind, types.ScheduleKindVM, types.ScheduleKindContainer)
+	}
+	switch schedule.Action {
+	case types.ScheduleActionStart, types.ScheduleActionStop, types.ScheduleActionRestart:
+	default:
+		return fmt.Errorf("invalid action %q, must be start, stop or restart", schedule.Action)
+	}
+	if len(schedule.Targets) == 0 {
+		return fmt.Errorf("a schedule needs targets, %s targets all of the namespace", types.ScheduleAllTargets)
+	}
+	for _, target := range schedule.Targets {
+		if target == types.ScheduleAllTargets {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(target); len(errs) > 0 {
+			return fmt.Errorf("invalid target %s: %s", target, strings.Join(errs, ", "))
+		}
+	}
+	if _, err := parseCron(schedule.Cron); err != nil {
+		return err
+	}
+	if _, err := time.LoadLocation(schedule.Timezone); err != nil {
+		return fmt.Errorf("invalid timezone %s: %w", schedule.Timezone, err)
+	}
+	return nil
+}
+
+// withNextRun returns the schedule with the time of its next run, none while it is disabled
+func withNextRun(schedule types.Schedule, now time.Time) types.Schedule {
+	schedule.NextRun = nil
+	if schedule.Disabled {
+		return schedule
+	}
+	expr, err := parseCron(schedule.Cron)
+	if err != nil {
+		return schedule
+	}
+	location, err := time.LoadLocation(schedule.Timezone)
+	if err != nil {
+		return schedule
+	}
+	if next := expr.next(now.In(location)); !next.IsZero() {
+		next = next.UTC()
+		schedule.NextRun = &next
+	}
+	return schedule
+}
+
+// PutSchedule stores a schedule, replacing the schedule with the same name but keeping the record of its last run
+func (m *ScheduleManager) PutSchedule(schedule types.Schedule) error {
+	existing, err := m.GetSchedule(schedule.Namespace, schedule.Name)
+	if err != nil {
+		return err
+	}
+	schedule.LastRun, schedule.LastResult, schedule.NextRun = nil, "", nil
+	if existing != nil {
+		schedule.LastRun, schedule.LastResult = existing.LastRun, existing.LastResult
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	if _, err := m.etcdClient.Put(ctx, m.etcdKey(schedule.Namespace, schedule.Name), string(data)); err != nil {
+		return fmt.Errorf("failed to store schedule: %w", err)
+	}
+	return nil
+}
+
+// GetSchedule returns a schedule with its next run, nil when it does not exist
+func (m *ScheduleManager) GetSchedule(namespace, name string) (*types.Schedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(namespace, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schedule: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	var schedule types.Schedule
+	if err := json.Unmarshal(resp.Kvs[0].Value, &schedule); err != nil {
+		return nil, fmt.Errorf("failed to parse schedule: %w", err)
+	}
+	schedule = withNextRun(schedule, time.Now())
+	return &schedule, nil
+}
+
+// ListSchedules returns the schedules of a namespace with their next runs, of all namespaces when it is empty
+func (m *ScheduleManager) ListSchedules(namespace string) ([]types.Schedule, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(namespace, ""), clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list schedules: %w", err)
+	}
+	now := time.Now()
+	schedules := make([]types.Schedule, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var schedule types.Schedule
+		if err := json.Unmarshal(kv.Value, &schedule); err != nil {
+			return nil, fmt.Errorf("failed to parse schedule %s: %w", kv.Key, err)
+		}
+		schedules = append(schedules, withNextRun(schedule, now))
+	}
+	return schedules, nil
+}
+
+// errScheduleNotFound is returned when deleting a schedule that does not exist
+var errScheduleNotFound = errors.New("schedule not found")
+
+// DeleteSchedule removes a schedule, errScheduleNotFound when there is none
+func (m *ScheduleManager) DeleteSchedule(namespace, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Delete(ctx, m.etcdKey(namespace, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete schedule: %w", err)
+	}
+	if resp.Deleted == 0 {
+		return fmt.Errorf("%w: %s", errScheduleNotFound, name)
+	}
+	return nil
+}
+
+// Run runs the due schedules at the start of every minute until ctx is done
+func (m *ScheduleManager) Run(ctx context.Context) {
+	m.logger.Info("starting scheduler", "interval", scheduleInterval)
+	for {
+		now := time.Now()
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(now.Truncate(scheduleInterval).Add(scheduleInterval).Sub(now)):
+		}
+		if err := m.runDue(time.Now().Truncate(scheduleInterval)); err != nil {
+			m.logger.Error("failed to run schedules", "error", err)
+		}
+	}
+}
+
+// runDue runs the schedules matching minute that did not run in it yet. A schedule is claimed by recording
+// its run only if it was not changed since it was read, so several servers sharing etcd run it once.
+func (m *ScheduleManager) runDue(minute time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey("", ""), clientv3.WithPrefix())
+	cancel()
+	if err != nil {
+		return fmt.Errorf("failed to list schedules: %w", err)
+	}
+	for _, kv := range resp.Kvs {
+		var schedule types.Schedule
+		if err := json.Unmarshal(kv.Value, &schedule); err != nil {
+			m.logger.Warn("failed to parse schedule", "key", string(kv.Key), "error", err)
+			continue
+		}
+		if schedule.Disabled || (schedule.LastRun != nil && !schedule.LastRun.Before(minute)) {
+			continue
+		}
+		expr, err := parseCron(schedule.Cron)
+		if err != nil {
+			m.logger.Warn("invalid schedule", "name", schedule.Name, "namespace", schedule.Namespace, "error", err)
+			continue
+		}
+		location, err := time.LoadLocation(schedule.Timezone)
+		if err != nil || !expr.matches(minute.In(location)) {
+			continue
+		}
+		lastRun := minute.UTC()
+		schedule.LastRun = &lastRun
+		revision, claimed, err := m.update(string(kv.Key), kv.ModRevision, schedule)
+		if err != nil || !claimed {
+			continue
+		}
+		schedule.LastResult = m.runSchedule(schedule)
+		if _, _, err := m.update(string(kv.Key), revision, schedule); err != nil {
+			m.logger.Warn("failed to record schedule result", "name", schedule.Name, "namespace", schedule.Namespace, "error", err)
+		}
+	}
+	return nil
+}
+
+// update stores a schedule if its key is still at revision, returning its new revision
+func (m *ScheduleManager) update(key string, revision int64, schedule types.Schedule) (int64, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(schedule)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to marshal schedule: %w", err)
+	}
+	resp, err := m.etcdClient.Txn(ctx).
+		If(clientv3.Compare(clientv3.ModRevision(key), "=", revision)).
+		Then(clientv3.OpPut(key, string(data))).
+		Commit()
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to store schedule: %w", err)
+	}
+	return resp.Header.Revision, resp.Succeeded, nil
+}
+
+// scheduleTargets returns the names of the VMs or containers a schedule acts on
+func scheduleTargets(schedule types.Schedule, logger *slog.Logger) ([]string, error) {
+	all := false
+	for _, target := range schedule.Targets {
+		all = all || target == types.ScheduleAllTargets
+	}
+	if !all {
+		return schedule.Targets, nil
+	}
+	var names []string
+	if schedule.Kind == types.ScheduleKindVM {
+		var list types.GovnoVMList
+		if err := listObjects(vmManager.withLogger(logger).kubectl, govnoVMResource, schedule.Namespace, &list); err != nil {
+			return nil, err
+		}
+		for _, vm := range list.Items {
+			names = append(names, vm.Name)
+		}
+		return names, nil
+	}
+	containers, err := containerManager.withLogger(logger).ListContainers(schedule.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for _, container := range containers {
+		names = append(names, container.Name)
+	}
+	return names, nil
+}
+
+// runSchedule runs the action of a schedule on its targets, returning ok or the errors
+func (m *ScheduleManager) runSchedule(schedule types.Schedule) string {
+	logger := m.logger.With("schedule", schedule.Name, "namespace", schedule.Namespace)
+	logger.Info("running schedule", "kind", schedule.Kind, "action", schedule.Action, "targets", schedule.Targets)
+	targets, err := scheduleTargets(schedule, logger)
+	if err != nil {
+		logger.Error("failed to resolve schedule targets", "error", err)
+		return err.Error()
+	}
+	vms, containers := vmManager.withLogger(logger), containerManager.withLogger(logger)
+	var errs []string
+	for _, target := range targets {
+		var err error
+		switch schedule.Kind + "/" + schedule.Action {
+		case types.ScheduleKindVM + "/" + types.ScheduleActionStart:
+			err = vms.StartVM(target, schedule.Namespace)
+		case types.ScheduleKindVM + "/" + types.ScheduleActionStop:
+			err = vms.StopVM(target, schedule.Namespace)
+		case types.ScheduleKindVM + "/" + types.ScheduleActionRestart:
+			err = vms.RestartVM(target, schedule.Namespace)
+		case types.ScheduleKindContainer + "/" + types.ScheduleActionStart:
+			err = containers.StartContainer(target, schedule.Namespace)
+		case types.ScheduleKindContainer + "/" + types.ScheduleActionStop:
+			err = containers.StopContainer(target, schedule.Namespace)
+		case types.ScheduleKindContainer + "/" + types.ScheduleActionRestart:
+			err = containers.RestartContainer(target, schedule.Namespace)
+		}
+		if err != nil {
+			logger.Warn("schedule action failed", "target", target, "error", err)
+			errs = append(errs, fmt.Sprintf("%s: %v", target, err))
+		}
+	}
+	if len(errs) > 0 {
+		return strings.Join(errs, "; ")
+	}
+	return "ok"
+}
+
+// scheduleRequest checks the auth and namespace access of a schedule request, responding with an error when denied
+func scheduleRequest(c *gin.Context) (string, bool) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return "", false
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return "", false
+	}
+	namespace := c.Param("namespace")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return "", false
+	}
+	return namespace, true
+}
+
+// ListSchedulesHandler handles requests to list the schedules of a namespace
+func ListSchedulesHandler(c *gin.Context) {
+	namespace, ok := scheduleRequest(c)
+	if !ok {
+		return
+	}
+	schedules, err := scheduleManager.forRequest(c).ListSchedules(namespace)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list schedules: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, schedules)
+}
+
+// GetScheduleHandler handles schedule retrieval requests
+func GetScheduleHandler(c *gin.Context) {
+	namespace, ok := scheduleRequest(c)
+	if !ok {
+		return
+	}
+	schedule, err := scheduleManager.forRequest(c).GetSchedule(namespace, c.Param("name"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get schedule: %v", err))
+		return
+	}
+	if schedule == nil {
+		respondWithError(c, http.StatusNotFound, "schedule not found")
+		return
+	}
+	c.JSON(http.StatusOK, schedule)
+}
+
+// PutScheduleHandler handles requests to create or replace a schedule
+func PutScheduleHandler(c *gin.Context) {
+	namespace, ok := scheduleRequest(c)
+	if !ok {
+		return
+	}
+	var schedule types.Schedule
+	if err := c.BindJSON(&schedule); err != nil {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	schedule.Name, schedule.Namespace = c.Param("name"), namespace
+	if err := validateSchedule(schedule); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := scheduleManager.forRequest(c).PutSchedule(schedule); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to store schedule: %v", err))
+		return
+	}
+	requestLogger(c).Info("schedule stored", "name", schedule.Name, "namespace", namespace)
+	respondWithSuccess(c, gin.H{"message": "schedule stored successfully"})
+}
+
+// DeleteScheduleHandler handles schedule deletion requests
+func DeleteScheduleHandler(c *gin.Context) {
+	namespace, ok := scheduleRequest(c)
+	if !ok {
+		return
+	}
+	name := c.Param("name")
+	if err := scheduleManager.forRequest(c).DeleteSchedule(namespace, name); err != nil {
+		if errors.Is(err, errScheduleNotFound) {
+			respondWithError(c, http.StatusNotFound, "schedule not found")
+			return
+		}
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete schedule: %v", err))
+		return
+	}
+	requestLogger(c).Info("schedule deleted", "name", name, "namespace", namespace)
+	respondWithSuccess(c, gin.H{"message": "schedule deleted successfully"})
+}
@@ -1,27 +1,35 @@
 package server
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
+	"regexp"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/logging"
 	"github.com/rusik69/govnocloud2/pkg/types"
 	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
 )
 
 // Server represents the HTTP server
 type Server struct {
-	config  types.ServerConfig
-	router  *gin.Engine
-	limiter *rate.Limiter
+	config     types.ServerConfig
+	router     *gin.Engine
+	limiter    *rate.Limiter
+	grpcServer *grpc.Server
 }
 
 var server *Server
 
+// allowedOrigins are the web UI origins allowed to call the API from a browser
+var allowedOrigins = []string{"http://localhost:8080", "http://127.0.0.1:8080", "http://master.govno2.cloud:8080"}
+
 var vmManager *VMManager
 var containerManager *ContainerManager
 var volumeManager *VolumeManager
@@ -32,6 +40,14 @@ var mysqlManager *MysqlManager
 var clickhouseManager *ClickhouseManager
 var llmManager *LLMManager
 var userManager *UserManager
+var idempotencyManager *IdempotencyManager
+var desiredStateManager *DesiredStateManager
+var sshKeyManager *SSHKeyManager
+var imageManager *ImageManager
+var sizeManager *SizeManager
+var scheduleManager *ScheduleManager
+var vmTemplateManager *VMTemplateManager
+var driftDetector *DriftDetector
 
 // NewServer creates a new server instance
 func NewServer(config types.ServerConfig) *Server {
@@ -57,17 +73,25 @@ func NewServer(config types.ServerConfig) *Server {
 	clickhouseManager = NewClickhouseManager()
 	llmManager = NewLLMManager()
 	userManager = NewUserManager()
+	idempotencyManager = NewIdempotencyManager(userManager.etcdClient)
+	desiredStateManager = NewDesiredStateManager(userManager.etcdClient)
+	sshKeyManager = NewSSHKeyManager(userManager.etcdClient)
+	imageManager = NewImageManager(userManager.etcdClient, config.ImagesDir, config.MaxImageSize)
+	sizeManager = NewSizeManager(userManager.etcdClient)
+	scheduleManager = NewScheduleManager(userManager.etcdClient)
+	vmTemplateManager = NewVMTemplateManager(userManager.etcdClient)
 
 	// Configure CORS with more restrictive settings
 	corsConfig := cors.DefaultConfig()
-	corsConfig.AllowOrigins = []string{"http://localhost:8080", "http://127.0.0.1:8080", "http://master.govno2.cloud:8080"}
+	corsConfig.AllowOrigins = allowedOrigins
 	corsConfig.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "Accept"}
-	corsConfig.ExposeHeaders = []string{"Content-Length"}
+	corsConfig.AllowHeaders = []string{"Origin", "Content-Type", "Content-Length", "Accept-Encoding", "X-CSRF-Token", "Authorization", "Accept", IdempotencyKeyHeader, RequestIDHeader}
+	corsConfig.ExposeHeaders = []string{"Content-Length", IdempotentReplayedHeader, RequestIDHeader}
 	corsConfig.AllowCredentials = true
 	corsConfig.MaxAge = 12 * time.Hour
 	router.Use(cors.New(corsConfig))
 
+	router.Use(RequestIDMiddleware())
 	router.Use(LoggingMiddleware())
 	router.Use(ErrorMiddleware())
 
@@ -88,6 +112,7 @@ func (s *Server) setupRoutes() {
 
 		// Protected endpoints (require authentication)
 		protected := v0.Group("")
+		protected.Use(IdempotencyMiddleware())
 		{
 			// VM endpoints
 			vms := protected.Group("/vms")
@@ -100,6 +125,27 @@ func (s *Server) setupRoutes() {
 				vms.GET("/:namespace/:name/stop", StopVMHandler)
 				vms.GET("/:namespace/:name/restart", RestartVMHandler)
 				vms.GET("/:namespace/:name/wait", WaitVMHandler)
+				vms.GET("/:namespace/:name/metrics", GetVMMetricsHandler)
+				vms.GET("/:namespace/:name/ports", GetVMPortsHandler)
+				vms.PUT("/:namespace/:name/ports", SetVMPortsHandler)
+				vms.PUT("/:namespace/:name/size", ResizeVMHandler)
+				vms.PUT("/:namespace/:name/run", SetVMRunPolicyHandler)
+				vms.POST("/:namespace/:name/volumes/:volume", AttachVMVolumeHandler)
+				vms.DELETE("/:namespace/:name/volumes/:volume", DetachVMVolumeHandler)
+				vms.GET("/:namespace/:name/snapshots", ListVMSnapshotsHandler)
+				vms.POST("/:namespace/:name/snapshots/:snapshot", CreateVMSnapshotHandler)
+				vms.GET("/:namespace/:name/snapshots/:snapshot", GetVMSnapshotHandler)
+				vms.DELETE("/:namespace/:name/snapshots/:snapshot", DeleteVMSnapshotHandler)
+				vms.POST("/:namespace/:name/snapshots/:snapshot/restore", RestoreVMSnapshotHandler)
+				vms.POST("/:namespace/:name/migrate", MigrateVMHandler)
+				vms.GET("/:namespace/:name/migrations", ListVMMigrationsHandler)
+				vms.GET("/:namespace/:name/migrations/:migration", GetVMMigrationHandler)
+				vms.POST("/:namespace/:name/clone/:target", CloneVMHandler)
+				vms.POST("/:namespace/:name/image/:image", ExportVMImageHandler)
+				vms.GET("/:namespace/:name/export", ExportVMHandler)
+				vms.POST("/:namespace/:name/import", ImportVMHandler)
+				vms.GET("/:namespace/:name/console", VMConsoleHandler)
+				vms.GET("/:namespace/:name/vnc", VMVNCHandler)
 			}
 
 			// Node endpoints
@@ -141,6 +187,9 @@ func (s *Server) setupRoutes() {
 				containers.POST("/:namespace/:name", CreateContainerHandler)
 				containers.GET("/:namespace/:name", GetContainerHandler)
 				containers.DELETE("/:namespace/:name", DeleteContainerHandler)
+				containers.GET("/:namespace/:name/start", StartContainerHandler)
+				containers.GET("/:namespace/:name/stop", StopContainerHandler)
+				containers.GET("/:namespace/:name/restart", RestartContainerHandler)
 			}
 			volumes := protected.Group("/volumes")
 			{
@@ -163,6 +212,53 @@ func (s *Server) setupRoutes() {
 				namespaces.GET("/:name", GetNamespaceHandler)
 				namespaces.DELETE("/:name", DeleteNamespaceHandler)
 			}
+			sshkeys := protected.Group("/sshkeys")
+			{
+				sshkeys.GET("", ListSSHKeysHandler)
+				sshkeys.POST("/:name", AddSSHKeyHandler)
+				sshkeys.GET("/:name", GetSSHKeyHandler)
+				sshkeys.DELETE("/:name", DeleteSSHKeyHandler)
+			}
+			images := protected.Group("/images")
+			{
+				images.GET("", ListImagesHandler)
+				images.POST("/:name", CreateImageHandler)
+				images.POST("/:name/upload", UploadImageHandler)
+				images.GET("/:name", GetImageHandler)
+				images.DELETE("/:name", DeleteImageHandler)
+			}
+			sizes := protected.Group("/sizes")
+			{
+				sizes.GET("/:kind", ListSizesHandler)
+				sizes.GET("/:kind/:name", GetSizeHandler)
+				sizes.PUT("/:kind/:name", PutSizeHandler)
+				sizes.DELETE("/:kind/:name", DeleteSizeHandler)
+			}
+			schedules := protected.Group("/schedules")
+			{
+				schedules.GET("/:namespace", ListSchedulesHandler)
+				schedules.GET("/:namespace/:name", GetScheduleHandler)
+				schedules.PUT("/:namespace/:name", PutScheduleHandler)
+				schedules.DELETE("/:namespace/:name", DeleteScheduleHandler)
+			}
+			vmtemplates := protected.Group("/vmtemplates")
+			{
+				vmtemplates.GET("/:namespace", ListVMTemplatesHandler)
+				vmtemplates.GET("/:namespace/:name", GetVMTemplateHandler)
+				vmtemplates.PUT("/:namespace/:name", PutVMTemplateHandler)
+				vmtemplates.DELETE("/:namespace/:name", DeleteVMTemplateHandler)
+				vmtemplates.POST("/:namespace/:name/vms", CreateVMBatchHandler)
+			}
+			vmpools := protected.Group("/vmpools")
+			{
+				vmpools.GET("/:namespace", ListVMPoolsHandler)
+				vmpools.DELETE("/:namespace/:name", DeleteVMPoolHandler)
+			}
+			drift := protected.Group("/drift")
+			{
+				drift.GET("", GetDriftHandler)
+				drift.POST("/heal", HealDriftHandler)
+			}
 			users := protected.Group("/users")
 			{
 				users.GET("", ListUsersHandler)
@@ -181,8 +277,45 @@ func (s *Server) setupRoutes() {
 func (s *Server) Start() error {
 	s.setupRoutes()
 
+	if err := EnsureCRDs(vmManager.kubectl); err != nil {
+		slog.Error("failed to install custom resource definitions", "error", err)
+	}
+	if err := ensureRootDiskStorageClass(vmManager.kubectl); err != nil {
+		slog.Error("failed to ensure root disk storage class", "error", err)
+	}
+	if err := imageManager.SeedDefaults(); err != nil {
+		slog.Error("failed to seed image catalog", "error", err)
+	}
+	if err := sizeManager.SeedDefaults(); err != nil {
+		slog.Error("failed to seed size catalog", "error", err)
+	}
+	if err := sizeManager.SyncInstancetypes(); err != nil {
+		slog.Error("failed to sync instancetypes", "error", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	controller := NewController()
+	driftDetector = NewDriftDetector(controller, s.config.AutoHeal)
+	go controller.Run(ctx)
+	go driftDetector.Run(ctx)
+	go scheduleManager.Run(ctx)
+	go NewIdleMonitor().Run(ctx)
+
+	if s.config.GRPCPort != "" {
+		if err := s.startGRPC(); err != nil {
+			return fmt.Errorf("failed to start grpc server: %w", err)
+		}
+		defer s.grpcServer.GracefulStop()
+	}
+
+	if s.config.GatewayPort != "" {
+		if err := s.startSSHGateway(); err != nil {
+			return fmt.Errorf("failed to start ssh gateway: %w", err)
+		}
+	}
+
 	addr := fmt.Sprintf("%s:%s", s.config.Host, s.config.Port)
-	log.Printf("Starting server on %s", addr)
+	slog.Info("starting server", "addr", addr)
 
 	if err := s.router.Run(addr); err != nil {
 		return fmt.Errorf("failed to start server: %w", err)
@@ -197,7 +330,8 @@ func Serve(serverConfig types.ServerConfig) {
 	defer userManager.etcdClient.Close()
 
 	if err := server.Start(); err != nil {
-		log.Fatalf("Server failed: %v", err)
+		slog.Error("server failed", "error", err)
+		os.Exit(1)
 	}
 }
 
@@ -210,7 +344,7 @@ type APIResponse struct {
 
 // respondWithError sends an error response
 func respondWithError(c *gin.Context, code int, message string) {
-	log.Printf("responding with error: %s", message)
+	requestLogger(c).Warn("responding with error", "status", code, "error", message)
 	c.JSON(code, APIResponse{
 		Success: false,
 		Error:   message,
@@ -228,6 +362,32 @@ func respondWithSuccess(c *gin.Context, data interface{}) {
 // MiddlewareFunc is an alias for gin.HandlerFunc for better readability
 type MiddlewareFunc = gin.HandlerFunc
 
+// RequestIDHeader carries the request ID between clients, the server and its logs
+const RequestIDHeader = "X-Request-ID"
+
+// validRequestID limits client supplied request IDs to something safe to log
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestIDMiddleware assigns every request an ID and a logger tagged with it
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !validRequestID.MatchString(requestID) {
+			requestID = logging.NewRequestID()
+		}
+		c.Header(RequestIDHeader, requestID)
+
+		logger := slog.Default().With("request_id", requestID)
+		c.Request = c.Request.WithContext(logging.WithLogger(c.Request.Context(), logger))
+		c.Next()
+	}
+}
+
+// requestLogger returns the logger of the current request
+func requestLogger(c *gin.Context) *slog.Logger {
+	return logging.FromContext(c.Request.Context())
+}
+
 // LoggingMiddleware creates a middleware for request logging
 func LoggingMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -238,12 +398,12 @@ func LoggingMiddleware() gin.HandlerFunc {
 		c.Next()
 
 		// Log request details
-		log.Printf(
-			"%s %s %d %s",
-			c.Request.Method,
-			c.Request.URL.Path,
-			c.Writer.Status(),
-			time.Since(start),
+		requestLogger(c).Info("request",
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"status", c.Writer.Status(),
+			"duration", time.Since(start).String(),
+			"client_ip", c.ClientIP(),
 		)
 	}
 }
@@ -253,7 +413,7 @@ func ErrorMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				log.Printf("Panic recovered: %v", err)
+				requestLogger(c).Error("panic recovered", "panic", err)
 				respondWithError(c, http.StatusInternalServerError, "Internal server error")
 			}
 		}()
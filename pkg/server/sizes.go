@@ -0,0 +1,575 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// sizesSeededKey marks the size catalog as seeded with the default sizes
+const sizesSeededKey = "/sizes-seeded"
+
+// Kinds of sizes in the catalog, also the path segment of their endpoints
+const (
+	sizeKindVM       = "vms"
+	sizeKindPostgres = "postgres"
+)
+
+// instancetypeResource is the KubeVirt VirtualMachineClusterInstancetype resource as understood by kubectl
+const instancetypeResource = "virtualmachineclusterinstancetypes.instancetype.kubevirt.io"
+
+// sizeLabel labels the instancetypes synced from the size catalog
+const sizeLabel = "govnocloud.io/size"
+
+// minPostgresRAM is the least memory in MiB a postgres size can have
+const minPostgresRAM = 256
+
+// SizeManager stores the catalog of VM and postgres sizes in etcd and syncs VM sizes to KubeVirt instancetypes
+type SizeManager struct {
+	etcdClient *clientv3.Client
+	kubectl    KubectlRunner
+	logger     *slog.Logger
+}
+
+// NewSizeManager creates a new size manager
+func NewSizeManager(etcdClient *clientv3.Client) *SizeManager {
+	return &SizeManager{
+		etcdClient: etcdClient,
+		kubectl:    &DefaultKubectlRunner{},
+		logger:     slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *SizeManager) forRequest(c *gin.Context) *SizeManager {
+	logger := requestLogger(c)
+	return &SizeManager{
+		etcdClient: m.etcdClient,
+		kubectl:    kubectlWithLogger(m.kubectl, logger),
+		logger:     logger,
+	}
+}
+
+// etcdKey returns the etcd key of a size of a kind
+func (m *SizeManager) etcdKey(kind, name string) string {
+	return "/sizes/" + kind + "/" + name
+}
+
+// SeedDefaults adds the default sizes to an empty catalog, sizes that were changed or deleted stay so
+func (m *SizeManager) SeedDefaults() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, sizesSeededKey)
+	if err != nil {
+		return fmt.Errorf("failed to check size catalog: %w", err)
+	}
+	if len(resp.Kvs) > 0 {
+		return nil
+	}
+	ops := []clientv3.Op{clientv3.OpPut(sizesSeededKey, "true")}
+	for name, size := range types.DefaultVMSizes {
+		data, err := json.Marshal(size)
+		if err != nil {
+			return fmt.Errorf("failed to marshal size %s: %w", name, err)
+		}
+		ops = append(ops, clientv3.OpPut(m.etcdKey(sizeKindVM, name), string(data)))
+	}
+	for name, size := range types.DefaultPostgresSizes {
+		data, err := json.Marshal(size)
+		if err != nil {
+			return fmt.Errorf("failed to marshal postgres size %s: %w", name, err)
+		}
+		ops = append(ops, clientv3.OpPut(m.etcdKey(sizeKindPostgres, name), string(data)))
+	}
+	if _, err := m.etcdClient.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(sizesSeededKey), "=", 0)).
+		Then(ops...).
+		Commit(); err != nil {
+		return fmt.Errorf("failed to seed size catalog: %w", err)
+	}
+	return nil
+}
+
+// put stores a size of a kind, replacing the size with the same name
+func (m *SizeManager) put(kind, name string, size any) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(size)
+	if err != nil {
+		return fmt.Errorf("failed to marshal size: %w", err)
+	}
+	if _, err := m.etcdClient.Put(ctx, m.etcdKey(kind, name), string(data)); err != nil {
+		return fmt.Errorf("failed to store size: %w", err)
+	}
+	return nil
+}
+
+// get reads a size of a kind into size, reporting whether it exists
+func (m *SizeManager) get(kind, name string, size any) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(kind, name))
+	if err != nil {
+		return false, fmt.Errorf("failed to get size: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(resp.Kvs[0].Value, size); err != nil {
+		return false, fmt.Errorf("failed to parse size: %w", err)
+	}
+	return true, nil
+}
+
+// list returns the stored sizes of a kind
+func (m *SizeManager) list(kind string) ([][]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(kind, ""), clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sizes: %w", err)
+	}
+	values := make([][]byte, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		values = append(values, kv.Value)
+	}
+	return values, nil
+}
+
+// delete removes a size of a kind
+func (m *SizeManager) delete(kind, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Delete(ctx, m.etcdKey(kind, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete size: %w", err)
+	}
+	if resp.Deleted == 0 {
+		return fmt.Errorf("size %s not found", name)
+	}
+	return nil
+}
+
+// GetVMSize returns a VM size, nil when it does not exist
+func (m *SizeManager) GetVMSize(name string) (*types.VMSize, error) {
+	var size types.VMSize
+	found, err := m.get(sizeKindVM, name, &size)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &size, nil
+}
+
+// ListVMSizes returns the VM sizes of the catalog
+func (m *SizeManager) ListVMSizes() ([]types.VMSize, error) {
+	values, err := m.list(sizeKindVM)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make([]types.VMSize, 0, len(values))
+	for _, value := range values {
+		var size types.VMSize
+		if err := json.Unmarshal(value, &size); err != nil {
+			return nil, fmt.Errorf("failed to parse size: %w", err)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// PutVMSize defines or edits a VM size and syncs its instancetype. KubeVirt keeps the instancetype revision a VM
+// was started with, so running VMs pick up an edited size on their next restart.
+func (m *SizeManager) PutVMSize(size types.VMSize) error {
+	if err := m.put(sizeKindVM, size.Name, size); err != nil {
+		return err
+	}
+	return applyInstancetype(m.kubectl, size)
+}
+
+// DeleteVMSize removes a VM size no VM uses and its instancetype
+func (m *SizeManager) DeleteVMSize(name string) error {
+	var vms types.GovnoVMList
+	if err := listObjects(m.kubectl, govnoVMResource, "", &vms); err != nil {
+		return err
+	}
+	for _, vm := range vms.Items {
+		if vm.Spec.Size == name {
+			return fmt.Errorf("size %s is used by VM %s/%s, retire it instead", name, vm.Namespace, vm.Name)
+		}
+	}
+	if err := m.delete(sizeKindVM, name); err != nil {
+		return err
+	}
+	if out, err := m.kubectl.Run("delete", instancetypeResource, name, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete instancetype %s: %s %w", name, out, err)
+	}
+	return nil
+}
+
+// GetPostgresSize returns a postgres size, nil when it does not exist
+func (m *SizeManager) GetPostgresSize(name string) (*types.PostgresSize, error) {
+	var size types.PostgresSize
+	found, err := m.get(sizeKindPostgres, name, &size)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &size, nil
+}
+
+// ListPostgresSizes returns the postgres sizes of the catalog
+func (m *SizeManager) ListPostgresSizes() ([]types.PostgresSize, error) {
+	values, err := m.list(sizeKindPostgres)
+	if err != nil {
+		return nil, err
+	}
+	sizes := make([]types.PostgresSize, 0, len(values))
+	for _, value := range values {
+		var size types.PostgresSize
+		if err := json.Unmarshal(value, &size); err != nil {
+			return nil, fmt.Errorf("failed to parse size: %w", err)
+		}
+		sizes = append(sizes, size)
+	}
+	return sizes, nil
+}
+
+// PutPostgresSize defines or edits a postgres size, the controller resizes the clusters using it
+func (m *SizeManager) PutPostgresSize(size types.PostgresSize) error {
+	return m.put(sizeKindPostgres, size.Name, size)
+}
+
+// DeletePostgresSize removes a postgres size no database uses
+func (m *SizeManager) DeletePostgresSize(name string) error {
+	databases, err := listDatabases(m.kubectl, "", types.DatabaseEnginePostgres)
+	if err != nil {
+		return err
+	}
+	for _, db := range databases {
+		if db.Spec.Size == name {
+			return fmt.Errorf("size %s is used by postgres %s/%s, retire it instead", name, db.Namespace, db.Name)
+		}
+	}
+	return m.delete(sizeKindPostgres, name)
+}
+
+// instancetypeManifest generates the VirtualMachineClusterInstancetype of a VM size
+func instancetypeManifest(size types.VMSize) (string, error) {
+	manifest, err := json.Marshal(map[string]any{
+		"apiVersion": "instancetype.kubevirt.io/v1beta1",
+		"kind":       "VirtualMachineClusterInstancetype",
+		"metadata": map[string]any{
+			"name":   size.Name,
+			"labels": map[string]string{sizeLabel: size.Name},
+		},
+		"spec": map[string]any{
+			"cpu":    map[string]any{"guest": size.CPU},
+			"memory": map[string]any{"guest": fmt.Sprintf("%dMi", size.RAM)},
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal instancetype: %w", err)
+	}
+	return string(manifest), nil
+}
+
+// applyInstancetype creates or updates the instancetype of a VM size
+func applyInstancetype(kubectl KubectlRunner, size types.VMSize) error {
+	manifest, err := instancetypeManifest(size)
+	if err != nil {
+		return err
+	}
+	if out, err := applyManifest(kubectl, manifest); err != nil {
+		return fmt.Errorf("failed to apply instancetype %s: %s %w", size.Name, out, err)
+	}
+	return nil
+}
+
+// ensureInstancetype creates the instancetype of a VM size when it is missing, as when the server started before KubeVirt
+func ensureInstancetype(kubectl KubectlRunner, name string) error {
+	out, err := kubectl.Run("get", instancetypeResource, name, "-o", "name", "--ignore-not-found")
+	if err != nil {
+		return fmt.Errorf("failed to get instancetype %s: %s %w", name, out, err)
+	}
+	if len(out) > 0 {
+		return nil
+	}
+	size, err := lookupVMSize(name)
+	if err != nil {
+		return err
+	}
+	return applyInstancetype(kubectl, *size)
+}
+
+// SyncInstancetypes makes the synced instancetypes match the VM sizes of the catalog
+func (m *SizeManager) SyncInstancetypes() error {
+	sizes, err := m.ListVMSizes()
+	if err != nil {
+		return err
+	}
+	names := make(map[string]bool, len(sizes))
+	for _, size := range sizes {
+		names[size.Name] = true
+		if err := applyInstancetype(m.kubectl, size); err != nil {
+			return err
+		}
+	}
+	out, err := m.kubectl.Run("get", instancetypeResource, "-l", sizeLabel, "-o", "jsonpath={.items[*].metadata.name}")
+	if err != nil {
+		return fmt.Errorf("failed to list instancetypes: %s %w", out, err)
+	}
+	for _, name := range strings.Fields(string(out)) {
+		if names[name] {
+			continue
+		}
+		m.logger.Info("deleting instancetype of a removed size", "name", name)
+		if out, err := m.kubectl.Run("delete", instancetypeResource, name, "--ignore-not-found"); err != nil {
+			return fmt.Errorf("failed to delete instancetype %s: %s %w", name, out, err)
+		}
+	}
+	return nil
+}
+
+// lookupVMSize returns a VM size of the catalog, retired or not
+func lookupVMSize(name string) (*types.VMSize, error) {
+	size, err := sizeManager.GetVMSize(name)
+	if err != nil {
+		return nil, err
+	}
+	if size == nil {
+		return nil, fmt.Errorf("invalid VM size: %s", name)
+	}
+	return size, nil
+}
+
+// validateNewVMSize checks that a size can be picked for a new VM or a resize
+func validateNewVMSize(name string) error {
+	size, err := lookupVMSize(name)
+	if err != nil {
+		return err
+	}
+	if size.Retired {
+		return fmt.Errorf("VM size %s is retired", name)
+	}
+	return nil
+}
+
+// lookupPostgresSize returns a postgres size of the catalog, retired or not
+func lookupPostgresSize(name string) (*types.PostgresSize, error) {
+	size, err := sizeManager.GetPostgresSize(name)
+	if err != nil {
+		return nil, err
+	}
+	if size == nil {
+		return nil, fmt.Errorf("invalid database size: %s", name)
+	}
+	return size, nil
+}
+
+// validateSizeName checks that a size name can name an instancetype and a label value
+func validateSizeName(name string) error {
+	if name == types.VMSizeCustom {
+		return fmt.Errorf("size name %s is reserved", name)
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("invalid size name %s: %s", name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// validateVMSize checks the values of a VM size
+func validateVMSize(size types.VMSize) error {
+	if err := validateSizeName(size.Name); err != nil {
+		return err
+	}
+	if size.CPU < 1 || size.CPU > maxCustomCPU {
+		return fmt.Errorf("invalid cpu %d, must be between 1 and %d", size.CPU, maxCustomCPU)
+	}
+	if size.RAM < minCustomRAM || size.RAM > maxCustomRAM {
+		return fmt.Errorf("invalid ram %dMi, must be between %dMi and %dMi", size.RAM, minCustomRAM, maxCustomRAM)
+	}
+	if size.Disk < 1 {
+		return fmt.Errorf("invalid disk %dGi", size.Disk)
+	}
+	return nil
+}
+
+// validatePostgresSize checks the values of a postgres size
+func validatePostgresSize(size types.PostgresSize) error {
+	if err := validateSizeName(size.Name); err != nil {
+		return err
+	}
+	if size.CPU < 1 {
+		return fmt.Errorf("invalid cpu %d", size.CPU)
+	}
+	if size.RAM < minPostgresRAM {
+		return fmt.Errorf("invalid ram %dMi, must be at least %dMi", size.RAM, minPostgresRAM)
+	}
+	return nil
+}
+
+// sizeKind returns the kind of sizes a request is for, responding with an error when it is unknown
+func sizeKind(c *gin.Context) (string, bool) {
+	kind := c.Param("kind")
+	if kind != sizeKindVM && kind != sizeKindPostgres {
+		respondWithError(c, http.StatusNotFound, fmt.Sprintf("unknown size kind %s, must be %s or %s", kind, sizeKindVM, sizeKindPostgres))
+		return "", false
+	}
+	return kind, true
+}
+
+// ListSizesHandler handles size listing requests, any user can list sizes
+func ListSizesHandler(c *gin.Context) {
+	auth, _, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	kind, ok := sizeKind(c)
+	if !ok {
+		return
+	}
+	var sizes any
+	if kind == sizeKindVM {
+		sizes, err = sizeManager.ListVMSizes()
+	} else {
+		sizes, err = sizeManager.ListPostgresSizes()
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list sizes: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, sizes)
+}
+
+// GetSizeHandler handles size retrieval requests
+func GetSizeHandler(c *gin.Context) {
+	auth, _, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	kind, ok := sizeKind(c)
+	if !ok {
+		return
+	}
+	name := c.Param("name")
+	var size any
+	if kind == sizeKindVM {
+		size, err = lookupVMSize(name)
+	} else {
+		size, err = lookupPostgresSize(name)
+	}
+	if err != nil {
+		respondWithError(c, http.StatusNotFound, err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, size)
+}
+
+// PutSizeHandler handles requests to define or edit a size, admins only
+func PutSizeHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if !CheckAdminAccess(username) {
+		respondWithError(c, http.StatusForbidden, "user does not have admin access")
+		return
+	}
+	kind, ok := sizeKind(c)
+	if !ok {
+		return
+	}
+	name := c.Param("name")
+	if kind == sizeKindVM {
+		var size types.VMSize
+		if err := c.BindJSON(&size); err != nil {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+			return
+		}
+		size.Name = name
+		if err := validateVMSize(size); err != nil {
+			respondWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		err = sizeManager.forRequest(c).PutVMSize(size)
+	} else {
+		var size types.PostgresSize
+		if err := c.BindJSON(&size); err != nil {
+			respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+			return
+		}
+		size.Name = name
+		if err := validatePostgresSize(size); err != nil {
+			respondWithError(c, http.StatusBadRequest, err.Error())
+			return
+		}
+		err = sizeManager.forRequest(c).PutPostgresSize(size)
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to store size: %v", err))
+		return
+	}
+	requestLogger(c).Info("size stored", "kind", kind, "name", name)
+	respondWithSuccess(c, gin.H{"message": "size stored successfully"})
+}
+
+// DeleteSizeHandler handles size deletion requests, admins only
+func DeleteSizeHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if !CheckAdminAccess(username) {
+		respondWithError(c, http.StatusForbidden, "user does not have admin access")
+		return
+	}
+	kind, ok := sizeKind(c)
+	if !ok {
+		return
+	}
+	name := c.Param("name")
+	if kind == sizeKindVM {
+		err = sizeManager.forRequest(c).DeleteVMSize(name)
+	} else {
+		err = sizeManager.forRequest(c).DeletePostgresSize(name)
+	}
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete size: %v", err))
+		return
+	}
+	requestLogger(c).Info("size deleted", "kind", kind, "name", name)
+	respondWithSuccess(c, gin.H{"message": "size deleted successfully"})
+}
@@ -0,0 +1,324 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Resources of KubeVirt snapshots and of CSI volume snapshots as understood by kubectl
+const (
+	vmSnapshotResource     = "virtualmachinesnapshots.snapshot.kubevirt.io"
+	volumeSnapshotResource = "volumesnapshots.snapshot.storage.k8s.io"
+)
+
+// vmSnapshotObject is the part of a KubeVirt VirtualMachineSnapshot the server reads
+type vmSnapshotObject struct {
+	metav1.ObjectMeta `json:"metadata"`
+	Spec              struct {
+		Source struct {
+			Name string `json:"name"`
+		} `json:"source"`
+	} `json:"spec"`
+	Status struct {
+		Phase                             string       `json:"phase"`
+		ReadyToUse                        bool         `json:"readyToUse"`
+		CreationTime                      *metav1.Time `json:"creationTime"`
+		VirtualMachineSnapshotContentName string       `json:"virtualMachineSnapshotContentName"`
+		Error                             *struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	} `json:"status"`
+}
+
+// vmSnapshotList is a list of KubeVirt VirtualMachineSnapshots
+type vmSnapshotList struct {
+	Items []vmSnapshotObject `json:"items"`
+}
+
+// volumeSnapshotList is the part of a list of CSI VolumeSnapshots the server reads
+type volumeSnapshotList struct {
+	Items []struct {
+		metav1.ObjectMeta `json:"metadata"`
+		Status            struct {
+			RestoreSize *resource.Quantity `json:"restoreSize"`
+		} `json:"status"`
+	} `json:"items"`
+}
+
+// snapshotFromObject converts a VirtualMachineSnapshot with the sizes of the snapshot contents
+func snapshotFromObject(object vmSnapshotObject, sizes map[string]*resource.Quantity) types.VMSnapshot {
+	snapshot := types.VMSnapshot{
+		Name:       object.Name,
+		VM:         object.Spec.Source.Name,
+		Phase:      object.Status.Phase,
+		ReadyToUse: object.Status.ReadyToUse,
+	}
+	if object.Status.CreationTime != nil {
+		snapshot.CreatedAt = &object.Status.CreationTime.Time
+	}
+	if size := sizes[object.Status.VirtualMachineSnapshotContentName]; size != nil {
+		snapshot.Size = size.String()
+	}
+	if object.Status.Error != nil {
+		snapshot.Error = object.Status.Error.Message
+	}
+	return snapshot
+}
+
+// snapshotSizes sums the sizes of the volume snapshots of every VirtualMachineSnapshotContent in a namespace
+func (m *VMManager) snapshotSizes(namespace string) (map[string]*resource.Quantity, error) {
+	var list volumeSnapshotList
+	if err := listObjects(m.kubectl, volumeSnapshotResource, namespace, &list); err != nil {
+		return nil, err
+	}
+	sizes := make(map[string]*resource.Quantity)
+	for _, item := range list.Items {
+		if item.Status.RestoreSize == nil {
+			continue
+		}
+		for _, owner := range item.OwnerReferences {
+			if owner.Kind != "VirtualMachineSnapshotContent" {
+				continue
+			}
+			if sizes[owner.Name] == nil {
+				sizes[owner.Name] = resource.NewQuantity(0, resource.BinarySI)
+			}
+			sizes[owner.Name].Add(*item.Status.RestoreSize)
+		}
+	}
+	return sizes, nil
+}
+
+// getSnapshotObject reads a VirtualMachineSnapshot of a VM, failing when it does not exist
+func (m *VMManager) getSnapshotObject(name, namespace, snapshot string) (*vmSnapshotObject, error) {
+	var object vmSnapshotObject
+	found, err := getObject(m.kubectl, vmSnapshotResource, namespace, snapshot, &object)
+	if err != nil {
+		return nil, err
+	}
+	if !found || object.Spec.Source.Name != name {
+		return nil, fmt.Errorf("snapshot %s of VM %s not found in namespace %s", snapshot, name, namespace)
+	}
+	return &object, nil
+}
+
+// CreateSnapshot takes a snapshot of a VM and its disks
+func (m *VMManager) CreateSnapshot(name, namespace, snapshot string) error {
+	if _, err := m.getVMResource(name, namespace); err != nil {
+		return err
+	}
+	var existing struct{}
+	found, err := getObject(m.kubectl, vmSnapshotResource, namespace, snapshot, &existing)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("snapshot %s already exists in namespace %s", snapshot, namespace)
+	}
+	manifest, err := json.Marshal(map[string]any{
+		"apiVersion": "snapshot.kubevirt.io/v1beta1",
+		"kind":       "VirtualMachineSnapshot",
+		"metadata": map[string]any{
+			"name":      snapshot,
+			"namespace": namespace,
+			"labels":    map[string]string{vmLabel: name},
+		},
+		"spec": map[string]any{
+			"source": map[string]string{"apiGroup": "kubevirt.io", "kind": "VirtualMachine", "name": name},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+	if out, err := applyManifest(m.kubectl, string(manifest)); err != nil {
+		return fmt.Errorf("failed to create snapshot %s of VM %s: %s %w", snapshot, name, out, err)
+	}
+	return nil
+}
+
+// ListSnapshots lists the snapshots of a VM
+func (m *VMManager) ListSnapshots(name, namespace string) ([]types.VMSnapshot, error) {
+	var list vmSnapshotList
+	if err := listObjects(m.kubectl, vmSnapshotResource, namespace, &list, "-l", vmLabel+"="+name); err != nil {
+		return nil, err
+	}
+	sizes, err := m.snapshotSizes(namespace)
+	if err != nil {
+		return nil, err
+	}
+	snapshots := make([]types.VMSnapshot, 0, len(list.Items))
+	for _, item := range list.Items {
+		snapshots = append(snapshots, snapshotFromObject(item, sizes))
+	}
+	return snapshots, nil
+}
+
+// GetSnapshot returns a snapshot of a VM
+func (m *VMManager) GetSnapshot(name, namespace, snapshot string) (types.VMSnapshot, error) {
+	object, err := m.getSnapshotObject(name, namespace, snapshot)
+	if err != nil {
+		return types.VMSnapshot{}, err
+	}
+	sizes, err := m.snapshotSizes(namespace)
+	if err != nil {
+		return types.VMSnapshot{}, err
+	}
+	return snapshotFromObject(*object, sizes), nil
+}
+
+// DeleteSnapshot deletes a snapshot of a VM, its volume snapshots are garbage collected with it
+func (m *VMManager) DeleteSnapshot(name, namespace, snapshot string) error {
+	if _, err := m.getSnapshotObject(name, namespace, snapshot); err != nil {
+		return err
+	}
+	if out, err := m.kubectl.Run("delete", vmSnapshotResource, snapshot, "-n", namespace, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete snapshot %s: %s %w", snapshot, out, err)
+	}
+	return nil
+}
+
+// RestoreSnapshot restores a stopped VM and its disks from a snapshot
+func (m *VMManager) RestoreSnapshot(name, namespace, snapshot string) (types.VMRestore, error) {
+	object, err := m.getSnapshotObject(name, namespace, snapshot)
+	if err != nil {
+		return types.VMRestore{}, err
+	}
+	if !object.Status.ReadyToUse {
+		return types.VMRestore{}, fmt.Errorf("snapshot %s is not ready to use", snapshot)
+	}
+	vm, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return types.VMRestore{}, err
+	}
+	running, err := m.vmRunning(name, namespace)
+	if err != nil {
+		return types.VMRestore{}, err
+	}
+	if vm.Spec.Running || running {
+		return types.VMRestore{}, fmt.Errorf("VM %s must be stopped before it is restored", name)
+	}
+	restore := types.VMRestore{
+		Name:     fmt.Sprintf("%s-restore-%d", snapshot, time.Now().Unix()),
+		VM:       name,
+		Snapshot: snapshot,
+	}
+	manifest, err := json.Marshal(map[string]any{
+		"apiVersion": "snapshot.kubevirt.io/v1beta1",
+		"kind":       "VirtualMachineRestore",
+		"metadata": map[string]any{
+			"name":      restore.Name,
+			"namespace": namespace,
+			"labels":    map[string]string{vmLabel: name},
+		},
+		"spec": map[string]any{
+			"target":                     map[string]string{"apiGroup": "kubevirt.io", "kind": "VirtualMachine", "name": name},
+			"virtualMachineSnapshotName": snapshot,
+		},
+	})
+	if err != nil {
+		return types.VMRestore{}, fmt.Errorf("failed to marshal restore: %w", err)
+	}
+	if out, err := applyManifest(m.kubectl, string(manifest)); err != nil {
+		return types.VMRestore{}, fmt.Errorf("failed to restore VM %s from snapshot %s: %s %w", name, snapshot, out, err)
+	}
+	return restore, nil
+}
+
+// snapshotRequest checks auth and namespace access of a snapshot request and returns the manager, VM name and namespace
+func snapshotRequest(c *gin.Context) (*VMManager, string, string, bool) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return nil, "", "", false
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return nil, "", "", false
+	}
+	namespace := c.Param("namespace")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return nil, "", "", false
+	}
+	return vmManager.forRequest(c), c.Param("name"), namespace, true
+}
+
+// ListVMSnapshotsHandler handles requests to list the snapshots of a VM
+func ListVMSnapshotsHandler(c *gin.Context) {
+	m, name, namespace, ok := snapshotRequest(c)
+	if !ok {
+		return
+	}
+	snapshots, err := m.ListSnapshots(name, namespace)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list snapshots: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, snapshots)
+}
+
+// CreateVMSnapshotHandler handles requests to snapshot a VM
+func CreateVMSnapshotHandler(c *gin.Context) {
+	m, name, namespace, ok := snapshotRequest(c)
+	if !ok {
+		return
+	}
+	snapshot := c.Param("snapshot")
+	if err := m.CreateSnapshot(name, namespace, snapshot); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create snapshot: %v", err))
+		return
+	}
+	requestLogger(c).Info("snapshot created", "name", name, "namespace", namespace, "snapshot", snapshot)
+	respondWithSuccess(c, gin.H{"message": "snapshot created successfully"})
+}
+
+// GetVMSnapshotHandler handles requests for a snapshot of a VM
+func GetVMSnapshotHandler(c *gin.Context) {
+	m, name, namespace, ok := snapshotRequest(c)
+	if !ok {
+		return
+	}
+	snapshot, err := m.GetSnapshot(name, namespace, c.Param("snapshot"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get snapshot: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// DeleteVMSnapshotHandler handles requests to delete a snapshot of a VM
+func DeleteVMSnapshotHandler(c *gin.Context) {
+	m, name, namespace, ok := snapshotRequest(c)
+	if !ok {
+		return
+	}
+	snapshot := c.Param("snapshot")
+	if err := m.DeleteSnapshot(name, namespace, snapshot); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete snapshot: %v", err))
+		return
+	}
+	requestLogger(c).Info("snapshot deleted", "name", name, "namespace", namespace, "snapshot", snapshot)
+	respondWithSuccess(c, gin.H{"message": "snapshot deleted successfully"})
+}
+
+// RestoreVMSnapshotHandler handles requests to restore a VM from a snapshot
+func RestoreVMSnapshotHandler(c *gin.Context) {
+	m, name, namespace, ok := snapshotRequest(c)
+	if !ok {
+		return
+	}
+	restore, err := m.RestoreSnapshot(name, namespace, c.Param("snapshot"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to restore snapshot: %v", err))
+		return
+	}
+	requestLogger(c).Info("restore started", "name", name, "namespace", namespace, "restore", restore.Name)
+	c.JSON(http.StatusOK, restore)
+}
@@ -0,0 +1,567 @@
+package server
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"sigs.k8s.io/yaml"
+)
+
+// sshGatewayDialTimeout bounds how long the gateway waits for a VM to accept a forwarded connection
+const sshGatewayDialTimeout = 10 * time.Second
+
+// sshGatewayHandshakeTimeout bounds how long a client may take to authenticate before it is disconnected
+const sshGatewayHandshakeTimeout = 30 * time.Second
+
+// Extensions of the SSH permissions of an authenticated gateway connection
+const (
+	sshGatewayUserExtension      = "govnocloud-user"
+	sshGatewayNamespaceExtension = "govnocloud-namespace"
+	sshGatewayVMExtension        = "govnocloud-vm"
+	sshGatewayLoginExtension     = "govnocloud-login"
+)
+
+// sshPort is the port of the sshd of VMs
+const sshPort = "22"
+
+// SSHGateway is an SSH gateway into the VMs on the pod network. Users connect as [<login>+]<vm>.<namespace> and
+// authenticate with a key of their key registry or their govnocloud credentials. Shells, commands and subsystems are
+// relayed to the sshd of the VM as the login user, the VM's cloud-init user by default, authenticating with the
+// user's forwarded agent or a password typed into the session; forwarded connections reach the VM as with ssh -J.
+type SSHGateway struct {
+	config *ssh.ServerConfig
+	logger *slog.Logger
+	vms    *VMManager
+}
+
+// NewSSHGateway creates an SSH gateway with the host key at hostKeyPath, generating an ed25519 key when it does not exist
+func NewSSHGateway(hostKeyPath string) (*SSHGateway, error) {
+	hostKey, err := loadOrCreateHostKey(hostKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	logger := slog.Default().With("component", "sshgateway")
+	g := &SSHGateway{logger: logger, vms: vmManager.withLogger(logger)}
+	g.config = &ssh.ServerConfig{
+		PublicKeyCallback:           g.publicKeyCallback,
+		KeyboardInteractiveCallback: g.keyboardInteractiveCallback,
+		ServerVersion:               "SSH-2.0-govnocloud2",
+	}
+	g.config.AddHostKey(hostKey)
+	return g, nil
+}
+
+// loadOrCreateHostKey reads the PEM encoded host key at path, generating and storing one when it does not exist
+func loadOrCreateHostKey(path string) (ssh.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err == nil {
+		signer, err := ssh.ParsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse SSH host key %s: %w", path, err)
+		}
+		return signer, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read SSH host key %s: %w", path, err)
+	}
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SSH host key: %w", err)
+	}
+	block, err := ssh.MarshalPrivateKey(key, "govnocloud2 ssh gateway")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal SSH host key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create SSH host key directory: %w", err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(block), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to store SSH host key %s: %w", path, err)
+	}
+	return ssh.NewSignerFromKey(key)
+}
+
+// parseGatewayTarget parses the SSH user of a gateway connection, [<login>+]<vm>.<namespace>, into the login user on
+// the VM, empty for its cloud-init user, the VM and its namespace
+func parseGatewayTarget(user string) (string, string, string, error) {
+	login, target, hasLogin := strings.Cut(user, "+")
+	if !hasLogin {
+		login, target = "", user
+	}
+	vm, namespace, ok := strings.Cut(target, ".")
+	if !ok || vm == "" || namespace == "" || (hasLogin && login == "") {
+		return "", "", "", fmt.Errorf("invalid target %q, connect as [<login>+]<vm>.<namespace>", user)
+	}
+	return login, vm, namespace, nil
+}
+
+// gatewayPermissions returns the permissions of a user authenticated for the target of a connection
+func gatewayPermissions(username, login, vm, namespace string) *ssh.Permissions {
+	return &ssh.Permissions{Extensions: map[string]string{
+		sshGatewayUserExtension:      username,
+		sshGatewayLoginExtension:     login,
+		sshGatewayVMExtension:        vm,
+		sshGatewayNamespaceExtension: namespace,
+	}}
+}
+
+// publicKeyCallback accepts a key registered by a user who has access to the namespace of the target
+func (g *SSHGateway) publicKeyCallback(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	login, vm, namespace, err := parseGatewayTarget(conn.User())
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := ssh.FingerprintSHA256(key)
+	owners, err := sshKeyManager.KeyOwners(fingerprint)
+	if err != nil {
+		g.logger.Error("failed to look up SSH key", "fingerprint", fingerprint, "error", err)
+		return nil, err
+	}
+	for _, username := range owners {
+		if CheckNamespaceAccess(username, namespace) {
+			g.logger.Info("SSH gateway key authentication", "username", username, "fingerprint", fingerprint,
+				"vm", vm, "namespace", namespace, "remote", conn.RemoteAddr().String())
+			return gatewayPermissions(username, login, vm, namespace), nil
+		}
+	}
+	return nil, fmt.Errorf("key %s has no access to namespace %s", fingerprint, namespace)
+}
+
+// keyboardInteractiveCallback asks for govnocloud credentials with access to the namespace of the target
+func (g *SSHGateway) keyboardInteractiveCallback(conn ssh.ConnMetadata, challenge ssh.KeyboardInteractiveChallenge) (*ssh.Permissions, error) {
+	login, vm, namespace, err := parseGatewayTarget(conn.User())
+	if err != nil {
+		return nil, err
+	}
+	answers, err := challenge("", "govnocloud2 credentials for "+conn.User(),
+		[]string{"Username: ", "Password: "}, []bool{true, false})
+	if err != nil {
+		return nil, err
+	}
+	if len(answers) != 2 {
+		return nil, fmt.Errorf("expected username and password")
+	}
+	username := answers[0]
+	if err := authenticate(g.logger, username, answers[1]); err != nil {
+		return nil, err
+	}
+	if !CheckNamespaceAccess(username, namespace) {
+		g.logger.Info("SSH gateway access denied", "username", username, "namespace", namespace)
+		return nil, fmt.Errorf("user %s has no access to namespace %s", username, namespace)
+	}
+	g.logger.Info("SSH gateway password authentication", "username", username, "vm", vm, "namespace", namespace,
+		"remote", conn.RemoteAddr().String())
+	return gatewayPermissions(username, login, vm, namespace), nil
+}
+
+// vmAddress returns the address of the first interface of a running VM
+func (g *SSHGateway) vmAddress(vm, namespace string) (string, error) {
+	var vmi vmiObject
+	found, err := getObject(g.vms.kubectl, vmiResource, namespace, vm, &vmi)
+	if err != nil {
+		return "", err
+	}
+	if !found || vmi.Status.Phase != "Running" {
+		return "", fmt.Errorf("VM %s is not running in namespace %s", vm, namespace)
+	}
+	for _, iface := range vmiInterfaces(&vmi) {
+		if iface.IP != "" {
+			return iface.IP, nil
+		}
+	}
+	return "", fmt.Errorf("VM %s has no address yet", vm)
+}
+
+// Serve accepts gateway connections on listener until it is closed
+func (g *SSHGateway) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go g.handleConn(conn)
+	}
+}
+
+// handleConn performs the SSH handshake of a connection and serves its channels
+func (g *SSHGateway) handleConn(netConn net.Conn) {
+	netConn.SetDeadline(time.Now().Add(sshGatewayHandshakeTimeout))
+	conn, channels, requests, err := ssh.NewServerConn(netConn, g.config)
+	if err != nil {
+		g.logger.Debug("SSH gateway handshake failed", "remote", netConn.RemoteAddr().String(), "error", err)
+		netConn.Close()
+		return
+	}
+	defer conn.Close()
+	netConn.SetDeadline(time.Time{})
+	go ssh.DiscardRequests(requests)
+	extensions := conn.Permissions.Extensions
+	logger := g.logger.With("username", extensions[sshGatewayUserExtension], "vm", extensions[sshGatewayVMExtension],
+		"namespace", extensions[sshGatewayNamespaceExtension])
+	for channel := range channels {
+		switch channel.ChannelType() {
+		case "direct-tcpip":
+			go g.forward(logger, conn, channel)
+		case "session":
+			go g.proxySession(logger, conn, channel)
+		default:
+			channel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+// forward connects a direct-tcpip channel to the requested port of the connection's VM. The requested host is
+// ignored, the VM the user authenticated for is the only destination.
+func (g *SSHGateway) forward(logger *slog.Logger, conn *ssh.ServerConn, channel ssh.NewChannel) {
+	var request struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(channel.ExtraData(), &request); err != nil {
+		channel.Reject(ssh.ConnectionFailed, "invalid forward request")
+		return
+	}
+	vm, namespace := conn.Permissions.Extensions[sshGatewayVMExtension], conn.Permissions.Extensions[sshGatewayNamespaceExtension]
+	address, err := g.vmAddress(vm, namespace)
+	if err != nil {
+		channel.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	target := net.JoinHostPort(address, strconv.Itoa(int(request.Port)))
+	upstream, err := net.DialTimeout("tcp", target, sshGatewayDialTimeout)
+	if err != nil {
+		logger.Warn("failed to connect to VM", "target", target, "error", err)
+		channel.Reject(ssh.ConnectionFailed, fmt.Sprintf("failed to connect to VM %s: %v", vm, err))
+		return
+	}
+	defer upstream.Close()
+	downstream, requests, err := channel.Accept()
+	if err != nil {
+		return
+	}
+	defer downstream.Close()
+	go ssh.DiscardRequests(requests)
+	logger.Info("forwarding to VM", "target", target)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		io.Copy(upstream, downstream)
+		if tcp, ok := upstream.(*net.TCPConn); ok {
+			tcp.CloseWrite()
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		io.Copy(downstream, upstream)
+		downstream.CloseWrite()
+	}()
+	wg.Wait()
+}
+
+// loginUser returns the user cloud-init creates on a VM, the default user when its user-data doesn't name one
+func (g *SSHGateway) loginUser(vm, namespace string) string {
+	resource, err := g.vms.getVMResource(vm, namespace)
+	if err != nil {
+		return types.DefaultVMUser
+	}
+	userData, _, err := g.vms.cloudInitData(resource)
+	if err != nil {
+		return types.DefaultVMUser
+	}
+	var config struct {
+		Users []json.RawMessage `json:"users"`
+	}
+	if err := yaml.Unmarshal([]byte(userData), &config); err != nil {
+		return types.DefaultVMUser
+	}
+	for _, entry := range config.Users {
+		var user struct {
+			Name string `json:"name"`
+		}
+		if json.Unmarshal(entry, &user) == nil && user.Name != "" {
+			return user.Name
+		}
+	}
+	return types.DefaultVMUser
+}
+
+// readGatewayLine reads a line typed into the pty of a session, echoing it unless it is secret
+func readGatewayLine(channel ssh.Channel, echo bool) (string, error) {
+	var line []byte
+	buf := make([]byte, 1)
+	for {
+		if _, err := channel.Read(buf); err != nil {
+			return "", err
+		}
+		switch buf[0] {
+		case '\r', '\n':
+			channel.Write([]byte("\r\n"))
+			return string(line), nil
+		case '\b', 127:
+			if len(line) > 0 {
+				line = line[:len(line)-1]
+				if echo {
+					channel.Write([]byte("\b \b"))
+				}
+			}
+		case 3:
+			return "", fmt.Errorf("interrupted")
+		default:
+			line = append(line, buf[0])
+			if echo {
+				channel.Write(buf)
+			}
+		}
+	}
+}
+
+// dialVM opens an SSH connection to the VM of a gateway connection. It authenticates with the agent the user
+// forwarded and, when the session has a pty, with a password or keyboard-interactive answers typed into it. The VM's
+// host key is not checked, the gateway reaches it over the pod network by the address KubeVirt reports.
+func (g *SSHGateway) dialVM(conn *ssh.ServerConn, channel ssh.Channel, forwardAgent, pty bool) (*ssh.Client, error) {
+	extensions := conn.Permissions.Extensions
+	vm, namespace := extensions[sshGatewayVMExtension], extensions[sshGatewayNamespaceExtension]
+	address, err := g.vmAddress(vm, namespace)
+	if err != nil {
+		return nil, err
+	}
+	login := extensions[sshGatewayLoginExtension]
+	if login == "" {
+		login = g.loginUser(vm, namespace)
+	}
+	var methods []ssh.AuthMethod
+	if forwardAgent {
+		agentChannel, requests, err := conn.OpenChannel("auth-agent@openssh.com", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open forwarded agent: %w", err)
+		}
+		defer agentChannel.Close()
+		go ssh.DiscardRequests(requests)
+		methods = append(methods, ssh.PublicKeysCallback(agent.NewClient(agentChannel).Signers))
+	}
+	if pty {
+		methods = append(methods,
+			ssh.KeyboardInteractive(func(name, instruction string, questions []string, echos []bool) ([]string, error) {
+				for _, text := range []string{name, instruction} {
+					if text != "" {
+						fmt.Fprintf(channel, "%s\r\n", text)
+					}
+				}
+				answers := make([]string, len(questions))
+				for i, question := range questions {
+					fmt.Fprint(channel, question)
+					if answers[i], err = readGatewayLine(channel, echos[i]); err != nil {
+						return nil, err
+					}
+				}
+				return answers, nil
+			}),
+			ssh.PasswordCallback(func() (string, error) {
+				fmt.Fprintf(channel, "%s@%s's password: ", login, vm)
+				return readGatewayLine(channel, false)
+			}))
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no way to log in to VM %s, forward your agent with ssh -A or request a tty with ssh -t", vm)
+	}
+	client, err := ssh.Dial("tcp", net.JoinHostPort(address, sshPort), &ssh.ClientConfig{
+		User:            login,
+		Auth:            methods,
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         sshGatewayDialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to log in to VM %s as %s: %w", vm, login, err)
+	}
+	return client, nil
+}
+
+// ptyRequest is the payload of a pty-req request
+type ptyRequest struct {
+	Term    string
+	Columns uint32
+	Rows    uint32
+	Width   uint32
+	Height  uint32
+	Modes   string
+}
+
+// terminalModes decodes the encoded terminal modes of a pty-req request
+func (r ptyRequest) terminalModes() ssh.TerminalModes {
+	modes := ssh.TerminalModes{}
+	encoded := []byte(r.Modes)
+	for len(encoded) >= 5 && encoded[0] != 0 {
+		modes[encoded[0]] = binary.BigEndian.Uint32(encoded[1:5])
+		encoded = encoded[5:]
+	}
+	return modes
+}
+
+// proxySession relays a session to the sshd of the connection's VM. The pty, environment and agent requests before
+// the shell, command or subsystem are replayed on the VM's session, window changes are passed on while it runs.
+func (g *SSHGateway) proxySession(logger *slog.Logger, conn *ssh.ServerConn, newChannel ssh.NewChannel) {
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		return
+	}
+	defer channel.Close()
+	var pty *ptyRequest
+	var env []struct{ Name, Value string }
+	forwardAgent := false
+	var session *ssh.Session
+	for request := range requests {
+		switch request.Type {
+		case "pty-req":
+			var payload ptyRequest
+			if err := ssh.Unmarshal(request.Payload, &payload); err != nil {
+				request.Reply(false, nil)
+				continue
+			}
+			pty = &payload
+			request.Reply(true, nil)
+		case "env":
+			var payload struct{ Name, Value string }
+			if err := ssh.Unmarshal(request.Payload, &payload); err != nil {
+				request.Reply(false, nil)
+				continue
+			}
+			env = append(env, payload)
+			request.Reply(true, nil)
+		case "auth-agent-req@openssh.com":
+			forwardAgent = true
+			request.Reply(true, nil)
+		case "window-change":
+			var payload struct{ Columns, Rows, Width, Height uint32 }
+			if session != nil && ssh.Unmarshal(request.Payload, &payload) == nil {
+				session.WindowChange(int(payload.Rows), int(payload.Columns))
+			}
+			request.Reply(session != nil, nil)
+		case "shell", "exec", "subsystem":
+			if session != nil {
+				request.Reply(false, nil)
+				continue
+			}
+			request.Reply(true, nil)
+			client, started, err := g.startSession(conn, channel, request, pty, env, forwardAgent)
+			if err != nil {
+				logger.Warn("failed to relay session to VM", "error", err)
+				fmt.Fprintf(channel.Stderr(), "govnocloud2 ssh gateway: %v\r\n", err)
+				channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{255}))
+				return
+			}
+			session = started
+			logger.Info("relaying session to VM", "request", request.Type)
+			go func() {
+				defer client.Close()
+				status := uint32(0)
+				if err := session.Wait(); err != nil {
+					var exitErr *ssh.ExitError
+					status = 255
+					if errors.As(err, &exitErr) {
+						status = uint32(exitErr.ExitStatus())
+					}
+				}
+				channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+				channel.Close()
+			}()
+		default:
+			request.Reply(false, nil)
+		}
+	}
+}
+
+// startSession logs in to the VM and starts the shell, command or subsystem of request on a session with the pty and
+// environment the user asked for, relaying its input and output through channel
+func (g *SSHGateway) startSession(conn *ssh.ServerConn, channel ssh.Channel, request *ssh.Request, pty *ptyRequest,
+	env []struct{ Name, Value string }, forwardAgent bool) (*ssh.Client, *ssh.Session, error) {
+	client, err := g.dialVM(conn, channel, forwardAgent, pty != nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	session, err := client.NewSession()
+	if err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("failed to open session on VM: %w", err)
+	}
+	for _, variable := range env {
+		// sshd only accepts the variables of its AcceptEnv
+		session.Setenv(variable.Name, variable.Value)
+	}
+	if pty != nil {
+		if err := session.RequestPty(pty.Term, int(pty.Rows), int(pty.Columns), pty.terminalModes()); err != nil {
+			client.Close()
+			return nil, nil, fmt.Errorf("failed to request pty on VM: %w", err)
+		}
+	}
+	// stdin is copied by hand, Session.Wait would otherwise wait for the user to close it
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		client.Close()
+		return nil, nil, err
+	}
+	session.Stdout, session.Stderr = channel, channel.Stderr()
+	var payload struct{ Value string }
+	if request.Type != "shell" {
+		if err := ssh.Unmarshal(request.Payload, &payload); err != nil {
+			client.Close()
+			return nil, nil, fmt.Errorf("invalid %s request", request.Type)
+		}
+	}
+	switch request.Type {
+	case "shell":
+		err = session.Shell()
+	case "exec":
+		err = session.Start(payload.Value)
+	case "subsystem":
+		err = session.RequestSubsystem(payload.Value)
+	}
+	if err != nil {
+		client.Close()
+		return nil, nil, fmt.Errorf("failed to start %s on VM: %w", request.Type, err)
+	}
+	go func() {
+		io.Copy(stdin, channel)
+		stdin.Close()
+	}()
+	return client, session, nil
+}
+
+// startSSHGateway listens for SSH gateway connections on the gateway port
+func (s *Server) startSSHGateway() error {
+	gateway, err := NewSSHGateway(s.config.GatewayHostKey)
+	if err != nil {
+		return err
+	}
+	addr := fmt.Sprintf("%s:%s", s.config.Host, s.config.GatewayPort)
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", addr, err)
+	}
+	slog.Info("starting ssh gateway", "addr", addr)
+	go func() {
+		if err := gateway.Serve(listener); err != nil {
+			slog.Error("ssh gateway failed", "error", err)
+		}
+	}()
+	return nil
+}
@@ -0,0 +1,262 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHKeyManager stores the SSH public keys of users in etcd
+type SSHKeyManager struct {
+	etcdClient *clientv3.Client
+}
+
+// NewSSHKeyManager creates a new SSH key manager
+func NewSSHKeyManager(etcdClient *clientv3.Client) *SSHKeyManager {
+	return &SSHKeyManager{etcdClient: etcdClient}
+}
+
+// etcdKey returns the etcd key of a user's SSH key
+func (m *SSHKeyManager) etcdKey(username, name string) string {
+	return fmt.Sprintf("/sshkeys/%s/%s", username, name)
+}
+
+// parseSSHKey validates a public key in authorized_keys format and returns it with its fingerprint
+func parseSSHKey(name, publicKey string) (types.SSHKey, error) {
+	key, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(publicKey))
+	if err != nil {
+		return types.SSHKey{}, fmt.Errorf("invalid SSH public key: %w", err)
+	}
+	normalized := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+	if comment != "" {
+		normalized += " " + comment
+	}
+	return types.SSHKey{Name: name, PublicKey: normalized, Fingerprint: ssh.FingerprintSHA256(key)}, nil
+}
+
+// AddKey validates and stores a user's SSH key, replacing a key with the same name
+func (m *SSHKeyManager) AddKey(username, name, publicKey string) (types.SSHKey, error) {
+	key, err := parseSSHKey(name, publicKey)
+	if err != nil {
+		return types.SSHKey{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(key)
+	if err != nil {
+		return types.SSHKey{}, fmt.Errorf("failed to marshal SSH key: %w", err)
+	}
+	if _, err := m.etcdClient.Put(ctx, m.etcdKey(username, name), string(data)); err != nil {
+		return types.SSHKey{}, fmt.Errorf("failed to store SSH key: %w", err)
+	}
+	return key, nil
+}
+
+// GetKey returns a user's SSH key, nil when it does not exist
+func (m *SSHKeyManager) GetKey(username, name string) (*types.SSHKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(username, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SSH key: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	var key types.SSHKey
+	if err := json.Unmarshal(resp.Kvs[0].Value, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse SSH key: %w", err)
+	}
+	return &key, nil
+}
+
+// ListKeys returns the SSH keys of a user
+func (m *SSHKeyManager) ListKeys(username string) ([]types.SSHKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(username, ""), clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SSH keys: %w", err)
+	}
+	keys := make([]types.SSHKey, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var key types.SSHKey
+		if err := json.Unmarshal(kv.Value, &key); err != nil {
+			return nil, fmt.Errorf("failed to parse SSH key %s: %w", kv.Key, err)
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// KeyOwners returns the users whose key registry holds a key with the fingerprint
+func (m *SSHKeyManager) KeyOwners(fingerprint string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, "/sshkeys/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list SSH keys: %w", err)
+	}
+	var owners []string
+	for _, kv := range resp.Kvs {
+		var key types.SSHKey
+		if err := json.Unmarshal(kv.Value, &key); err != nil {
+			return nil, fmt.Errorf("failed to parse SSH key %s: %w", kv.Key, err)
+		}
+		if key.Fingerprint != fingerprint {
+			continue
+		}
+		username, _, _ := strings.Cut(strings.TrimPrefix(string(kv.Key), "/sshkeys/"), "/")
+		owners = append(owners, username)
+	}
+	return owners, nil
+}
+
+// DeleteKey removes a user's SSH key
+func (m *SSHKeyManager) DeleteKey(username, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Delete(ctx, m.etcdKey(username, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete SSH key: %w", err)
+	}
+	if resp.Deleted == 0 {
+		return fmt.Errorf("SSH key %s not found", name)
+	}
+	return nil
+}
+
+// resolveSSHKeys validates the SSH keys of a VM and adds the registry keys it references by name
+func resolveSSHKeys(username string, vm *types.VM) error {
+	keys := make([]string, 0, len(vm.SSHKeys)+len(vm.SSHKeyNames))
+	for _, publicKey := range vm.SSHKeys {
+		key, err := parseSSHKey("", publicKey)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key.PublicKey)
+	}
+	for _, name := range vm.SSHKeyNames {
+		key, err := sshKeyManager.GetKey(username, name)
+		if err != nil {
+			return err
+		}
+		if key == nil {
+			return fmt.Errorf("SSH key %s not found", name)
+		}
+		keys = append(keys, key.PublicKey)
+	}
+	vm.SSHKeys = keys
+	vm.SSHKeyNames = nil
+	return nil
+}
+
+// ListSSHKeysHandler handles requests to list the authenticated user's SSH keys
+func ListSSHKeysHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	keys, err := sshKeyManager.ListKeys(username)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list SSH keys: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, keys)
+}
+
+// AddSSHKeyHandler handles requests to add an SSH key to the authenticated user's registry
+func AddSSHKeyHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	name := c.Param("name")
+	if name == "" {
+		respondWithError(c, http.StatusBadRequest, "name is required")
+		return
+	}
+	var req types.SSHKey
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	if _, err := parseSSHKey(name, req.PublicKey); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	key, err := sshKeyManager.AddKey(username, name, req.PublicKey)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to add SSH key: %v", err))
+		return
+	}
+	requestLogger(c).Info("SSH key added", "name", name, "fingerprint", key.Fingerprint)
+	respondWithSuccess(c, key)
+}
+
+// GetSSHKeyHandler handles requests to get one of the authenticated user's SSH keys
+func GetSSHKeyHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	name := c.Param("name")
+	key, err := sshKeyManager.GetKey(username, name)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get SSH key: %v", err))
+		return
+	}
+	if key == nil {
+		respondWithError(c, http.StatusNotFound, fmt.Sprintf("SSH key %s not found", name))
+		return
+	}
+	c.JSON(http.StatusOK, key)
+}
+
+// DeleteSSHKeyHandler handles requests to delete one of the authenticated user's SSH keys
+func DeleteSSHKeyHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	name := c.Param("name")
+	if err := sshKeyManager.DeleteKey(username, name); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete SSH key: %v", err))
+		return
+	}
+	requestLogger(c).Info("SSH key deleted", "name", name)
+	respondWithSuccess(c, gin.H{"message": "SSH key deleted successfully"})
+}
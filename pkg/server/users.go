@@ -4,8 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -25,7 +26,8 @@ func NewUserManager() *UserManager {
 		DialTimeout: 5 * time.Second,
 	})
 	if err != nil {
-		log.Fatalf("failed to connect to etcd: %v", err)
+		slog.Error("failed to connect to etcd", "error", err)
+		os.Exit(1)
 	}
 	return &UserManager{etcdClient: etcdClient}
 }
@@ -289,7 +291,7 @@ func (m *UserManager) RemoveNamespaceFromUser(name, namespace string) error {
 func (m *UserManager) VerifyPassword(name, password string) (bool, error) {
 	storedPassword, err := m.GetUserPassword(name)
 	if err != nil {
-		log.Printf("Failed to get stored password for user %s: %v", name, err)
+		slog.Error("failed to get stored password for user", "name", name, "error", err)
 		return false, fmt.Errorf("failed to get stored password: %w", err)
 	}
 
@@ -338,13 +340,13 @@ func GetUserHandler(c *gin.Context) {
 	}
 	name := c.Param("name")
 	if name == "" {
-		log.Printf("name is required")
+		requestLogger(c).Warn("name is required")
 		respondWithError(c, http.StatusBadRequest, "name is required")
 		return
 	}
 	user, err := userManager.GetUser(name)
 	if err != nil {
-		log.Printf("failed to get user: %v", err)
+		requestLogger(c).Error("failed to get user", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get user: %v", err))
 		return
 	}
@@ -372,19 +374,19 @@ func CreateUserHandler(c *gin.Context) {
 	}
 	name := c.Param("name")
 	if name == "" {
-		log.Printf("name is required")
+		requestLogger(c).Warn("name is required")
 		respondWithError(c, http.StatusBadRequest, "name is required")
 		return
 	}
 	var user types.User
 	if err := c.ShouldBindJSON(&user); err != nil {
-		log.Printf("failed to bind user: %v", err)
+		requestLogger(c).Error("failed to bind user", "error", err)
 		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("failed to bind user: %v", err))
 		return
 	}
 	err = userManager.CreateUser(name, user)
 	if err != nil {
-		log.Printf("failed to create user: %v", err)
+		requestLogger(c).Error("failed to create user", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create user: %v", err))
 		return
 	}
@@ -408,13 +410,13 @@ func DeleteUserHandler(c *gin.Context) {
 	}
 	name := c.Param("name")
 	if name == "" {
-		log.Printf("name is required")
+		requestLogger(c).Warn("name is required")
 		respondWithError(c, http.StatusBadRequest, "name is required")
 		return
 	}
 	err = userManager.DeleteUser(name)
 	if err != nil {
-		log.Printf("failed to delete user: %v", err)
+		requestLogger(c).Error("failed to delete user", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete user: %v", err))
 		return
 	}
@@ -438,13 +440,13 @@ func GetUserPasswordHandler(c *gin.Context) {
 	}
 	name := c.Param("name")
 	if name == "" {
-		log.Printf("name is required")
+		requestLogger(c).Warn("name is required")
 		respondWithError(c, http.StatusBadRequest, "name is required")
 		return
 	}
 	password, err := userManager.GetUserPassword(name)
 	if err != nil {
-		log.Printf("failed to get user password: %v", err)
+		requestLogger(c).Error("failed to get user password", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get user password: %v", err))
 		return
 	}
@@ -468,13 +470,13 @@ func SetUserPasswordHandler(c *gin.Context) {
 	}
 	name := c.Param("name")
 	if name == "" {
-		log.Printf("name is required")
+		requestLogger(c).Warn("name is required")
 		respondWithError(c, http.StatusBadRequest, "name is required")
 		return
 	}
 	var password string
 	if err := c.ShouldBindJSON(&password); err != nil {
-		log.Printf("failed to bind password: %v", err)
+		requestLogger(c).Error("failed to bind password", "error", err)
 		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("failed to bind password: %v", err))
 		return
 	}
@@ -482,7 +484,7 @@ func SetUserPasswordHandler(c *gin.Context) {
 	// Store password in plain text (no hashing)
 	err = userManager.SetUserPassword(name, password)
 	if err != nil {
-		log.Printf("failed to set user password: %v", err)
+		requestLogger(c).Error("failed to set user password", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to set user password: %v", err))
 		return
 	}
@@ -507,13 +509,13 @@ func AddNamespaceToUserHandler(c *gin.Context) {
 	name := c.Param("name")
 	namespace := c.Param("namespace")
 	if name == "" || namespace == "" {
-		log.Printf("name and namespace are required")
+		requestLogger(c).Warn("name and namespace are required")
 		respondWithError(c, http.StatusBadRequest, "name and namespace are required")
 		return
 	}
 	err = userManager.AddNamespaceToUser(name, namespace)
 	if err != nil {
-		log.Printf("failed to add namespace to user: %v", err)
+		requestLogger(c).Error("failed to add namespace to user", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to add namespace to user: %v", err))
 		return
 	}
@@ -538,13 +540,13 @@ func RemoveNamespaceFromUserHandler(c *gin.Context) {
 	name := c.Param("name")
 	namespace := c.Param("namespace")
 	if name == "" || namespace == "" {
-		log.Printf("name and namespace are required")
+		requestLogger(c).Warn("name and namespace are required")
 		respondWithError(c, http.StatusBadRequest, "name and namespace are required")
 		return
 	}
 	err = userManager.RemoveNamespaceFromUser(name, namespace)
 	if err != nil {
-		log.Printf("failed to remove namespace from user: %v", err)
+		requestLogger(c).Error("failed to remove namespace from user", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to remove namespace from user: %v", err))
 		return
 	}
@@ -0,0 +1,524 @@
+package server
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// vmExportTTL bounds how long a VirtualMachineExport outlives an export the server could not clean up
+const vmExportTTL = "2h"
+
+// archiveRootDisk is the name of the root disk in an export archive
+const archiveRootDisk = "rootdisk"
+
+// exportedVM is a VM whose disks were downloaded from a VirtualMachineExport into a temporary directory
+type exportedVM struct {
+	dir     string
+	format  string
+	archive types.VMArchive
+}
+
+// Close removes the downloaded disks
+func (e *exportedVM) Close() error {
+	return os.RemoveAll(e.dir)
+}
+
+// Filename returns the name a download of the export is saved as
+func (e *exportedVM) Filename() string {
+	return e.archive.Name + "." + e.format
+}
+
+// Write writes the export to w, a tar archive of its manifest and disks or the qcow2 root disk
+func (e *exportedVM) Write(w io.Writer) error {
+	if e.format == types.VMExportFormatQCOW2 {
+		return copyFile(w, filepath.Join(e.dir, archiveRootDisk+".qcow2"))
+	}
+	tw := tar.NewWriter(w)
+	manifest, err := json.MarshalIndent(e.archive, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal archive manifest: %w", err)
+	}
+	if err := tw.WriteHeader(&tar.Header{Name: types.VMArchiveManifest, Mode: 0o644, Size: int64(len(manifest)),
+		ModTime: e.archive.ExportedAt}); err != nil {
+		return fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		return fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+	for _, disk := range e.archive.Disks {
+		file := filepath.Join(e.dir, path.Base(disk.File))
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("failed to read disk %s: %w", disk.Name, err)
+		}
+		if err := tw.WriteHeader(&tar.Header{Name: disk.File, Mode: 0o644, Size: info.Size(),
+			ModTime: e.archive.ExportedAt}); err != nil {
+			return fmt.Errorf("failed to write disk %s: %w", disk.Name, err)
+		}
+		if err := copyFile(tw, file); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+// copyFile copies the file at path to w
+func copyFile(w io.Writer, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer file.Close()
+	if _, err := io.Copy(w, file); err != nil {
+		return fmt.Errorf("failed to copy %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// volumeSize returns the requested size of a PersistentVolumeClaim
+func (m *VMManager) volumeSize(name, namespace string) (string, error) {
+	var pvc corev1.PersistentVolumeClaim
+	found, err := getObject(m.kubectl, "pvc", namespace, name, &pvc)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("volume %s not found in namespace %s", name, namespace)
+	}
+	return pvc.Spec.Resources.Requests.Storage().String(), nil
+}
+
+// cloudInitData returns the user-data and network-data of the cloud-init Secret of a VM
+func (m *VMManager) cloudInitData(resource *types.GovnoVM) (string, string, error) {
+	if resource.Spec.CloudInitSecret == "" {
+		return "", "", nil
+	}
+	var secret corev1.Secret
+	found, err := getObject(m.kubectl, "secrets", resource.Namespace, resource.Spec.CloudInitSecret, &secret)
+	if err != nil {
+		return "", "", err
+	}
+	if !found {
+		return "", "", fmt.Errorf("cloud-init secret %s of VM %s not found", resource.Spec.CloudInitSecret, resource.Name)
+	}
+	return string(secret.Data[cloudInitUserDataKey]), string(secret.Data[cloudInitNetworkDataKey]), nil
+}
+
+// ExportVM downloads the disks of a stopped VM through a KubeVirt VirtualMachineExport. The tar format keeps the
+// root disk and the attached volumes as gzip compressed raw images next to the spec and cloud-init of the VM, the
+// qcow2 format converts the root disk alone. The caller writes the export and closes it.
+func (m *VMManager) ExportVM(name, namespace, format string) (*exportedVM, error) {
+	if format != types.VMExportFormatTar && format != types.VMExportFormatQCOW2 {
+		return nil, fmt.Errorf("invalid export format %q, must be %s or %s", format, types.VMExportFormatTar, types.VMExportFormatQCOW2)
+	}
+	resource, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	running, err := m.vmRunning(name, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if running {
+		return nil, fmt.Errorf("VM %s is running, stop it before exporting it", name)
+	}
+	rootDisk, err := m.currentRootDisk(resource)
+	if err != nil {
+		return nil, err
+	}
+	rootSize, err := rootDiskSize(resource.Spec)
+	if err != nil {
+		return nil, err
+	}
+	userData, networkData, err := m.cloudInitData(resource)
+	if err != nil {
+		return nil, err
+	}
+	sysprep, err := m.sysprepData(resource)
+	if err != nil {
+		return nil, err
+	}
+	archive := types.VMArchive{
+		Version:     types.VMArchiveVersion,
+		Name:        name,
+		Namespace:   namespace,
+		ExportedAt:  time.Now().UTC(),
+		Spec:        resource.Spec,
+		UserData:    userData,
+		NetworkData: networkData,
+		Sysprep:     sysprep,
+	}
+	// volumes are exported under the names of their claims
+	claims := map[string]string{archiveRootDisk: rootDisk}
+	archive.Disks = append(archive.Disks, types.VMArchiveDisk{Name: archiveRootDisk, File: "disks/" + archiveRootDisk + ".img.gz", Size: rootSize})
+	if format == types.VMExportFormatTar {
+		for _, volume := range resource.Spec.Volumes {
+			size, err := m.volumeSize(volume, namespace)
+			if err != nil {
+				return nil, err
+			}
+			claims[volume] = volume
+			archive.Disks = append(archive.Disks, types.VMArchiveDisk{Name: volume, File: "disks/" + volume + ".img.gz", Size: size})
+		}
+	}
+
+	if err := os.MkdirAll(imageManager.dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create images directory: %w", err)
+	}
+	dir, err := os.MkdirTemp(imageManager.dir, "export-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+	export := &exportedVM{dir: dir, format: format, archive: archive}
+	exportName := fmt.Sprintf("%s-export-%d", name, archive.ExportedAt.Unix())
+	m.logger.Info("exporting VM", "name", name, "namespace", namespace, "export", exportName, "format", format)
+	if out, err := m.virtctl.Run("vmexport", "create", exportName, "--vm="+name, "-n", namespace, "--ttl="+vmExportTTL); err != nil {
+		export.Close()
+		return nil, fmt.Errorf("failed to create export of VM %s: %s %w", name, out, err)
+	}
+	defer func() {
+		if out, err := m.virtctl.Run("vmexport", "delete", exportName, "-n", namespace); err != nil {
+			m.logger.Warn("failed to delete VM export", "export", exportName, "output", string(out), "error", err)
+		}
+	}()
+	for _, disk := range archive.Disks {
+		file, diskFormat := filepath.Join(dir, path.Base(disk.File)), "gzip"
+		if format == types.VMExportFormatQCOW2 {
+			file, diskFormat = filepath.Join(dir, archiveRootDisk+".img"), "raw"
+		}
+		if out, err := m.virtctl.Run("vmexport", "download", exportName, "-n", namespace, "--volume="+claims[disk.Name],
+			"--output="+file, "--format="+diskFormat, "--port-forward", "--keep-vme"); err != nil {
+			export.Close()
+			return nil, fmt.Errorf("failed to download disk %s of VM %s: %s %w", disk.Name, name, out, err)
+		}
+	}
+	if format == types.VMExportFormatQCOW2 {
+		raw := filepath.Join(dir, archiveRootDisk+".img")
+		if out, err := exec.Command("qemu-img", "convert", "-c", "-f", "raw", "-O", "qcow2",
+			raw, filepath.Join(dir, archiveRootDisk+".qcow2")).CombinedOutput(); err != nil {
+			export.Close()
+			return nil, fmt.Errorf("failed to convert root disk of VM %s to qcow2: %s %w", name, out, err)
+		}
+		os.Remove(raw)
+	}
+	return export, nil
+}
+
+// uploadDisk uploads a disk image of an archive into a new DataVolume, a root disk as a migratable block volume
+// and an attached volume like the volumes of the volumes API
+func (m *VMManager) uploadDisk(name, namespace, size, file string, root bool) error {
+	proxy, err := uploadProxyURL(m.kubectl)
+	if err != nil {
+		return err
+	}
+	args := []string{"image-upload", "dv", name, "-n", namespace, "--size=" + size, "--image-path=" + file,
+		"--uploadproxy-url=" + proxy, "--insecure"}
+	if root {
+		args = append(args, "--storage-class="+rootDiskStorageClass, "--access-mode=ReadWriteMany", "--volume-mode=block")
+	} else {
+		args = append(args, "--storage-class=longhorn", "--access-mode=ReadWriteOnce")
+	}
+	if out, err := m.virtctl.Run(args...); err != nil {
+		return fmt.Errorf("failed to upload disk %s: %s %w", name, out, err)
+	}
+	return nil
+}
+
+// readArchiveManifest reads the manifest an export archive starts with
+func readArchiveManifest(tr *tar.Reader) (types.VMArchive, error) {
+	var archive types.VMArchive
+	header, err := tr.Next()
+	if err != nil {
+		return archive, fmt.Errorf("failed to read archive: %w", err)
+	}
+	if header.Name != types.VMArchiveManifest {
+		return archive, fmt.Errorf("archive starts with %s instead of %s", header.Name, types.VMArchiveManifest)
+	}
+	if err := json.NewDecoder(tr).Decode(&archive); err != nil {
+		return archive, fmt.Errorf("failed to parse %s: %w", types.VMArchiveManifest, err)
+	}
+	if archive.Version != types.VMArchiveVersion {
+		return archive, fmt.Errorf("unsupported archive version %d", archive.Version)
+	}
+	if len(archive.Disks) == 0 || archive.Disks[0].Name != archiveRootDisk {
+		return archive, fmt.Errorf("archive has no root disk")
+	}
+	return archive, nil
+}
+
+// ImportVM creates the VM name in namespace from an export archive read from r. Its disks are uploaded into a root
+// disk and volumes named like the exported ones, its cloud-init is stored in a generated Secret and the VM keeps the
+// spec of the exported one, MACs and addresses included, and is started.
+func (m *VMManager) ImportVM(name, namespace string, r io.Reader) (err error) {
+	var existing struct{}
+	found, err := getObject(m.kubectl, govnoVMResource, namespace, name, &existing)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("VM %s already exists in namespace %s", name, namespace)
+	}
+	tr := tar.NewReader(r)
+	archive, err := readArchiveManifest(tr)
+	if err != nil {
+		return err
+	}
+	spec := archive.Spec
+	if err := validateVMResources(spec); err != nil {
+		return fmt.Errorf("VM of the archive can't run here: %w", err)
+	}
+	if spec.Networks, err = normalizeVMNetworks(spec.Networks, namespace); err != nil {
+		return err
+	}
+	if err := validateVMPlacement(spec.Placement); err != nil {
+		return err
+	}
+	if err := validateRunPolicy(spec.RunStrategy, spec.IdleTimeout); err != nil {
+		return err
+	}
+	if err := validateVMDevices(spec.Devices); err != nil {
+		return err
+	}
+	ports := make([]types.VMPort, 0, len(spec.Ports))
+	for _, port := range spec.Ports {
+		port.NodePort, port.Endpoint = 0, ""
+		ports = append(ports, port)
+	}
+	if spec.Ports, spec.ServiceType, err = normalizeVMPorts(ports, spec.ServiceType); err != nil {
+		return err
+	}
+	disks := make(map[string]types.VMArchiveDisk, len(archive.Disks))
+	claims := make(map[string]string, len(archive.Disks))
+	limits := make(map[string]int64, len(archive.Disks))
+	// the disks are checked before any of them is uploaded, a bad one would leave the import halfway
+	for _, disk := range archive.Disks {
+		if errs := validation.IsDNS1123Label(disk.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid disk name %s in the archive: %s", disk.Name, strings.Join(errs, ", "))
+		}
+		if _, ok := claims[disk.Name]; ok {
+			return fmt.Errorf("disk %s appears twice in the archive", disk.Name)
+		}
+		size, err := resource.ParseQuantity(disk.Size)
+		if err != nil {
+			return fmt.Errorf("invalid size %q of disk %s in the archive: %w", disk.Size, disk.Name, err)
+		}
+		// a disk file is no larger than its disk, and no larger than an image file may be
+		limits[disk.Name] = min(size.Value(), imageManager.maxSize)
+		claim, kind := disk.Name, "pvc"
+		if reservedDiskNames[disk.Name] && disk.Name != archiveRootDisk {
+			return fmt.Errorf("disk name %s of the archive is reserved", disk.Name)
+		}
+		if disk.Name == archiveRootDisk {
+			claim, kind = rootDiskName(name), dataVolumeResource
+		}
+		found, err := getObject(m.kubectl, kind, namespace, claim, &existing)
+		if err != nil {
+			return err
+		}
+		if found {
+			return fmt.Errorf("disk %s of the archive would overwrite %s %s", disk.Name, kind, claim)
+		}
+		disks[disk.File], claims[disk.Name] = disk, claim
+	}
+
+	if err := os.MkdirAll(imageManager.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create images directory: %w", err)
+	}
+	dir, err := os.MkdirTemp(imageManager.dir, "import-")
+	if err != nil {
+		return fmt.Errorf("failed to create import directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	// a failed import leaves none of the disks it uploaded behind
+	var uploaded []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		for _, claim := range uploaded {
+			if out, err := m.kubectl.Run("delete", dataVolumeResource, claim, "-n", namespace, "--ignore-not-found"); err != nil {
+				m.logger.Warn("failed to delete imported disk", "disk", claim, "output", string(out), "error", err)
+			}
+		}
+	}()
+	m.logger.Info("importing VM", "name", name, "namespace", namespace, "source", archive.Namespace+"/"+archive.Name)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		disk, ok := disks[header.Name]
+		if !ok {
+			continue
+		}
+		file := filepath.Join(dir, path.Base(disk.File))
+		if err := writeArchiveFile(file, tr, limits[disk.Name]); err != nil {
+			return err
+		}
+		if err := m.uploadDisk(claims[disk.Name], namespace, disk.Size, file, disk.Name == archiveRootDisk); err != nil {
+			return err
+		}
+		uploaded = append(uploaded, claims[disk.Name])
+		delete(disks, header.Name)
+		os.Remove(file)
+	}
+	if len(disks) > 0 {
+		return fmt.Errorf("archive misses %s", strings.Join(slices.Sorted(maps.Keys(disks)), ", "))
+	}
+	if out, err := m.kubectl.Run("label", dataVolumeResource, rootDiskName(name), "-n", namespace, vmLabel+"="+name); err != nil {
+		return fmt.Errorf("failed to label root disk of VM %s: %s %w", name, out, err)
+	}
+
+	// the generated network-data of networks is regenerated, the user-data and any other network-data are kept
+	vm := types.VM{Name: name, UserData: archive.UserData, Networks: spec.Networks}
+	if len(spec.Networks) == 0 {
+		vm.NetworkData = archive.NetworkData
+	}
+	spec.CloudInitSecret, spec.NetworkData, spec.SysprepSecret = "", false, ""
+	switch provisioning(spec.Devices) {
+	case types.VMProvisioningCloudInit, types.VMProvisioningCloudbaseInit:
+		secret, err := generateCloudInitSecret(namespace, cloudInitSecretName(name), vm, map[string]string{vmLabel: name})
+		if err != nil {
+			return err
+		}
+		if out, err := applyManifest(m.kubectl, secret); err != nil {
+			return fmt.Errorf("failed to create cloud-init secret of VM %s: %s %w", name, out, err)
+		}
+		spec.CloudInitSecret = cloudInitSecretName(name)
+		spec.NetworkData = provisioning(spec.Devices) == types.VMProvisioningCloudInit && (vm.NetworkData != "" || len(vm.Networks) > 0)
+	case types.VMProvisioningSysprep:
+		if archive.Sysprep != "" {
+			secret, err := generateSysprepSecret(namespace, sysprepSecretName(name), archive.Sysprep, map[string]string{vmLabel: name})
+			if err != nil {
+				return err
+			}
+			if out, err := applyManifest(m.kubectl, secret); err != nil {
+				return fmt.Errorf("failed to create sysprep secret of VM %s: %s %w", name, out, err)
+			}
+			spec.SysprepSecret = sysprepSecretName(name)
+		}
+	}
+	spec.Running = true
+	spec.RestartedAt = ""
+	spec.Volumes = nil
+	for _, disk := range archive.Disks[1:] {
+		spec.Volumes = append(spec.Volumes, disk.Name)
+	}
+	resource := types.GovnoVM{
+		TypeMeta:   typeMeta(govnoVMKind),
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Spec:       spec,
+	}
+	if err := applyResource(m.kubectl, resource); err != nil {
+		return fmt.Errorf("failed to create VM %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeArchiveFile writes a file of an archive of at most limit bytes to path
+func writeArchiveFile(path string, r io.Reader, limit int64) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	defer file.Close()
+	written, err := io.Copy(file, io.LimitReader(r, limit+1))
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	if written > limit {
+		return fmt.Errorf("%s of the archive is larger than %d bytes", filepath.Base(path), limit)
+	}
+	return file.Close()
+}
+
+// ExportVMHandler handles requests to download a stopped VM as an archive
+func ExportVMHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	format := c.DefaultQuery("format", types.VMExportFormatTar)
+	if format != types.VMExportFormatTar && format != types.VMExportFormatQCOW2 {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid export format %q", format))
+		return
+	}
+	export, err := vmManager.forRequest(c).ExportVM(name, namespace, format)
+	if err != nil {
+		requestLogger(c).Error("failed to export VM", "name", name, "namespace", namespace, "error", err)
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to export VM: %v", err))
+		return
+	}
+	defer export.Close()
+	contentType := "application/x-tar"
+	if format == types.VMExportFormatQCOW2 {
+		contentType = "application/octet-stream"
+	}
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", export.Filename()))
+	c.Status(http.StatusOK)
+	// the response is committed, a failure can only cut the download short
+	if err := export.Write(c.Writer); err != nil {
+		requestLogger(c).Error("failed to send VM export", "name", name, "namespace", namespace, "error", err)
+		return
+	}
+	requestLogger(c).Info("VM exported", "name", name, "namespace", namespace, "format", format)
+}
+
+// ImportVMHandler handles requests to create a VM from an uploaded export archive
+func ImportVMHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	if err := vmManager.forRequest(c).ImportVM(name, namespace, c.Request.Body); err != nil {
+		requestLogger(c).Error("failed to import VM", "name", name, "namespace", namespace, "error", err)
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to import VM: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM imported", "name", name, "namespace", namespace)
+	respondWithSuccess(c, gin.H{"message": "VM imported successfully"})
+}
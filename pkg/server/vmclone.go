@@ -0,0 +1,164 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// cloneCloudInitSecret copies the generated cloud-init Secret of a VM for its clone, regenerating the network-data
+// of the clone's networks
+func (m *VMManager) cloneCloudInitSecret(namespace, name, target string, networks []types.VMNetwork) error {
+	var secret corev1.Secret
+	found, err := getObject(m.kubectl, "secrets", namespace, cloudInitSecretName(name), &secret)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("cloud-init secret of VM %s not found", name)
+	}
+	clone := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cloudInitSecretName(target),
+			Namespace: namespace,
+			Labels:    map[string]string{vmLabel: target},
+		},
+		Data: secret.Data,
+	}
+	if len(networks) > 0 {
+		networkData, err := generateNetworkData(networks)
+		if err != nil {
+			return err
+		}
+		clone.Data[cloudInitNetworkDataKey] = []byte(networkData)
+	}
+	manifest, err := json.Marshal(clone)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cloud-init secret: %w", err)
+	}
+	if out, err := applyManifest(m.kubectl, string(manifest)); err != nil {
+		return fmt.Errorf("failed to create cloud-init secret of VM %s: %s %w", target, out, err)
+	}
+	return nil
+}
+
+// CloneVM creates a VM from the root disk and spec of an existing VM. The clone gets a copy of the root disk,
+// its cloud-init, ports and networks with new MACs but none of the attached volumes, and the clone of a running VM
+// is crash-consistent. VMs with static addresses can't be cloned as the clone would take over their addresses.
+func (m *VMManager) CloneVM(name, namespace, target string) error {
+	source, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return err
+	}
+	var existing struct{}
+	found, err := getObject(m.kubectl, govnoVMResource, namespace, target, &existing)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("VM %s already exists in namespace %s", target, namespace)
+	}
+	if hasStaticNetwork(source.Spec.Networks) {
+		return fmt.Errorf("VM %s has static addresses, create the VM with its own addresses instead of cloning", name)
+	}
+	found, err = getObject(m.kubectl, dataVolumeResource, namespace, rootDiskName(target), &existing)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("a root disk %s is left from a deleted VM, delete it or create the VM to reuse it", rootDiskName(target))
+	}
+	rootDisk, err := m.currentRootDisk(source)
+	if err != nil {
+		return err
+	}
+	size, err := rootDiskSize(source.Spec)
+	if err != nil {
+		return err
+	}
+
+	spec := source.Spec
+	spec.Running = true
+	spec.RestartedAt = ""
+	spec.Volumes = nil
+	spec.Ports = make([]types.VMPort, 0, len(source.Spec.Ports))
+	for _, port := range source.Spec.Ports {
+		port.NodePort, port.Endpoint = 0, ""
+		spec.Ports = append(spec.Ports, port)
+	}
+	if len(spec.Ports) == 0 {
+		spec.Ports = nil
+	}
+	spec.Networks = make([]types.VMNetwork, 0, len(source.Spec.Networks))
+	for _, network := range source.Spec.Networks {
+		network.MAC = ""
+		spec.Networks = append(spec.Networks, network)
+	}
+	if spec.Networks, err = normalizeVMNetworks(spec.Networks, namespace); err != nil {
+		return err
+	}
+	// the generated cloud-init and sysprep Secrets are copied, a Secret the user brought is shared
+	if spec.CloudInitSecret == cloudInitSecretName(name) {
+		if err := m.cloneCloudInitSecret(namespace, name, target, spec.Networks); err != nil {
+			return err
+		}
+		spec.CloudInitSecret = cloudInitSecretName(target)
+	}
+	if spec.SysprepSecret == sysprepSecretName(name) {
+		if err := m.cloneSysprepSecret(namespace, name, target); err != nil {
+			return err
+		}
+		spec.SysprepSecret = sysprepSecretName(target)
+	}
+
+	// the clone's root disk exists before its GovnoVM, so the controller reuses it instead of importing the image
+	manifest, err := dataVolumeManifest(rootDiskName(target), namespace, map[string]string{vmLabel: target},
+		pvcSource(namespace, rootDisk), size)
+	if err != nil {
+		return err
+	}
+	if out, err := applyManifest(m.kubectl, manifest); err != nil {
+		return fmt.Errorf("failed to clone root disk of VM %s: %s %w", name, out, err)
+	}
+	resource := types.GovnoVM{
+		TypeMeta:   typeMeta(govnoVMKind),
+		ObjectMeta: metav1.ObjectMeta{Name: target, Namespace: namespace},
+		Spec:       spec,
+	}
+	if err := applyResource(m.kubectl, resource); err != nil {
+		return fmt.Errorf("failed to create VM %s: %w", target, err)
+	}
+	return nil
+}
+
+// CloneVMHandler handles requests to clone a VM
+func CloneVMHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	target := c.Param("target")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	if err := vmManager.forRequest(c).CloneVM(name, namespace, target); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to clone VM: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM cloned", "name", name, "namespace", namespace, "target", target)
+	respondWithSuccess(c, gin.H{"message": "VM cloned successfully"})
+}
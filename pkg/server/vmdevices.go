@@ -0,0 +1,260 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// windowsOS is the OS of Windows images, which default to UEFI and sysprep
+const windowsOS = "windows"
+
+// sysprepUnattendKey is the key of the sysprep Secret KubeVirt passes to Windows setup of a generalized image
+const sysprepUnattendKey = "Unattend.xml"
+
+// sysprepSecretName returns the name of the sysprep Secret govnocloud generates for a VM
+func sysprepSecretName(vmName string) string {
+	return vmName + "-sysprep"
+}
+
+// normalizeImageDevices validates the device settings of an image, defaulting Windows images to UEFI and sysprep
+func normalizeImageDevices(image *types.VMImage) error {
+	if image.OS == windowsOS {
+		if image.Devices == nil {
+			image.Devices = &types.VMDevices{}
+		}
+		if image.Devices.Firmware == "" {
+			image.Devices.Firmware = types.VMFirmwareUEFI
+		}
+		if image.Devices.Provisioning == "" {
+			image.Devices.Provisioning = types.VMProvisioningSysprep
+		}
+	}
+	return validateVMDevices(image.Devices)
+}
+
+// validateVMDevices checks the firmware, disk bus, interface model and provisioning of device settings
+func validateVMDevices(devices *types.VMDevices) error {
+	if devices == nil {
+		return nil
+	}
+	if !slices.Contains([]string{"", types.VMFirmwareBIOS, types.VMFirmwareUEFI, types.VMFirmwareSecureBoot}, devices.Firmware) {
+		return fmt.Errorf("invalid firmware %s, use bios, uefi or secureboot", devices.Firmware)
+	}
+	if !slices.Contains([]string{"", types.VMDiskBusVirtio, types.VMDiskBusSATA}, devices.DiskBus) {
+		return fmt.Errorf("invalid disk bus %s, use virtio or sata", devices.DiskBus)
+	}
+	if !slices.Contains([]string{"", types.VMInterfaceModelVirtio, types.VMInterfaceModelE1000e}, devices.InterfaceModel) {
+		return fmt.Errorf("invalid interface model %s, use virtio or e1000e", devices.InterfaceModel)
+	}
+	if !slices.Contains([]string{"", types.VMProvisioningCloudInit, types.VMProvisioningCloudbaseInit,
+		types.VMProvisioningSysprep, types.VMProvisioningNone}, devices.Provisioning) {
+		return fmt.Errorf("invalid provisioning %s, use cloud-init, cloudbase-init, sysprep or none", devices.Provisioning)
+	}
+	return nil
+}
+
+// provisioning returns how the guest of a GovnoVM is configured on first boot
+func provisioning(devices *types.VMDevices) string {
+	if devices == nil || devices.Provisioning == "" {
+		return types.VMProvisioningCloudInit
+	}
+	return devices.Provisioning
+}
+
+// validateProvisioning rejects the first boot settings of a VM its image can't apply
+func validateProvisioning(vm types.VM, image *types.VMImage) error {
+	switch provisioning(image.Devices) {
+	case types.VMProvisioningCloudInit:
+	case types.VMProvisioningCloudbaseInit:
+		// the generated network-data is netplan, which cloudbase-init does not read
+		if vm.NetworkData != "" {
+			return fmt.Errorf("image %s is provisioned with cloudbase-init, which can't take networkData", image.Name)
+		}
+		for _, network := range vm.Networks {
+			if network.Addressing == types.VMAddressingStatic {
+				return fmt.Errorf("image %s is provisioned with cloudbase-init, network %s must use dhcp", image.Name, network.Name)
+			}
+		}
+	case types.VMProvisioningSysprep, types.VMProvisioningNone:
+		if vm.CloudInitSecret != "" || vm.UserData != "" || vm.NetworkData != "" || len(vm.SSHKeys) > 0 ||
+			len(vm.SSHKeyNames) > 0 || vm.User != "" {
+			return fmt.Errorf("image %s is provisioned with %s, which can't take cloud-init settings", image.Name, provisioning(image.Devices))
+		}
+		for _, network := range vm.Networks {
+			if network.Addressing == types.VMAddressingStatic {
+				return fmt.Errorf("image %s has no cloud-init, network %s must use dhcp", image.Name, network.Name)
+			}
+		}
+	}
+	if vm.Sysprep != "" && provisioning(image.Devices) != types.VMProvisioningSysprep {
+		return fmt.Errorf("image %s is not provisioned with sysprep", image.Name)
+	}
+	return nil
+}
+
+// setProvisioning copies the device settings of its image into a GovnoVM, which keeps them when the image changes,
+// and points it at the Secrets its provisioning reads
+func setProvisioning(resource *types.GovnoVM, vm types.VM, image *types.VMImage) {
+	if image.Devices != nil {
+		devices := *image.Devices
+		resource.Spec.Devices = &devices
+	}
+	switch provisioning(image.Devices) {
+	case types.VMProvisioningSysprep, types.VMProvisioningNone:
+		resource.Spec.CloudInitSecret, resource.Spec.NetworkData = "", false
+		if vm.Sysprep != "" {
+			resource.Spec.SysprepSecret = sysprepSecretName(resource.Name)
+		}
+	case types.VMProvisioningCloudbaseInit:
+		resource.Spec.NetworkData = false
+	}
+}
+
+// generateSysprepSecret generates the Secret of the given name holding the unattend.xml of a VM
+func generateSysprepSecret(namespace, name, unattend string, labels map[string]string) (string, error) {
+	secret := corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    labels,
+		},
+		StringData: map[string]string{sysprepUnattendKey: unattend},
+	}
+	manifest, err := json.Marshal(secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal sysprep secret: %w", err)
+	}
+	return string(manifest), nil
+}
+
+// sysprepData returns the unattend.xml of the sysprep Secret of a VM, empty when it has none
+func (m *VMManager) sysprepData(resource *types.GovnoVM) (string, error) {
+	if resource.Spec.SysprepSecret == "" {
+		return "", nil
+	}
+	var secret corev1.Secret
+	found, err := getObject(m.kubectl, "secrets", resource.Namespace, resource.Spec.SysprepSecret, &secret)
+	if err != nil {
+		return "", err
+	}
+	if !found {
+		return "", fmt.Errorf("sysprep secret %s of VM %s not found", resource.Spec.SysprepSecret, resource.Name)
+	}
+	return string(secret.Data[sysprepUnattendKey]), nil
+}
+
+// rootDiskBus returns the bus of the root disk and the cloud-init disk of a GovnoVM
+func rootDiskBus(devices *types.VMDevices) string {
+	if devices == nil || devices.DiskBus == "" {
+		return types.VMDiskBusVirtio
+	}
+	return devices.DiskBus
+}
+
+// interfaceModel returns the model of the network interfaces of a GovnoVM, empty for KubeVirt's virtio default
+func interfaceModel(devices *types.VMDevices) string {
+	if devices == nil || devices.InterfaceModel == types.VMInterfaceModelVirtio {
+		return ""
+	}
+	return devices.InterfaceModel
+}
+
+// generateProvisioning generates the disk and volume the guest of a GovnoVM reads its first boot configuration from
+func generateProvisioning(spec types.GovnoVMSpec) (string, string) {
+	switch provisioning(spec.Devices) {
+	case types.VMProvisioningCloudInit, types.VMProvisioningCloudbaseInit:
+		if spec.CloudInitSecret == "" {
+			return "", ""
+		}
+		source := "cloudInitNoCloud"
+		if provisioning(spec.Devices) == types.VMProvisioningCloudbaseInit {
+			source = "cloudInitConfigDrive"
+		}
+		disk := fmt.Sprintf(`
+          - name: cloudinitdisk
+            disk:
+              bus: %s`, rootDiskBus(spec.Devices))
+		volume := fmt.Sprintf(`
+      - name: cloudinitdisk
+        %s:
+          secretRef:
+            name: %s`, source, spec.CloudInitSecret)
+		if spec.NetworkData {
+			volume += fmt.Sprintf(`
+          networkDataSecretRef:
+            name: %s`, spec.CloudInitSecret)
+		}
+		return disk, volume
+	case types.VMProvisioningSysprep:
+		if spec.SysprepSecret == "" {
+			return "", ""
+		}
+		return `
+          - name: sysprep
+            cdrom:
+              bus: sata`, fmt.Sprintf(`
+      - name: sysprep
+        sysprep:
+          secret:
+            name: %s`, spec.SysprepSecret)
+	}
+	return "", ""
+}
+
+// generateTPM generates the persistent TPM device of a GovnoVM
+func generateTPM(devices *types.VMDevices) string {
+	if devices == nil || !devices.TPM {
+		return ""
+	}
+	return `
+          tpm:
+            persistent: true`
+}
+
+// generateFirmware generates the firmware of the domain of a GovnoVM, nothing for the BIOS default. The EFI variables
+// persist like the TPM, and secure boot needs SMM.
+func generateFirmware(devices *types.VMDevices) string {
+	if devices == nil || devices.Firmware == "" || devices.Firmware == types.VMFirmwareBIOS {
+		return ""
+	}
+	secureBoot := devices.Firmware == types.VMFirmwareSecureBoot
+	firmware := fmt.Sprintf(`
+        firmware:
+          bootloader:
+            efi:
+              secureBoot: %t
+              persistent: true`, secureBoot)
+	if secureBoot {
+		firmware += `
+        features:
+          smm:
+            enabled: true`
+	}
+	return firmware
+}
+
+// cloneSysprepSecret copies the generated sysprep Secret of a VM for its clone
+func (m *VMManager) cloneSysprepSecret(namespace, name, target string) error {
+	var secret corev1.Secret
+	found, err := getObject(m.kubectl, "secrets", namespace, sysprepSecretName(name), &secret)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("sysprep secret of VM %s not found", name)
+	}
+	manifest, err := generateSysprepSecret(namespace, sysprepSecretName(target), string(secret.Data[sysprepUnattendKey]), map[string]string{vmLabel: target})
+	if err != nil {
+		return err
+	}
+	if out, err := applyManifest(m.kubectl, manifest); err != nil {
+		return fmt.Errorf("failed to create sysprep secret of VM %s: %s %w", target, out, err)
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/rusik69/govnocloud2/pkg/k8s"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// dataVolumeResource is the CDI DataVolume resource as understood by kubectl
+const dataVolumeResource = "datavolumes.cdi.kubevirt.io"
+
+// rootDiskStorageClass is the storage class root disks are provisioned from, its RWX block volumes live-migrate
+const rootDiskStorageClass = "longhorn-migratable"
+
+// ensureRootDiskStorageClass creates the storage class of root disks when it is missing, as on clusters installed
+// before it existed
+func ensureRootDiskStorageClass(kubectl KubectlRunner) error {
+	out, err := kubectl.Run("get", "storageclass", rootDiskStorageClass, "-o", "name", "--ignore-not-found")
+	if err != nil {
+		return fmt.Errorf("failed to get storage class %s: %s %w", rootDiskStorageClass, out, err)
+	}
+	if len(out) > 0 {
+		return nil
+	}
+	if out, err := applyManifest(kubectl, k8s.LonghornMigratableClass); err != nil {
+		return fmt.Errorf("failed to create storage class %s: %s %w", rootDiskStorageClass, out, err)
+	}
+	return nil
+}
+
+// rootDiskName returns the name of the DataVolume holding the root disk of a VM
+func rootDiskName(vmName string) string {
+	return vmName + "-rootdisk"
+}
+
+// normalizeDiskSize validates a root disk size override, a plain number being a size in Gi
+func normalizeDiskSize(size string) (string, error) {
+	if size == "" {
+		return "", nil
+	}
+	if gi, err := strconv.Atoi(size); err == nil {
+		size = fmt.Sprintf("%dGi", gi)
+	}
+	quantity, err := resource.ParseQuantity(size)
+	if err != nil {
+		return "", fmt.Errorf("invalid disk size %s: %w", size, err)
+	}
+	if quantity.Sign() <= 0 {
+		return "", fmt.Errorf("invalid disk size: %s", size)
+	}
+	return quantity.String(), nil
+}
+
+// rootDiskSize returns the size of the root disk of a GovnoVM, its override or the disk of its size
+func rootDiskSize(spec types.GovnoVMSpec) (string, error) {
+	if spec.Disk != "" {
+		return spec.Disk, nil
+	}
+	size, err := lookupVMSize(spec.Size)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%dGi", size.Disk), nil
+}
+
+// imageLabel labels the PVCs holding images
+const imageLabel = "govnocloud.io/image"
+
+// registrySource returns the DataVolume source importing a containerdisk
+func registrySource(image string) map[string]any {
+	return map[string]any{"registry": map[string]any{"url": "docker://" + image}}
+}
+
+// pvcSource returns the DataVolume source cloning a PVC
+func pvcSource(namespace, name string) map[string]any {
+	return map[string]any{"pvc": map[string]any{"namespace": namespace, "name": name}}
+}
+
+// imageSource returns the DataVolume source of an image, a containerdisk or a PVC
+func imageSource(image *types.VMImage) map[string]any {
+	if image.PVC != "" {
+		return pvcSource(imagePVCNamespace(image), image.PVC)
+	}
+	return registrySource(image.Image)
+}
+
+// dataVolumeManifest generates a DataVolume filling a Longhorn volume from a source
+func dataVolumeManifest(name, namespace string, labels map[string]string, source map[string]any, size string) (string, error) {
+	dataVolume := map[string]any{
+		"apiVersion": "cdi.kubevirt.io/v1beta1",
+		"kind":       "DataVolume",
+		"metadata": map[string]any{
+			"name":      name,
+			"namespace": namespace,
+			"labels":    labels,
+		},
+		"spec": map[string]any{
+			"source": source,
+			"storage": map[string]any{
+				"accessModes":      []string{"ReadWriteMany"},
+				"volumeMode":       "Block",
+				"storageClassName": rootDiskStorageClass,
+				"resources": map[string]any{
+					"requests": map[string]string{"storage": size},
+				},
+			},
+		},
+	}
+	manifest, err := json.Marshal(dataVolume)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal data volume: %w", err)
+	}
+	return string(manifest), nil
+}
+
+// generateDataVolumeManifest generates the DataVolume importing the image of a GovnoVM into a Longhorn volume
+func (m *VMManager) generateDataVolumeManifest(vm *types.GovnoVM, image *types.VMImage) (string, error) {
+	size, err := rootDiskSize(vm.Spec)
+	if err != nil {
+		return "", err
+	}
+	return dataVolumeManifest(rootDiskName(vm.Name), vm.Namespace, map[string]string{vmLabel: vm.Name},
+		imageSource(image), size)
+}
+
+// currentRootDisk returns the DataVolume the VirtualMachine of a GovnoVM boots from,
+// which differs from the generated root disk once the VM was restored from a snapshot
+func (m *VMManager) currentRootDisk(resource *types.GovnoVM) (string, error) {
+	var object virtualMachineObject
+	found, err := getObject(m.kubectl, virtualMachineResource, resource.Namespace, resource.Name, &object)
+	if err != nil {
+		return "", err
+	}
+	if found {
+		for _, volume := range object.Spec.Template.Spec.Volumes {
+			if volume.Name == "rootdisk" && volume.DataVolume != nil && volume.DataVolume.Name != "" {
+				return volume.DataVolume.Name, nil
+			}
+		}
+	}
+	return rootDiskName(resource.Name), nil
+}
+
+// applyRootDisk creates the root disk DataVolume of a GovnoVM unless it exists, a retained disk being reused
+func (m *VMManager) applyRootDisk(resource *types.GovnoVM) error {
+	name := rootDiskName(resource.Name)
+	var existing struct{}
+	found, err := getObject(m.kubectl, dataVolumeResource, resource.Namespace, name, &existing)
+	if err != nil {
+		return err
+	}
+	if !found {
+		image, err := lookupImage(resource.Spec.Image, resource.Namespace)
+		if err != nil {
+			return err
+		}
+		manifest, err := m.generateDataVolumeManifest(resource, image)
+		if err != nil {
+			return err
+		}
+		m.logger.Debug("generated root disk manifest", "manifest", manifest)
+		if out, err := applyManifest(m.kubectl, manifest); err != nil {
+			return fmt.Errorf("failed to create root disk %s: %s %w", name, out, err)
+		}
+	}
+	return setOwner(m.kubectl, dataVolumeResource, resource.Namespace, name, govnoVMKind, resource.ObjectMeta)
+}
+
+// retainRootDisk releases the root disk of a VM from its GovnoVM so it survives the VM's deletion
+func (m *VMManager) retainRootDisk(name, namespace string) error {
+	disk := rootDiskName(name)
+	var existing struct{}
+	found, err := getObject(m.kubectl, dataVolumeResource, namespace, disk, &existing)
+	if err != nil || !found {
+		return err
+	}
+	patch := `{"metadata":{"ownerReferences":null}}`
+	if out, err := m.kubectl.Run("patch", dataVolumeResource, disk, "-n", namespace, "--type=merge", "-p", patch); err != nil {
+		return fmt.Errorf("failed to retain root disk %s: %s %w", disk, out, err)
+	}
+	return nil
+}
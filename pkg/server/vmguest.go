@@ -0,0 +1,181 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// guestAgentInfo is the part of the guest agent info virtctl guestosinfo prints the server reads
+type guestAgentInfo struct {
+	GuestAgentVersion string `json:"guestAgentVersion"`
+	Hostname          string `json:"hostname"`
+	OS                struct {
+		Name          string `json:"name"`
+		PrettyName    string `json:"prettyName"`
+		Version       string `json:"version"`
+		KernelRelease string `json:"kernelRelease"`
+		Machine       string `json:"machine"`
+	} `json:"os"`
+	Timezone string `json:"timezone"`
+	UserList []struct {
+		UserName  string  `json:"userName"`
+		Domain    string  `json:"domain"`
+		LoginTime float64 `json:"loginTime"`
+	} `json:"userList"`
+	FSInfo struct {
+		Disks []struct {
+			DiskName       string `json:"diskName"`
+			MountPoint     string `json:"mountPoint"`
+			FileSystemType string `json:"fileSystemType"`
+			TotalBytes     int64  `json:"totalBytes"`
+			UsedBytes      int64  `json:"usedBytes"`
+		} `json:"disks"`
+	} `json:"fsInfo"`
+}
+
+// vmiInterfaces returns the network interfaces of a VirtualMachineInstance with their addresses
+func vmiInterfaces(vmi *vmiObject) []types.VMInterface {
+	interfaces := make([]types.VMInterface, 0, len(vmi.Status.Interfaces))
+	for _, iface := range vmi.Status.Interfaces {
+		interfaces = append(interfaces, types.VMInterface{
+			Name:          iface.Name,
+			InterfaceName: iface.InterfaceName,
+			MAC:           iface.MAC,
+			IP:            iface.IPAddress,
+			IPs:           iface.IPAddresses,
+		})
+	}
+	return interfaces
+}
+
+// vmiStartedAt returns when a VirtualMachineInstance last started running, nil when it never did
+func vmiStartedAt(vmi *vmiObject) *time.Time {
+	var startedAt *time.Time
+	for _, transition := range vmi.Status.PhaseTransitionTimestamps {
+		if transition.Phase != "Running" {
+			continue
+		}
+		at := transition.PhaseTransitionTimestamp.UTC()
+		if startedAt == nil || at.After(*startedAt) {
+			startedAt = &at
+		}
+	}
+	return startedAt
+}
+
+// vmiConditions returns the conditions of a VirtualMachineInstance
+func vmiConditions(vmi *vmiObject) []types.VMCondition {
+	conditions := make([]types.VMCondition, 0, len(vmi.Status.Conditions))
+	for _, condition := range vmi.Status.Conditions {
+		conditions = append(conditions, types.VMCondition{
+			Type:    condition.Type,
+			Status:  condition.Status,
+			Reason:  condition.Reason,
+			Message: condition.Message,
+		})
+	}
+	return conditions
+}
+
+// vmiGuestOS returns the guest OS a VirtualMachineInstance reports, nil before the guest agent reported it
+func vmiGuestOS(vmi *vmiObject) *types.VMGuestInfo {
+	info := vmi.Status.GuestOSInfo
+	if info.Name == "" && info.KernelRelease == "" {
+		return nil
+	}
+	return &types.VMGuestInfo{OS: types.VMGuestOS{
+		Name:          info.Name,
+		PrettyName:    info.PrettyName,
+		Version:       info.Version,
+		KernelRelease: info.KernelRelease,
+		Machine:       info.Machine,
+	}}
+}
+
+// guestInfo asks the qemu-guest-agent of a running VM for its hostname, users and filesystems
+func (m *VMManager) guestInfo(name, namespace string) (*types.VMGuestInfo, error) {
+	out, err := m.virtctl.Run("guestosinfo", name, "-n", namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guest info of VM %s: %s %w", name, out, err)
+	}
+	var agent guestAgentInfo
+	if err := json.Unmarshal(out, &agent); err != nil {
+		return nil, fmt.Errorf("failed to parse guest info of VM %s: %w", name, err)
+	}
+	info := &types.VMGuestInfo{
+		AgentVersion: agent.GuestAgentVersion,
+		Hostname:     agent.Hostname,
+		Timezone:     agent.Timezone,
+		OS: types.VMGuestOS{
+			Name:          agent.OS.Name,
+			PrettyName:    agent.OS.PrettyName,
+			Version:       agent.OS.Version,
+			KernelRelease: agent.OS.KernelRelease,
+			Machine:       agent.OS.Machine,
+		},
+	}
+	for _, user := range agent.UserList {
+		guestUser := types.VMGuestUser{Name: user.UserName, Domain: user.Domain}
+		if user.LoginTime > 0 {
+			loginTime := time.UnixMilli(int64(user.LoginTime * 1000)).UTC()
+			guestUser.LoginTime = &loginTime
+		}
+		info.Users = append(info.Users, guestUser)
+	}
+	for _, disk := range agent.FSInfo.Disks {
+		info.Filesystems = append(info.Filesystems, types.VMGuestFilesystem{
+			Disk:       disk.DiskName,
+			MountPoint: disk.MountPoint,
+			Type:       disk.FileSystemType,
+			TotalBytes: disk.TotalBytes,
+			UsedBytes:  disk.UsedBytes,
+		})
+	}
+	return info, nil
+}
+
+// vmFromObjects builds the VM reported to users from its GovnoVM, its KubeVirt VirtualMachine and,
+// while it runs, its VirtualMachineInstance
+func vmFromObjects(resource *types.GovnoVM, object *virtualMachineObject, vmi *vmiObject, ports []types.VMPort) (types.VM, error) {
+	cpu, ram, err := vmResources(resource.Spec)
+	if err != nil {
+		return types.VM{}, err
+	}
+	disk, err := rootDiskSize(resource.Spec)
+	if err != nil {
+		return types.VM{}, err
+	}
+	createdAt := resource.CreationTimestamp.UTC()
+	vm := types.VM{
+		Name:        resource.Name,
+		Namespace:   resource.Namespace,
+		Size:        resource.Spec.Size,
+		CPU:         cpu,
+		RAM:         ram,
+		Image:       resource.Spec.Image,
+		Disk:        disk,
+		Status:      resource.Status.Phase,
+		Ports:       ports,
+		ServiceType: resource.Spec.ServiceType,
+		Networks:    resource.Spec.Networks,
+		RunStrategy: reportedRunStrategy(resource.Spec),
+		IdleTimeout: resource.Spec.IdleTimeout,
+		Placement:   effectivePlacement(resource.Spec),
+		Devices:     resource.Spec.Devices,
+		Volumes:     vmDisks(*object),
+		CreatedAt:   &createdAt,
+	}
+	if vmi != nil {
+		vm.Node = vmi.Status.NodeName
+		vm.Interfaces = vmiInterfaces(vmi)
+		vm.Conditions = vmiConditions(vmi)
+		vm.Guest = vmiGuestOS(vmi)
+		if vm.StartedAt = vmiStartedAt(vmi); vm.StartedAt != nil && vmi.Status.Phase == "Running" {
+			vm.Uptime = time.Since(*vm.StartedAt).Round(time.Second).String()
+		}
+	}
+	return vm, nil
+}
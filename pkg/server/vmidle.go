@@ -0,0 +1,210 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// idleCheckInterval is how often the idle monitor samples the activity of VMs with an idle timeout
+const idleCheckInterval = 5 * time.Minute
+
+// A VM is active when its virt-launcher pod used more CPU or moved more network traffic than this between samples
+const (
+	idleCPUCores       = 0.05
+	idleNetworkBytesPS = 1024
+)
+
+// launcherPodLabel labels the virt-launcher pod of a running VM with the VM's name
+const launcherPodLabel = "vm.kubevirt.io/name"
+
+// idleSample is the last activity sample of the virt-launcher pod of a VM
+type idleSample struct {
+	pod      string
+	at       time.Time
+	cpu      uint64
+	network  uint64
+	activeAt time.Time
+}
+
+// podStats is the part of the kubelet stats summary of a pod the idle monitor reads
+type podStats struct {
+	PodRef struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+	} `json:"podRef"`
+	CPU struct {
+		UsageCoreNanoSeconds uint64 `json:"usageCoreNanoSeconds"`
+	} `json:"cpu"`
+	Network struct {
+		Interfaces []struct {
+			RxBytes uint64 `json:"rxBytes"`
+			TxBytes uint64 `json:"txBytes"`
+		} `json:"interfaces"`
+	} `json:"network"`
+}
+
+// IdleMonitor stops running VMs that had no CPU or network activity for their idle timeout. Activity is
+// sampled from the kubelet stats of their virt-launcher pods and kept in memory, so a server restart or a
+// VM restart starts the idle time over.
+type IdleMonitor struct {
+	kubectl  KubectlRunner
+	logger   *slog.Logger
+	interval time.Duration
+	samples  map[string]idleSample
+}
+
+// NewIdleMonitor creates a new idle monitor
+func NewIdleMonitor() *IdleMonitor {
+	logger := slog.Default().With("component", "idle")
+	return &IdleMonitor{
+		kubectl:  kubectlWithLogger(&DefaultKubectlRunner{}, logger),
+		logger:   logger,
+		interval: idleCheckInterval,
+		samples:  map[string]idleSample{},
+	}
+}
+
+// Run checks the VMs with an idle timeout every interval until ctx is done
+func (m *IdleMonitor) Run(ctx context.Context) {
+	m.logger.Info("starting idle monitor", "interval", m.interval)
+	ticker := time.NewTicker(m.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		if err := m.Check(time.Now()); err != nil {
+			m.logger.Error("failed to check idle VMs", "error", err)
+		}
+	}
+}
+
+// Check samples the running VMs with an idle timeout and stops those idle for longer than it
+func (m *IdleMonitor) Check(now time.Time) error {
+	var vms types.GovnoVMList
+	if err := listObjects(m.kubectl, govnoVMResource, "", &vms); err != nil {
+		return err
+	}
+	nodeStats := map[string]map[string]podStats{}
+	seen := map[string]bool{}
+	for _, vm := range vms.Items {
+		if vm.Spec.IdleTimeout == "" || !vm.Spec.Running {
+			continue
+		}
+		timeout, err := time.ParseDuration(vm.Spec.IdleTimeout)
+		if err != nil {
+			continue
+		}
+		key := vm.Namespace + "/" + vm.Name
+		pod, node, err := m.launcherPod(vm.Namespace, vm.Name)
+		if err != nil {
+			m.logger.Warn("failed to find virt-launcher pod", "name", vm.Name, "namespace", vm.Namespace, "error", err)
+			continue
+		}
+		if pod == "" {
+			continue
+		}
+		stats, ok := nodeStats[node]
+		if !ok {
+			if stats, err = m.nodePodStats(node); err != nil {
+				m.logger.Warn("failed to get node stats", "node", node, "error", err)
+			}
+			nodeStats[node] = stats
+		}
+		podStat, ok := stats[vm.Namespace+"/"+pod]
+		if !ok {
+			continue
+		}
+		seen[key] = true
+		sample := m.sample(key, pod, podStat, now)
+		if idle := now.Sub(sample.activeAt); idle >= timeout {
+			m.logger.Info("stopping idle VM", "name", vm.Name, "namespace", vm.Namespace, "idle", idle.Round(time.Minute))
+			if err := vmManager.withLogger(m.logger).StopVM(vm.Name, vm.Namespace); err != nil {
+				m.logger.Warn("failed to stop idle VM", "name", vm.Name, "namespace", vm.Namespace, "error", err)
+				continue
+			}
+			delete(m.samples, key)
+		}
+	}
+	for key := range m.samples {
+		if !seen[key] {
+			delete(m.samples, key)
+		}
+	}
+	return nil
+}
+
+// sample records the activity of the virt-launcher pod of a VM and returns its sample, whose activeAt is
+// the last time the VM was seen active. A new pod counts as active.
+func (m *IdleMonitor) sample(key, pod string, stats podStats, now time.Time) idleSample {
+	var network uint64
+	for _, iface := range stats.Network.Interfaces {
+		network += iface.RxBytes + iface.TxBytes
+	}
+	current := idleSample{pod: pod, at: now, cpu: stats.CPU.UsageCoreNanoSeconds, network: network, activeAt: now}
+	previous, ok := m.samples[key]
+	if ok && previous.pod == pod {
+		current.activeAt = previous.activeAt
+		if seconds := now.Sub(previous.at).Seconds(); seconds > 0 {
+			cores := float64(current.cpu-min(current.cpu, previous.cpu)) / 1e9 / seconds
+			bytesPS := float64(current.network-min(current.network, previous.network)) / seconds
+			if cores > idleCPUCores || bytesPS > idleNetworkBytesPS {
+				current.activeAt = now
+			}
+		}
+	}
+	m.samples[key] = current
+	return current
+}
+
+// launcherPod returns the running virt-launcher pod of a VM and its node, no pod when the VM is not running
+func (m *IdleMonitor) launcherPod(namespace, name string) (string, string, error) {
+	var pods struct {
+		Items []struct {
+			Metadata struct {
+				Name string `json:"name"`
+			} `json:"metadata"`
+			Spec struct {
+				NodeName string `json:"nodeName"`
+			} `json:"spec"`
+			Status struct {
+				Phase string `json:"phase"`
+			} `json:"status"`
+		} `json:"items"`
+	}
+	if err := listObjects(m.kubectl, "pods", namespace, &pods, "-l", launcherPodLabel+"="+name); err != nil {
+		return "", "", err
+	}
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == "Running" {
+			return pod.Metadata.Name, pod.Spec.NodeName, nil
+		}
+	}
+	return "", "", nil
+}
+
+// nodePodStats returns the kubelet stats of the pods of a node keyed by namespace/name
+func (m *IdleMonitor) nodePodStats(node string) (map[string]podStats, error) {
+	out, err := m.kubectl.Run("get", "--raw", "/api/v1/nodes/"+node+"/proxy/stats/summary")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stats of node %s: %s %w", node, out, err)
+	}
+	var summary struct {
+		Pods []podStats `json:"pods"`
+	}
+	if err := json.Unmarshal(out, &summary); err != nil {
+		return nil, fmt.Errorf("failed to parse stats of node %s: %w", node, err)
+	}
+	stats := make(map[string]podStats, len(summary.Pods))
+	for _, pod := range summary.Pods {
+		stats[pod.PodRef.Namespace+"/"+pod.PodRef.Name] = pod
+	}
+	return stats, nil
+}
@@ -0,0 +1,205 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// prometheusProxy is the API server proxy path of the Prometheus of the monitoring stack, which scrapes the
+// kubevirt_vmi_* metrics of virt-handler
+const prometheusProxy = "/api/v1/namespaces/monitoring/services/monitoring-kube-prometheus-prometheus:9090/proxy"
+
+// Bounds of the time range of VM metrics. The range is split into about metricsPoints points, and rates are taken
+// over at least minRateWindow so that they span several scrapes.
+const (
+	defaultMetricsRange = time.Hour
+	maxMetricsRange     = 30 * 24 * time.Hour
+	minMetricsRange     = 5 * time.Minute
+	metricsPoints       = 120
+	minMetricsStep      = 15 * time.Second
+	minRateWindow       = 2 * time.Minute
+)
+
+// vmMetricQuery is the PromQL query of a VM metric, with the selector of the VM at %[1]s and the rate window at %[2]s.
+// Disk and network metrics have a series per device, named by the device label.
+type vmMetricQuery struct {
+	metric      string
+	unit        string
+	deviceLabel string
+	query       string
+}
+
+// vmMetricQueries are the queries of the metrics GetMetrics returns
+var vmMetricQueries = []vmMetricQuery{
+	{types.VMMetricCPU, "cores", "", `sum(rate(kubevirt_vmi_cpu_usage_seconds_total{%[1]s}[%[2]s]))`},
+	{types.VMMetricMemory, "bytes", "", `sum(kubevirt_vmi_memory_used_bytes{%[1]s})`},
+	{types.VMMetricDiskRead, "bytes/s", "drive", `sum by (drive) (rate(kubevirt_vmi_storage_read_traffic_bytes_total{%[1]s}[%[2]s]))`},
+	{types.VMMetricDiskWrite, "bytes/s", "drive", `sum by (drive) (rate(kubevirt_vmi_storage_write_traffic_bytes_total{%[1]s}[%[2]s]))`},
+	{types.VMMetricNetworkReceive, "bytes/s", "interface", `sum by (interface) (rate(kubevirt_vmi_network_receive_bytes_total{%[1]s}[%[2]s]))`},
+	{types.VMMetricNetworkTransmit, "bytes/s", "interface", `sum by (interface) (rate(kubevirt_vmi_network_transmit_bytes_total{%[1]s}[%[2]s]))`},
+}
+
+// prometheusRangeResponse is the part of a Prometheus range query response govnocloud reads
+type prometheusRangeResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+	Data   struct {
+		Result []struct {
+			Metric map[string]string `json:"metric"`
+			Values [][2]any          `json:"values"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// parseMetricsRange parses the time range of VM metrics, a duration such as 90m or 6h or a number of days such as 7d
+func parseMetricsRange(value string) (time.Duration, error) {
+	if value == "" {
+		return defaultMetricsRange, nil
+	}
+	var window time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid range %s", value)
+		}
+		window = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if window, err = time.ParseDuration(value); err != nil {
+			return 0, fmt.Errorf("invalid range %s", value)
+		}
+	}
+	if window < minMetricsRange || window > maxMetricsRange {
+		return 0, fmt.Errorf("range %s must be between %s and 30d", value, minMetricsRange)
+	}
+	return window, nil
+}
+
+// GetMetrics returns the CPU, memory, disk and network usage of a VM over the last window from Prometheus. The
+// series only select the VM's namespace, so a user sees nothing of VMs elsewhere.
+func (m *VMManager) GetMetrics(name, namespace string, window time.Duration) (types.VMMetrics, error) {
+	var resource types.GovnoVM
+	found, err := getObject(m.kubectl, govnoVMResource, namespace, name, &resource)
+	if err != nil {
+		return types.VMMetrics{}, err
+	}
+	if !found {
+		return types.VMMetrics{}, fmt.Errorf("VM %s not found in namespace %s", name, namespace)
+	}
+	step := max((window / metricsPoints).Truncate(time.Second), minMetricsStep)
+	end := time.Now().Truncate(step)
+	metrics := types.VMMetrics{
+		Name:      name,
+		Namespace: namespace,
+		Start:     end.Add(-window),
+		End:       end,
+		Step:      int(step.Seconds()),
+		Series:    []types.VMMetricSeries{},
+	}
+	selector := fmt.Sprintf("namespace=%q,name=%q", namespace, name)
+	rateWindow := fmt.Sprintf("%ds", int(max(step, minRateWindow).Seconds()))
+	for _, query := range vmMetricQueries {
+		series, err := m.queryPrometheusRange(fmt.Sprintf(query.query, selector, rateWindow), metrics.Start, end, step)
+		if err != nil {
+			return types.VMMetrics{}, fmt.Errorf("failed to query %s of VM %s: %w", query.metric, name, err)
+		}
+		for _, s := range series {
+			s.Metric, s.Unit = query.metric, query.unit
+			if query.deviceLabel != "" {
+				s.Device = s.labels[query.deviceLabel]
+			}
+			metrics.Series = append(metrics.Series, s.VMMetricSeries)
+		}
+	}
+	return metrics, nil
+}
+
+// prometheusSeries is a series of a Prometheus range query with its labels
+type prometheusSeries struct {
+	types.VMMetricSeries
+	labels map[string]string
+}
+
+// queryPrometheusRange runs a range query against Prometheus through the API server proxy. Points without a
+// finite value are dropped, JSON can't carry them.
+func (m *VMManager) queryPrometheusRange(query string, start, end time.Time, step time.Duration) ([]prometheusSeries, error) {
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	params.Set("step", strconv.Itoa(int(step.Seconds())))
+	out, err := m.kubectl.Run("get", "--raw", prometheusProxy+"/api/v1/query_range?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prometheus: %s %w", out, err)
+	}
+	var response prometheusRangeResponse
+	if err := json.Unmarshal(out, &response); err != nil {
+		return nil, fmt.Errorf("failed to parse prometheus response: %w", err)
+	}
+	if response.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed: %s", response.Error)
+	}
+	series := make([]prometheusSeries, 0, len(response.Data.Result))
+	for _, result := range response.Data.Result {
+		s := prometheusSeries{
+			VMMetricSeries: types.VMMetricSeries{Points: make([]types.VMMetricPoint, 0, len(result.Values))},
+			labels:         result.Metric,
+		}
+		for _, sample := range result.Values {
+			at, ok := sample[0].(float64)
+			if !ok {
+				continue
+			}
+			text, ok := sample[1].(string)
+			if !ok {
+				continue
+			}
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+				continue
+			}
+			s.Points = append(s.Points, types.VMMetricPoint{Time: int64(at), Value: value})
+		}
+		series = append(series, s)
+	}
+	return series, nil
+}
+
+// GetVMMetricsHandler handles requests for the usage metrics of a VM over the range query parameter, 1h by default
+func GetVMMetricsHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	window, err := parseMetricsRange(c.Query("range"))
+	if err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	metrics, err := vmManager.forRequest(c).GetMetrics(name, namespace, window)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get VM metrics: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, metrics)
+}
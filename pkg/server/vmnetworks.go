@@ -0,0 +1,210 @@
+package server
+
+import (
+	"crypto/rand"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// maxInterfaceNameLength is the longest interface name Linux guests accept
+const maxInterfaceNameLength = 15
+
+// generateMAC returns a random locally administered unicast MAC address
+func generateMAC() (string, error) {
+	mac := make([]byte, 6)
+	if _, err := rand.Read(mac); err != nil {
+		return "", fmt.Errorf("failed to generate MAC address: %w", err)
+	}
+	mac[0] = mac[0]&0xfc | 0x02
+	return net.HardwareAddr(mac).String(), nil
+}
+
+// validateNAD validates the NetworkAttachmentDefinition of a nad network of a VM in namespace, [namespace/]name. A
+// definition of another namespace is refused, the server attaches the VM with its own credentials.
+func validateNAD(network types.VMNetwork, namespace string) error {
+	if network.NAD == "" {
+		return fmt.Errorf("network %s needs a NetworkAttachmentDefinition", network.Name)
+	}
+	name := network.NAD
+	if nadNamespace, nadName, ok := strings.Cut(network.NAD, "/"); ok {
+		if nadNamespace != namespace {
+			return fmt.Errorf("NetworkAttachmentDefinition %s of network %s must be in namespace %s", network.NAD, network.Name, namespace)
+		}
+		name = nadName
+	}
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("invalid NetworkAttachmentDefinition %s of network %s: %s", network.NAD, network.Name, strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// normalizeVMNetworks validates the networks of a VM in namespace, defaults their addressing to DHCP and generates
+// missing MACs
+func normalizeVMNetworks(networks []types.VMNetwork, namespace string) ([]types.VMNetwork, error) {
+	if len(networks) == 0 {
+		return nil, nil
+	}
+	names := make(map[string]bool, len(networks))
+	pods := 0
+	normalized := make([]types.VMNetwork, 0, len(networks))
+	for _, network := range networks {
+		if errs := validation.IsDNS1123Label(network.Name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid network name %s: %s", network.Name, strings.Join(errs, ", "))
+		}
+		if len(network.Name) > maxInterfaceNameLength {
+			return nil, fmt.Errorf("network name %s is longer than %d characters", network.Name, maxInterfaceNameLength)
+		}
+		if names[network.Name] {
+			return nil, fmt.Errorf("duplicate network %s", network.Name)
+		}
+		names[network.Name] = true
+		switch network.Type {
+		case types.VMNetworkPod:
+			if pods++; pods > 1 {
+				return nil, fmt.Errorf("a VM can only have one pod network")
+			}
+			if network.Addressing == types.VMAddressingStatic {
+				return nil, fmt.Errorf("pod network %s is addressed by DHCP only", network.Name)
+			}
+		case types.VMNetworkBridge:
+		case types.VMNetworkNAD:
+			if err := validateNAD(network, namespace); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("invalid type %q of network %s, must be pod, bridge or nad", network.Type, network.Name)
+		}
+		if network.Type != types.VMNetworkNAD && network.NAD != "" {
+			return nil, fmt.Errorf("network %s of type %s can't name a NetworkAttachmentDefinition", network.Name, network.Type)
+		}
+		switch network.Addressing {
+		case "", types.VMAddressingDHCP:
+			network.Addressing = types.VMAddressingDHCP
+			if network.Address != "" || network.Gateway != "" || len(network.DNS) > 0 {
+				return nil, fmt.Errorf("network %s is addressed by DHCP, address, gateway and dns need static addressing", network.Name)
+			}
+		case types.VMAddressingStatic:
+			if _, _, err := net.ParseCIDR(network.Address); err != nil {
+				return nil, fmt.Errorf("invalid address %q of network %s, must be in CIDR notation", network.Address, network.Name)
+			}
+			if network.Gateway != "" && net.ParseIP(network.Gateway) == nil {
+				return nil, fmt.Errorf("invalid gateway %s of network %s", network.Gateway, network.Name)
+			}
+			for _, dns := range network.DNS {
+				if net.ParseIP(dns) == nil {
+					return nil, fmt.Errorf("invalid dns %s of network %s", dns, network.Name)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("invalid addressing %q of network %s, must be dhcp or static", network.Addressing, network.Name)
+		}
+		if network.MAC == "" {
+			mac, err := generateMAC()
+			if err != nil {
+				return nil, err
+			}
+			network.MAC = mac
+		} else if _, err := net.ParseMAC(network.MAC); err != nil {
+			return nil, fmt.Errorf("invalid mac %s of network %s", network.MAC, network.Name)
+		}
+		normalized = append(normalized, network)
+	}
+	return normalized, nil
+}
+
+// hasStaticNetwork reports whether a VM has a statically addressed network
+func hasStaticNetwork(networks []types.VMNetwork) bool {
+	for _, network := range networks {
+		if network.Addressing == types.VMAddressingStatic {
+			return true
+		}
+	}
+	return false
+}
+
+// multusNetworkName returns the Multus network name of a bridge or nad network of a VM in namespace
+func multusNetworkName(network types.VMNetwork, namespace string) string {
+	if network.Type == types.VMNetworkBridge {
+		return types.BridgeNetworkNamespace + "/" + types.BridgeNetworkName
+	}
+	if strings.Contains(network.NAD, "/") {
+		return network.NAD
+	}
+	return namespace + "/" + network.NAD
+}
+
+// generateNetworks generates the interfaces and networks of the networks of a GovnoVM, with interfaces of model
+// unless it is empty. A VM without networks gets none of them and KubeVirt attaches it to the pod network, unless
+// it needs another interface model. VMs on the LAN bridge are kept on bridged nodes by their effective node selector.
+func generateNetworks(networks []types.VMNetwork, namespace, model string) (string, string) {
+	if len(networks) == 0 {
+		if model == "" {
+			return "", ""
+		}
+		networks = []types.VMNetwork{{Name: "default", Type: types.VMNetworkPod}}
+	}
+	interfaces, sources := `
+          interfaces:`, `
+      networks:`
+	for _, network := range networks {
+		binding := "bridge"
+		if network.Type == types.VMNetworkPod {
+			binding = "masquerade"
+		}
+		interfaces += fmt.Sprintf(`
+          - name: %s
+            %s: {}`, network.Name, binding)
+		if network.MAC != "" {
+			interfaces += fmt.Sprintf(`
+            macAddress: "%s"`, network.MAC)
+		}
+		if model != "" {
+			interfaces += fmt.Sprintf(`
+            model: %s`, model)
+		}
+		if network.Type == types.VMNetworkPod {
+			sources += fmt.Sprintf(`
+      - name: %s
+        pod: {}`, network.Name)
+			continue
+		}
+		sources += fmt.Sprintf(`
+      - name: %s
+        multus:
+          networkName: %q`, network.Name, multusNetworkName(network, namespace))
+	}
+	return interfaces, sources
+}
+
+// generateNetworkData generates the cloud-init network-data configuring the interfaces of a VM by their MAC
+func generateNetworkData(networks []types.VMNetwork) (string, error) {
+	ethernets := make(map[string]any, len(networks))
+	for _, network := range networks {
+		ethernet := map[string]any{
+			"match":    map[string]string{"macaddress": network.MAC},
+			"set-name": network.Name,
+		}
+		if network.Addressing == types.VMAddressingStatic {
+			ethernet["addresses"] = []string{network.Address}
+			if network.Gateway != "" {
+				ethernet["routes"] = []map[string]string{{"to": "default", "via": network.Gateway}}
+			}
+			if len(network.DNS) > 0 {
+				ethernet["nameservers"] = map[string]any{"addresses": network.DNS}
+			}
+		} else {
+			ethernet["dhcp4"] = true
+		}
+		ethernets[network.Name] = ethernet
+	}
+	data, err := yaml.Marshal(map[string]any{"version": 2, "ethernets": ethernets})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal network-data: %w", err)
+	}
+	return string(data), nil
+}
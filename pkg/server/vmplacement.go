@@ -0,0 +1,259 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// The virt-launcher pods of VMs in an affinity or anti-affinity group carry a label of the group, which the
+// pod affinity terms of the other VMs of the group select
+const (
+	affinityGroupLabelPrefix     = "affinity." + types.CRDGroup + "/"
+	antiAffinityGroupLabelPrefix = "anti-affinity." + types.CRDGroup + "/"
+)
+
+// validateVMPlacement checks the node selector, groups, tolerations and hugepage size of a VM placement
+func validateVMPlacement(placement *types.VMPlacement) error {
+	if placement == nil {
+		return nil
+	}
+	for key, value := range placement.NodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			return fmt.Errorf("invalid node selector label %s: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			return fmt.Errorf("invalid node selector value %s of label %s: %s", value, key, strings.Join(errs, ", "))
+		}
+	}
+	for _, group := range append(slices.Clone(placement.AffinityGroups), placement.AntiAffinityGroups...) {
+		if errs := validation.IsDNS1123Label(group); len(errs) > 0 {
+			return fmt.Errorf("invalid group %s: %s", group, strings.Join(errs, ", "))
+		}
+	}
+	for _, toleration := range placement.Tolerations {
+		switch toleration.Operator {
+		case "", "Equal":
+			if toleration.Key == "" {
+				return fmt.Errorf("a toleration without key needs the Exists operator")
+			}
+		case "Exists":
+			if toleration.Value != "" {
+				return fmt.Errorf("toleration %s with the Exists operator can't have a value", toleration.Key)
+			}
+		default:
+			return fmt.Errorf("invalid operator %q of toleration %s, must be Equal or Exists", toleration.Operator, toleration.Key)
+		}
+		switch toleration.Effect {
+		case "", "NoSchedule", "PreferNoSchedule", "NoExecute":
+		default:
+			return fmt.Errorf("invalid effect %q of toleration %s, must be NoSchedule, PreferNoSchedule or NoExecute",
+				toleration.Effect, toleration.Key)
+		}
+	}
+	switch placement.Hugepages {
+	case "", types.VMHugepages2Mi, types.VMHugepages1Gi:
+	default:
+		return fmt.Errorf("invalid hugepages %q, must be %s or %s", placement.Hugepages, types.VMHugepages2Mi, types.VMHugepages1Gi)
+	}
+	return nil
+}
+
+// pinnedResources reports whether a GovnoVM has dedicated CPUs or hugepages
+func pinnedResources(spec types.GovnoVMSpec) bool {
+	return spec.Placement != nil && (spec.Placement.DedicatedCPU || spec.Placement.Hugepages != "")
+}
+
+// inlineResources reports whether the CPU and memory of a GovnoVM are inlined into its VirtualMachine instead of
+// referencing the instancetype of its size. KubeVirt rejects CPU and memory settings next to an instancetype, so
+// VMs with dedicated CPUs or hugepages inline the values of their size.
+func inlineResources(spec types.GovnoVMSpec) bool {
+	return customSize(spec) || pinnedResources(spec)
+}
+
+// validateHugepages checks that the memory of a GovnoVM backed by hugepages is a multiple of the page size
+func validateHugepages(spec types.GovnoVMSpec) error {
+	if spec.Placement == nil || spec.Placement.Hugepages != types.VMHugepages1Gi {
+		return nil
+	}
+	_, ram, err := vmResources(spec)
+	if err != nil {
+		return err
+	}
+	if ram%1024 != 0 {
+		return fmt.Errorf("VM ram %dMi is not a multiple of its %s hugepages", ram, spec.Placement.Hugepages)
+	}
+	return nil
+}
+
+// effectiveNodeSelector returns the node selector of a GovnoVM, its own labels and the bridge label when it sits on the LAN bridge
+func effectiveNodeSelector(spec types.GovnoVMSpec) map[string]string {
+	selector := map[string]string{}
+	if spec.Placement != nil {
+		maps.Copy(selector, spec.Placement.NodeSelector)
+	}
+	for _, network := range spec.Networks {
+		if network.Type == types.VMNetworkBridge {
+			selector[types.BridgeNodeLabel] = "true"
+		}
+	}
+	if len(selector) == 0 {
+		return nil
+	}
+	return selector
+}
+
+// effectivePlacement returns the placement a GovnoVM is scheduled with, nil when it can run anywhere
+func effectivePlacement(spec types.GovnoVMSpec) *types.VMPlacement {
+	placement := types.VMPlacement{}
+	if spec.Placement != nil {
+		placement = *spec.Placement
+	}
+	placement.NodeSelector = effectiveNodeSelector(spec)
+	if placement.NodeSelector == nil && len(placement.AffinityGroups) == 0 && len(placement.AntiAffinityGroups) == 0 &&
+		len(placement.Tolerations) == 0 && !placement.DedicatedCPU && placement.Hugepages == "" {
+		return nil
+	}
+	return &placement
+}
+
+// generateDomainResources generates the inlined CPU and memory of the domain of a VM, nothing for a VM referencing an instancetype
+func generateDomainResources(spec types.GovnoVMSpec) (string, error) {
+	if !inlineResources(spec) {
+		return "", nil
+	}
+	cpu, ram, err := vmResources(spec)
+	if err != nil {
+		return "", err
+	}
+	resources := fmt.Sprintf(`
+        cpu:
+          sockets: %d`, cpu)
+	if spec.Placement != nil && spec.Placement.DedicatedCPU {
+		resources += `
+          dedicatedCpuPlacement: true`
+	}
+	resources += fmt.Sprintf(`
+        memory:
+          guest: %dMi`, ram)
+	if spec.Placement != nil && spec.Placement.Hugepages != "" {
+		resources += fmt.Sprintf(`
+          hugepages:
+            pageSize: %s`, spec.Placement.Hugepages)
+	}
+	// dedicated CPUs need the Guaranteed QoS class, so the memory request equals the limit
+	if spec.Placement != nil && spec.Placement.DedicatedCPU {
+		resources += fmt.Sprintf(`
+        resources:
+          requests:
+            memory: %[1]dMi
+          limits:
+            memory: %[1]dMi`, ram)
+	}
+	return resources, nil
+}
+
+// generateGroupLabels generates the template labels of the affinity and anti-affinity groups of a VM
+func generateGroupLabels(placement *types.VMPlacement) string {
+	if placement == nil {
+		return ""
+	}
+	labels := ""
+	for _, group := range placement.AffinityGroups {
+		labels += fmt.Sprintf(`
+        %s%s: "true"`, affinityGroupLabelPrefix, group)
+	}
+	for _, group := range placement.AntiAffinityGroups {
+		labels += fmt.Sprintf(`
+        %s%s: "true"`, antiAffinityGroupLabelPrefix, group)
+	}
+	return labels
+}
+
+// generatePodAffinity generates the pod affinity or anti-affinity of a VM to the pods labeled with groups, one term
+// per group on the hostname topology, required or weighted preferences
+func generatePodAffinity(kind, labelPrefix string, groups []string, preferred bool) string {
+	if len(groups) == 0 {
+		return ""
+	}
+	if !preferred {
+		affinity := fmt.Sprintf(`
+        %s:
+          requiredDuringSchedulingIgnoredDuringExecution:`, kind)
+		for _, group := range groups {
+			affinity += fmt.Sprintf(`
+          - topologyKey: %s
+            labelSelector:
+              matchExpressions:
+              - key: %s%s
+                operator: Exists`, nodeHostnameLabel, labelPrefix, group)
+		}
+		return affinity
+	}
+	affinity := fmt.Sprintf(`
+        %s:
+          preferredDuringSchedulingIgnoredDuringExecution:`, kind)
+	for _, group := range groups {
+		affinity += fmt.Sprintf(`
+          - weight: 100
+            podAffinityTerm:
+              topologyKey: %s
+              labelSelector:
+                matchExpressions:
+                - key: %s%s
+                  operator: Exists`, nodeHostnameLabel, labelPrefix, group)
+	}
+	return affinity
+}
+
+// generateScheduling generates the node selector, pod affinities and tolerations of the template of a VM
+func generateScheduling(spec types.GovnoVMSpec) string {
+	scheduling := ""
+	if selector := effectiveNodeSelector(spec); len(selector) > 0 {
+		scheduling += `
+      nodeSelector:`
+		for _, key := range slices.Sorted(maps.Keys(selector)) {
+			scheduling += fmt.Sprintf(`
+        %q: %q`, key, selector[key])
+		}
+	}
+	placement := spec.Placement
+	if placement == nil {
+		return scheduling
+	}
+	affinity := generatePodAffinity("podAffinity", affinityGroupLabelPrefix, placement.AffinityGroups, placement.Preferred) +
+		generatePodAffinity("podAntiAffinity", antiAffinityGroupLabelPrefix, placement.AntiAffinityGroups, placement.Preferred)
+	if affinity != "" {
+		scheduling += `
+      affinity:` + affinity
+	}
+	if len(placement.Tolerations) > 0 {
+		scheduling += `
+      tolerations:`
+		for _, toleration := range placement.Tolerations {
+			operator := toleration.Operator
+			if operator == "" {
+				operator = "Equal"
+			}
+			scheduling += fmt.Sprintf(`
+      - operator: %s`, operator)
+			if toleration.Key != "" {
+				scheduling += fmt.Sprintf(`
+        key: %q`, toleration.Key)
+			}
+			if toleration.Value != "" {
+				scheduling += fmt.Sprintf(`
+        value: %q`, toleration.Value)
+			}
+			if toleration.Effect != "" {
+				scheduling += fmt.Sprintf(`
+        effect: %s`, toleration.Effect)
+			}
+		}
+	}
+	return scheduling
+}
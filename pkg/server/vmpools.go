@@ -0,0 +1,306 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
+)
+
+// vmPoolResource is the KubeVirt VirtualMachinePool resource as understood by kubectl
+const vmPoolResource = "virtualmachinepools.pool.kubevirt.io"
+
+// Labels of the pools created from templates, of their VMs and of their generated Secrets
+const (
+	vmPoolLabel     = types.CRDGroup + "/pool"
+	vmTemplateLabel = types.CRDGroup + "/template"
+)
+
+// Suffixes of the generated Secrets of a pool. The Secrets of VMs end in -cloudinit or -sysprep, so a pool's never
+// takes over one of a VM.
+const (
+	poolCloudInitSecretSuffix = "-pool-userdata"
+	poolSysprepSecretSuffix   = "-pool-unattend"
+)
+
+// poolRootDisk is the DataVolume template of the root disks of pool VMs, KubeVirt suffixes it per VM
+const poolRootDisk = "rootdisk"
+
+// vmPoolObject is the part of a VirtualMachinePool govnocloud reads
+type vmPoolObject struct {
+	Metadata struct {
+		Name      string            `json:"name"`
+		Namespace string            `json:"namespace"`
+		Labels    map[string]string `json:"labels"`
+	} `json:"metadata"`
+	Spec struct {
+		Replicas int `json:"replicas"`
+	} `json:"spec"`
+	Status struct {
+		ReadyReplicas int `json:"readyReplicas"`
+	} `json:"status"`
+}
+
+// vmPoolObjectList is a list of VirtualMachinePools
+type vmPoolObjectList struct {
+	Items []vmPoolObject `json:"items"`
+}
+
+// generatePoolManifest generates the VirtualMachinePool of count VMs of a GovnoVM, whose VirtualMachine template is the
+// one the GovnoVM would get with the root disk turned into a DataVolume template
+func (m *VMManager) generatePoolManifest(resource *types.GovnoVM, template string, count int) (string, error) {
+	vmManifest, err := m.generateManifest(resource, poolRootDisk)
+	if err != nil {
+		return "", err
+	}
+	var vm struct {
+		Spec map[string]any `json:"spec"`
+	}
+	if err := yaml.Unmarshal([]byte(vmManifest), &vm); err != nil {
+		return "", fmt.Errorf("failed to parse VM manifest: %w", err)
+	}
+	vmTemplate, ok := vm.Spec["template"].(map[string]any)
+	if !ok {
+		return "", fmt.Errorf("VM manifest has no template")
+	}
+	metadata, _ := vmTemplate["metadata"].(map[string]any)
+	if metadata == nil {
+		metadata = map[string]any{}
+		vmTemplate["metadata"] = metadata
+	}
+	labels, _ := metadata["labels"].(map[string]any)
+	if labels == nil {
+		labels = map[string]any{}
+		metadata["labels"] = labels
+	}
+	labels[vmPoolLabel] = resource.Name
+
+	image, err := lookupImage(resource.Spec.Image, resource.Namespace)
+	if err != nil {
+		return "", err
+	}
+	size, err := rootDiskSize(resource.Spec)
+	if err != nil {
+		return "", err
+	}
+	dvManifest, err := dataVolumeManifest(poolRootDisk, resource.Namespace, nil, imageSource(image), size)
+	if err != nil {
+		return "", err
+	}
+	var dataVolume struct {
+		Spec map[string]any `json:"spec"`
+	}
+	if err := json.Unmarshal([]byte(dvManifest), &dataVolume); err != nil {
+		return "", fmt.Errorf("failed to parse data volume: %w", err)
+	}
+	vm.Spec["dataVolumeTemplates"] = []any{map[string]any{
+		"metadata": map[string]any{"name": poolRootDisk},
+		"spec":     dataVolume.Spec,
+	}}
+
+	poolLabels := map[string]string{vmPoolLabel: resource.Name, vmTemplateLabel: template}
+	pool := map[string]any{
+		"apiVersion": "pool.kubevirt.io/v1alpha1",
+		"kind":       "VirtualMachinePool",
+		"metadata": map[string]any{
+			"name":      resource.Name,
+			"namespace": resource.Namespace,
+			"labels":    poolLabels,
+		},
+		"spec": map[string]any{
+			"replicas": count,
+			"selector": map[string]any{"matchLabels": map[string]string{vmPoolLabel: resource.Name}},
+			"virtualMachineTemplate": map[string]any{
+				"metadata": map[string]any{"labels": poolLabels},
+				"spec":     vm.Spec,
+			},
+		},
+	}
+	manifest, err := json.Marshal(pool)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal pool: %w", err)
+	}
+	return string(manifest), nil
+}
+
+// CreatePool creates a VirtualMachinePool of count VMs of a template. The VMs share one cloud-init or sysprep Secret,
+// the cloud-init one generated unless the template names one, and are managed by KubeVirt rather than as GovnoVMs.
+func (m *VMManager) CreatePool(template types.VMTemplate, name string, count int) (types.VMBatchResult, error) {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return types.VMBatchResult{}, fmt.Errorf("invalid pool name %s: %s", name, strings.Join(errs, ", "))
+	}
+	var existing struct{}
+	found, err := getObject(m.kubectl, vmPoolResource, template.Namespace, name, &existing)
+	if err != nil {
+		return types.VMBatchResult{}, err
+	}
+	if found {
+		return types.VMBatchResult{}, fmt.Errorf("pool %s already exists", name)
+	}
+	if err := m.checkPoolNames(template.Namespace, name, count); err != nil {
+		return types.VMBatchResult{}, err
+	}
+	vm := template.VM
+	vm.Name, vm.Namespace = name, template.Namespace
+	resource := m.generateResource(template.Namespace, vm)
+	if err := validateVMResources(resource.Spec); err != nil {
+		return types.VMBatchResult{}, err
+	}
+	if !inlineResources(resource.Spec) {
+		if err := ensureInstancetype(m.kubectl, resource.Spec.Size); err != nil {
+			return types.VMBatchResult{}, err
+		}
+	}
+	if vm.CloudInitSecret != "" {
+		if resource.Spec.NetworkData, err = cloudInitSecretHasNetworkData(m.kubectl, template.Namespace, vm.CloudInitSecret); err != nil {
+			return types.VMBatchResult{}, err
+		}
+	}
+	image, err := lookupImage(vm.Image, template.Namespace)
+	if err != nil {
+		return types.VMBatchResult{}, err
+	}
+	if err := validateProvisioning(vm, image); err != nil {
+		return types.VMBatchResult{}, err
+	}
+	setProvisioning(&resource, vm, image)
+	if vm.CloudInitSecret == "" && resource.Spec.CloudInitSecret != "" {
+		resource.Spec.CloudInitSecret = name + poolCloudInitSecretSuffix
+	}
+	if resource.Spec.SysprepSecret != "" {
+		resource.Spec.SysprepSecret = name + poolSysprepSecretSuffix
+	}
+	manifest, err := m.generatePoolManifest(&resource, template.Name, count)
+	if err != nil {
+		return types.VMBatchResult{}, err
+	}
+	if resource.Spec.SysprepSecret != "" {
+		secret, err := generateSysprepSecret(template.Namespace, resource.Spec.SysprepSecret, vm.Sysprep, map[string]string{vmPoolLabel: name})
+		if err != nil {
+			return types.VMBatchResult{}, err
+		}
+		if out, err := applyManifest(m.kubectl, secret); err != nil {
+			return types.VMBatchResult{}, fmt.Errorf("failed to create sysprep secret of pool %s: %s %w", name, out, err)
+		}
+	}
+	if vm.CloudInitSecret == "" && resource.Spec.CloudInitSecret != "" {
+		// the pool's Secrets are found by this label when the pool is deleted
+		secret, err := generateCloudInitSecret(template.Namespace, resource.Spec.CloudInitSecret, vm, map[string]string{vmPoolLabel: name})
+		if err != nil {
+			return types.VMBatchResult{}, err
+		}
+		if out, err := applyManifest(m.kubectl, secret); err != nil {
+			return types.VMBatchResult{}, fmt.Errorf("failed to create cloud-init secret of pool %s: %s %w", name, out, err)
+		}
+	}
+	m.logger.Debug("generated VM pool manifest", "manifest", manifest)
+	if out, err := applyManifest(m.kubectl, manifest); err != nil {
+		return types.VMBatchResult{}, fmt.Errorf("failed to create pool %s: %s %w", name, out, err)
+	}
+	result := types.VMBatchResult{Template: template.Name, Pool: name, Created: count, VMs: make([]types.VMBatchItem, count)}
+	for i := range result.VMs {
+		result.VMs[i] = types.VMBatchItem{Name: fmt.Sprintf("%s-%d", name, i), Status: types.VMBatchCreated}
+	}
+	return result, nil
+}
+
+// checkPoolNames refuses a pool of count VMs when a VM has its name or the name of one of its VMs, a GovnoVM would
+// take over the pool's VirtualMachine of the same name
+func (m *VMManager) checkPoolNames(namespace, name string, count int) error {
+	names := make(map[string]bool, count+1)
+	names[name] = true
+	for i := range count {
+		names[fmt.Sprintf("%s-%d", name, i)] = true
+	}
+	var resources types.GovnoVMList
+	if err := listObjects(m.kubectl, govnoVMResource, namespace, &resources); err != nil {
+		return err
+	}
+	for _, resource := range resources.Items {
+		if names[resource.Name] {
+			return fmt.Errorf("VM %s already exists, name the pool differently", resource.Name)
+		}
+	}
+	var objects virtualMachineList
+	if err := listObjects(m.kubectl, virtualMachineResource, namespace, &objects); err != nil {
+		return err
+	}
+	for _, object := range objects.Items {
+		if names[object.Metadata.Name] {
+			return fmt.Errorf("VM %s already exists, name the pool differently", object.Metadata.Name)
+		}
+	}
+	return nil
+}
+
+// ListPools lists the VirtualMachinePools of a namespace
+func (m *VMManager) ListPools(namespace string) ([]types.VMPool, error) {
+	var list vmPoolObjectList
+	if err := listObjects(m.kubectl, vmPoolResource, namespace, &list); err != nil {
+		return nil, err
+	}
+	pools := make([]types.VMPool, 0, len(list.Items))
+	for _, item := range list.Items {
+		pools = append(pools, types.VMPool{
+			Name:          item.Metadata.Name,
+			Namespace:     item.Metadata.Namespace,
+			Template:      item.Metadata.Labels[vmTemplateLabel],
+			Replicas:      item.Spec.Replicas,
+			ReadyReplicas: item.Status.ReadyReplicas,
+		})
+	}
+	return pools, nil
+}
+
+// DeletePool deletes a VirtualMachinePool with its VMs, their disks and its generated Secrets
+func (m *VMManager) DeletePool(name, namespace string) error {
+	var existing struct{}
+	found, err := getObject(m.kubectl, vmPoolResource, namespace, name, &existing)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("pool %s not found in namespace %s", name, namespace)
+	}
+	if out, err := m.kubectl.Run("delete", vmPoolResource, name, "-n", namespace, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete pool %s: %s %w", name, out, err)
+	}
+	if out, err := m.kubectl.Run("delete", "secret", "-n", namespace, "-l", vmPoolLabel+"="+name, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("failed to delete secrets of pool %s: %s %w", name, out, err)
+	}
+	return nil
+}
+
+// ListVMPoolsHandler handles requests to list the VM pools of a namespace
+func ListVMPoolsHandler(c *gin.Context) {
+	_, namespace, ok := vmTemplateRequest(c)
+	if !ok {
+		return
+	}
+	pools, err := vmManager.forRequest(c).ListPools(namespace)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list pools: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, pools)
+}
+
+// DeleteVMPoolHandler handles VM pool deletion requests
+func DeleteVMPoolHandler(c *gin.Context) {
+	_, namespace, ok := vmTemplateRequest(c)
+	if !ok {
+		return
+	}
+	name := c.Param("name")
+	if err := vmManager.forRequest(c).DeletePool(name, namespace); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete pool: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM pool deleted", "name", name, "namespace", namespace)
+	respondWithSuccess(c, gin.H{"message": "pool deleted successfully"})
+}
@@ -0,0 +1,254 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// vmNameLabel is the label KubeVirt puts on the virt-launcher pod of a VirtualMachine
+const vmNameLabel = "vm.kubevirt.io/name"
+
+// vmServiceName returns the name of the Service exposing the ports of a VM
+func vmServiceName(vmName string) string {
+	return vmName + "-ports"
+}
+
+// normalizeVMPorts validates the ports of a VM and fills in defaults, dropping the fields only reported by GetVM
+func normalizeVMPorts(ports []types.VMPort, serviceType string) ([]types.VMPort, string, error) {
+	if len(ports) == 0 {
+		return nil, "", nil
+	}
+	switch serviceType {
+	case "":
+		serviceType = types.VMServiceTypeNodePort
+	case types.VMServiceTypeNodePort, types.VMServiceTypeLoadBalancer:
+	default:
+		return nil, "", fmt.Errorf("invalid service type: %s", serviceType)
+	}
+	normalized := make([]types.VMPort, 0, len(ports))
+	names := make(map[string]bool, len(ports))
+	for _, port := range ports {
+		if port.SourcePort < 1 || port.SourcePort > 65535 {
+			return nil, "", fmt.Errorf("invalid port: %d", port.SourcePort)
+		}
+		if port.DestinationPort == 0 {
+			port.DestinationPort = port.SourcePort
+		}
+		if port.DestinationPort < 1 || port.DestinationPort > 65535 {
+			return nil, "", fmt.Errorf("invalid destination port: %d", port.DestinationPort)
+		}
+		port.Protocol = strings.ToUpper(port.Protocol)
+		switch port.Protocol {
+		case "":
+			port.Protocol = string(corev1.ProtocolTCP)
+		case string(corev1.ProtocolTCP), string(corev1.ProtocolUDP):
+		default:
+			return nil, "", fmt.Errorf("invalid protocol: %s", port.Protocol)
+		}
+		if port.Name == "" {
+			port.Name = fmt.Sprintf("%s-%d", strings.ToLower(port.Protocol), port.DestinationPort)
+		}
+		if names[port.Name] {
+			return nil, "", fmt.Errorf("duplicate port name: %s", port.Name)
+		}
+		names[port.Name] = true
+		normalized = append(normalized, types.VMPort{
+			Name:            port.Name,
+			SourcePort:      port.SourcePort,
+			DestinationPort: port.DestinationPort,
+			Protocol:        port.Protocol,
+		})
+	}
+	return normalized, serviceType, nil
+}
+
+// generateServiceManifest generates the Service exposing the ports of a GovnoVM on its virt-launcher pod
+func (m *VMManager) generateServiceManifest(resource *types.GovnoVM) (string, error) {
+	service := corev1.Service{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      vmServiceName(resource.Name),
+			Namespace: resource.Namespace,
+			Labels:    map[string]string{vmLabel: resource.Name},
+		},
+		Spec: corev1.ServiceSpec{
+			Type:     corev1.ServiceType(resource.Spec.ServiceType),
+			Selector: map[string]string{vmNameLabel: resource.Name},
+		},
+	}
+	for _, port := range resource.Spec.Ports {
+		service.Spec.Ports = append(service.Spec.Ports, corev1.ServicePort{
+			Name:       port.Name,
+			Protocol:   corev1.Protocol(port.Protocol),
+			Port:       int32(port.DestinationPort),
+			TargetPort: intstr.FromInt32(int32(port.SourcePort)),
+		})
+	}
+	manifest, err := json.Marshal(service)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal service: %w", err)
+	}
+	return string(manifest), nil
+}
+
+// applyPorts creates or updates the Service of a GovnoVM, deleting it when the VM exposes no ports
+func (m *VMManager) applyPorts(resource *types.GovnoVM) error {
+	name := vmServiceName(resource.Name)
+	if len(resource.Spec.Ports) == 0 {
+		if out, err := m.kubectl.Run("delete", "service", name, "-n", resource.Namespace, "--ignore-not-found"); err != nil {
+			return fmt.Errorf("failed to delete service %s: %s %w", name, out, err)
+		}
+		return nil
+	}
+	manifest, err := m.generateServiceManifest(resource)
+	if err != nil {
+		return err
+	}
+	if out, err := applyManifest(m.kubectl, manifest); err != nil {
+		return fmt.Errorf("failed to apply service %s: %s %w", name, out, err)
+	}
+	return setOwner(m.kubectl, "services", resource.Namespace, name, govnoVMKind, resource.ObjectMeta)
+}
+
+// portsStatus fills in the node ports allocated for the ports of a VM and their endpoints, the LB address of a
+// LoadBalancer Service or the master for a NodePort one, as every node serves node ports
+func (m *VMManager) portsStatus(namespace, name string, ports []types.VMPort) ([]types.VMPort, error) {
+	if len(ports) == 0 {
+		return []types.VMPort{}, nil
+	}
+	var service corev1.Service
+	found, err := getObject(m.kubectl, "services", namespace, vmServiceName(name), &service)
+	if err != nil || !found {
+		return ports, err
+	}
+	host := ""
+	if ingress := service.Status.LoadBalancer.Ingress; len(ingress) > 0 {
+		host = ingress[0].IP
+		if host == "" {
+			host = ingress[0].Hostname
+		}
+	}
+	nodeHost := ""
+	if server != nil {
+		nodeHost = server.config.MasterHost
+	}
+	status := make([]types.VMPort, 0, len(ports))
+	for _, port := range ports {
+		for _, servicePort := range service.Spec.Ports {
+			if servicePort.Name != port.Name {
+				continue
+			}
+			port.NodePort = int(servicePort.NodePort)
+			switch {
+			case host != "":
+				port.Endpoint = net.JoinHostPort(host, strconv.Itoa(int(servicePort.Port)))
+			case port.NodePort != 0 && nodeHost != "":
+				port.Endpoint = net.JoinHostPort(nodeHost, strconv.Itoa(port.NodePort))
+			}
+		}
+		status = append(status, port)
+	}
+	return status, nil
+}
+
+// GetPorts returns the ports of a VM with their allocated node ports and endpoints
+func (m *VMManager) GetPorts(name, namespace string) (types.VMPorts, error) {
+	vm, err := m.GetVM(name, namespace)
+	if err != nil {
+		return types.VMPorts{}, err
+	}
+	return types.VMPorts{ServiceType: vm.ServiceType, Ports: vm.Ports}, nil
+}
+
+// SetPorts replaces the ports of a VM, the controller updates its Service
+func (m *VMManager) SetPorts(name, namespace string, ports types.VMPorts) error {
+	normalized, serviceType, err := normalizeVMPorts(ports.Ports, ports.ServiceType)
+	if err != nil {
+		return err
+	}
+	var resource types.GovnoVM
+	found, err := getObject(m.kubectl, govnoVMResource, namespace, name, &resource)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("VM %s not found in namespace %s", name, namespace)
+	}
+	spec := map[string]any{"ports": nil, "serviceType": nil}
+	if len(normalized) > 0 {
+		spec = map[string]any{"ports": normalized, "serviceType": serviceType}
+	}
+	if err := patchSpec(m.kubectl, govnoVMResource, namespace, name, spec); err != nil {
+		return fmt.Errorf("failed to set ports of VM %s: %w", name, err)
+	}
+	return nil
+}
+
+// GetVMPortsHandler handles requests for the ports of a VM
+func GetVMPortsHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	ports, err := vmManager.forRequest(c).GetPorts(name, namespace)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get VM ports: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, ports)
+}
+
+// SetVMPortsHandler handles requests to replace the ports of a VM
+func SetVMPortsHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	var ports types.VMPorts
+	if err := c.ShouldBindJSON(&ports); err != nil {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	if _, _, err := normalizeVMPorts(ports.Ports, ports.ServiceType); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := vmManager.forRequest(c).SetPorts(name, namespace, ports); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to set VM ports: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM ports set", "name", name, "namespace", namespace, "ports", len(ports.Ports))
+	respondWithSuccess(c, gin.H{"message": "VM ports set successfully"})
+}
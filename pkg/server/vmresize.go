@@ -0,0 +1,210 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Bounds of custom VM sizes, KubeVirt hotplugs memory in guests of at least 1Gi only
+const (
+	maxCustomCPU = 64
+	minCustomRAM = 1024
+	maxCustomRAM = 256 * 1024
+)
+
+// customSize reports whether a GovnoVM has custom CPU and RAM instead of a size of the catalog
+func customSize(spec types.GovnoVMSpec) bool {
+	return spec.CPU > 0 && spec.RAM > 0
+}
+
+// vmResources returns the vCPUs and memory in MiB of a GovnoVM, its custom values or those of its size
+func vmResources(spec types.GovnoVMSpec) (int, int, error) {
+	if customSize(spec) {
+		return spec.CPU, spec.RAM, nil
+	}
+	size, err := lookupVMSize(spec.Size)
+	if err != nil {
+		return 0, 0, err
+	}
+	return size.CPU, size.RAM, nil
+}
+
+// validateVMResources checks that a GovnoVM has a size of the catalog, retired or not, or valid custom CPU and RAM,
+// and memory its hugepages fit
+func validateVMResources(spec types.GovnoVMSpec) error {
+	if spec.CPU == 0 && spec.RAM == 0 {
+		if _, err := lookupVMSize(spec.Size); err != nil {
+			return err
+		}
+		return validateHugepages(spec)
+	}
+	if spec.CPU < 1 || spec.CPU > maxCustomCPU {
+		return fmt.Errorf("invalid VM cpu %d, must be between 1 and %d", spec.CPU, maxCustomCPU)
+	}
+	if spec.RAM < minCustomRAM || spec.RAM > maxCustomRAM {
+		return fmt.Errorf("invalid VM ram %dMi, must be between %dMi and %dMi", spec.RAM, minCustomRAM, maxCustomRAM)
+	}
+	return validateHugepages(spec)
+}
+
+// resizedSpec returns the spec of a GovnoVM after a resize. The root disk keeps its size, so a VM
+// without a disk override gets the disk of its old size pinned.
+func resizedSpec(spec types.GovnoVMSpec, resize types.VMResize) (types.GovnoVMSpec, error) {
+	if spec.Disk == "" {
+		disk, err := rootDiskSize(spec)
+		if err != nil {
+			return spec, err
+		}
+		spec.Disk = disk
+	}
+	switch {
+	case resize.Size != "" && (resize.CPU != 0 || resize.RAM != 0):
+		return spec, fmt.Errorf("either a size or cpu and ram must be given, not both")
+	case resize.Size != "":
+		if err := validateNewVMSize(resize.Size); err != nil {
+			return spec, err
+		}
+		spec.Size, spec.CPU, spec.RAM = resize.Size, 0, 0
+	default:
+		spec.Size, spec.CPU, spec.RAM = types.VMSizeCustom, resize.CPU, resize.RAM
+	}
+	return spec, validateVMResources(spec)
+}
+
+// canHotplug reports whether a running VirtualMachineInstance can take cpu vCPUs and ram MiB without a restart.
+// KubeVirt only hotplugs upwards, within the maximums the VMI was started with, and by migrating it.
+func canHotplug(vmi vmiObject, cpu, ram int) (bool, string) {
+	if migratable, message := liveMigratable(vmi); !migratable {
+		return false, "not live-migratable: " + message
+	}
+	domain := vmi.Spec.Domain
+	if domain.CPU == nil || domain.Memory == nil || domain.Memory.MaxGuest == "" {
+		return false, "started without hotplug support"
+	}
+	if cpu < domain.CPU.Sockets || cpu > domain.CPU.MaxSockets {
+		return false, fmt.Sprintf("cpu can only grow from %d up to %d", domain.CPU.Sockets, domain.CPU.MaxSockets)
+	}
+	guest, err := resource.ParseQuantity(domain.Memory.Guest)
+	if err != nil {
+		return false, fmt.Sprintf("invalid guest memory %s", domain.Memory.Guest)
+	}
+	maxGuest, err := resource.ParseQuantity(domain.Memory.MaxGuest)
+	if err != nil {
+		return false, fmt.Sprintf("invalid max guest memory %s", domain.Memory.MaxGuest)
+	}
+	wanted := int64(ram) * 1024 * 1024
+	if wanted < guest.Value() || wanted > maxGuest.Value() {
+		return false, fmt.Sprintf("memory can only grow from %s up to %s", domain.Memory.Guest, domain.Memory.MaxGuest)
+	}
+	return true, ""
+}
+
+// ResizeVM changes the CPU and memory of a VM to another size or to custom values. A running VM is
+// resized with CPU and memory hotplug when KubeVirt supports it, otherwise the resize asks for a restart the
+// controller performs after applying the new size, so the VM is never left stopped at its old size.
+func (m *VMManager) ResizeVM(name, namespace string, resize types.VMResize) (types.VMResizeResult, error) {
+	vm, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return types.VMResizeResult{}, err
+	}
+	spec, err := resizedSpec(vm.Spec, resize)
+	if err != nil {
+		return types.VMResizeResult{}, err
+	}
+	cpu, ram, err := vmResources(spec)
+	if err != nil {
+		return types.VMResizeResult{}, err
+	}
+	result := types.VMResizeResult{Size: spec.Size, CPU: cpu, RAM: ram, Method: types.VMResizeStopped}
+	patch := map[string]any{"size": spec.Size, "disk": spec.Disk, "cpu": nil, "ram": nil}
+	if spec.Size == types.VMSizeCustom {
+		patch["cpu"], patch["ram"] = cpu, ram
+	}
+
+	running, err := m.vmRunning(name, namespace)
+	if err != nil {
+		return types.VMResizeResult{}, err
+	}
+	if running {
+		var vmi vmiObject
+		found, err := getObject(m.kubectl, vmiResource, namespace, name, &vmi)
+		if err != nil {
+			return types.VMResizeResult{}, err
+		}
+		hotplug, reason := found, "not running"
+		if found {
+			hotplug, reason = canHotplug(vmi, cpu, ram)
+		}
+		// KubeVirt only live-updates within an instancetype reference or within inlined resources
+		if hotplug && inlineResources(vm.Spec) != inlineResources(spec) {
+			hotplug, reason = false, "switching between an instancetype and inlined resources"
+		}
+		if hotplug && pinnedResources(spec) {
+			hotplug, reason = false, "dedicated CPUs or hugepages"
+		}
+		if hotplug {
+			result.Method = types.VMResizeHotplug
+		} else {
+			m.logger.Info("restarting VM to resize it", "name", name, "namespace", namespace, "reason", reason)
+			patch["running"] = true
+			patch["restartedAt"] = time.Now().UTC().Format(time.RFC3339Nano)
+			result.Method = types.VMResizeRestart
+		}
+	}
+	m.logger.Info("resizing VM", "name", name, "namespace", namespace, "size", spec.Size, "cpu", cpu, "ram", ram,
+		"method", result.Method)
+	if err := patchSpec(m.kubectl, govnoVMResource, namespace, name, patch); err != nil {
+		return types.VMResizeResult{}, fmt.Errorf("failed to resize VM %s in namespace %s: %w", name, namespace, err)
+	}
+	return result, nil
+}
+
+// ResizeVMHandler handles VM resize requests
+func ResizeVMHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	var resize types.VMResize
+	if err := c.BindJSON(&resize); err != nil {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	vm, err := vmManager.forRequest(c).getVMResource(name, namespace)
+	if err != nil {
+		respondWithError(c, http.StatusNotFound, err.Error())
+		return
+	}
+	if _, err := resizedSpec(vm.Spec, resize); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := vmManager.forRequest(c).ResizeVM(name, namespace, resize)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to resize VM: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM resized", "name", name, "namespace", namespace, "size", result.Size, "method", result.Method)
+	// the controller completes a resize by restart
+	if result.Method == types.VMResizeRestart {
+		c.JSON(http.StatusAccepted, result)
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,178 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+)
+
+// minIdleTimeout is the shortest idle timeout of a VM, shorter ones would stop VMs that are just booting
+const minIdleTimeout = 30 * time.Minute
+
+// validateRunPolicy validates the run strategy and idle timeout of a VM
+func validateRunPolicy(runStrategy, idleTimeout string) error {
+	switch runStrategy {
+	case "", types.VMRunStrategyAlways, types.VMRunStrategyRerunOnFailure, types.VMRunStrategyManual, types.VMRunStrategyHalted:
+	default:
+		return fmt.Errorf("invalid run strategy %s, must be Always, RerunOnFailure, Manual or Halted", runStrategy)
+	}
+	if idleTimeout == "" {
+		return nil
+	}
+	timeout, err := time.ParseDuration(idleTimeout)
+	if err != nil {
+		return fmt.Errorf("invalid idle timeout %s: %w", idleTimeout, err)
+	}
+	if timeout < minIdleTimeout {
+		return fmt.Errorf("idle timeout %s is shorter than %s", idleTimeout, minIdleTimeout)
+	}
+	return nil
+}
+
+// specRunStrategy returns the run strategy a GovnoVM records for a requested one, Halted is recorded as not running
+func specRunStrategy(runStrategy string) (string, bool) {
+	switch runStrategy {
+	case types.VMRunStrategyHalted:
+		return "", false
+	case types.VMRunStrategyAlways:
+		return "", true
+	}
+	return runStrategy, true
+}
+
+// manifestRunStrategy returns the KubeVirt run strategy of a GovnoVM. A stopped VM is Halted unless
+// its strategy is Manual, whose VMs are started and stopped through virtctl instead.
+func manifestRunStrategy(spec types.GovnoVMSpec) string {
+	switch {
+	case spec.RunStrategy == types.VMRunStrategyManual:
+		return types.VMRunStrategyManual
+	case !spec.Running:
+		return types.VMRunStrategyHalted
+	case spec.RunStrategy == "":
+		return types.VMRunStrategyAlways
+	}
+	return spec.RunStrategy
+}
+
+// reportedRunStrategy returns the run strategy of a GovnoVM as reported to users
+func reportedRunStrategy(spec types.GovnoVMSpec) string {
+	if spec.RunStrategy != types.VMRunStrategyManual && !spec.Running {
+		return types.VMRunStrategyHalted
+	}
+	if spec.RunStrategy == "" {
+		return types.VMRunStrategyAlways
+	}
+	return spec.RunStrategy
+}
+
+// applyManualRun starts or stops a Manual VM whose running state was changed, KubeVirt leaves them as they are.
+// It only runs when the spec changed, so a Manual VM shut down from inside the guest stays down.
+func (m *VMManager) applyManualRun(resource *types.GovnoVM) error {
+	if resource.Spec.RunStrategy != types.VMRunStrategyManual {
+		return nil
+	}
+	running, err := m.vmRunning(resource.Name, resource.Namespace)
+	if err != nil {
+		return err
+	}
+	switch {
+	case resource.Spec.Running && !running:
+		return m.virtctlPower("start", resource.Name, resource.Namespace)
+	case !resource.Spec.Running && running:
+		return m.virtctlPower("stop", resource.Name, resource.Namespace)
+	}
+	return nil
+}
+
+// virtctlPower starts or stops a VM with virtctl
+func (m *VMManager) virtctlPower(action, name, namespace string) error {
+	m.logger.Info("running virtctl", "action", action, "name", name, "namespace", namespace)
+	if out, err := m.virtctl.Run(action, name, "-n", namespace); err != nil {
+		return fmt.Errorf("failed to %s VM %s in namespace %s: %s %w", action, name, namespace, out, err)
+	}
+	return nil
+}
+
+// setRunning marks a VM as running or stopped, the controller starts or stops it. A Manual VM that is already
+// marked so, because its guest shut it down or it was started from inside, is started or stopped right away.
+func (m *VMManager) setRunning(name, namespace string, running bool) error {
+	vm, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return err
+	}
+	if vm.Spec.RunStrategy == types.VMRunStrategyManual && vm.Spec.Running == running {
+		return m.applyManualRun(vm)
+	}
+	return patchSpec(m.kubectl, govnoVMResource, namespace, name, map[string]any{"running": running})
+}
+
+// SetRunPolicy changes the run strategy and idle timeout of a VM and returns its effective run policy
+func (m *VMManager) SetRunPolicy(name, namespace string, policy types.VMRunPolicy) (types.VMRunPolicy, error) {
+	if err := validateRunPolicy(policy.RunStrategy, policy.IdleTimeout); err != nil {
+		return types.VMRunPolicy{}, err
+	}
+	runStrategy, running := specRunStrategy(policy.RunStrategy)
+	patch := map[string]any{"runStrategy": nil, "idleTimeout": nil}
+	if runStrategy != "" {
+		patch["runStrategy"] = runStrategy
+	}
+	if policy.IdleTimeout != "" {
+		patch["idleTimeout"] = policy.IdleTimeout
+	}
+	// Halted stops the VM, Always and RerunOnFailure keep it running and start it, Manual leaves it as it is
+	if runStrategy != types.VMRunStrategyManual {
+		patch["running"] = running
+	}
+	m.logger.Info("setting VM run policy", "name", name, "namespace", namespace, "runStrategy", policy.RunStrategy,
+		"idleTimeout", policy.IdleTimeout)
+	if err := patchSpec(m.kubectl, govnoVMResource, namespace, name, patch); err != nil {
+		return types.VMRunPolicy{}, fmt.Errorf("failed to set run policy of VM %s in namespace %s: %w", name, namespace, err)
+	}
+	resource, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return types.VMRunPolicy{}, err
+	}
+	return types.VMRunPolicy{RunStrategy: reportedRunStrategy(resource.Spec), IdleTimeout: resource.Spec.IdleTimeout}, nil
+}
+
+// SetVMRunPolicyHandler handles requests to change the run strategy and idle timeout of a VM
+func SetVMRunPolicyHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	var policy types.VMRunPolicy
+	if err := c.BindJSON(&policy); err != nil {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	if err := validateRunPolicy(policy.RunStrategy, policy.IdleTimeout); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if _, err := vmManager.forRequest(c).getVMResource(name, namespace); err != nil {
+		respondWithError(c, http.StatusNotFound, err.Error())
+		return
+	}
+	effective, err := vmManager.forRequest(c).SetRunPolicy(name, namespace, policy)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to set run policy: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM run policy set", "name", name, "namespace", namespace, "runStrategy", effective.RunStrategy)
+	c.JSON(http.StatusOK, effective)
+}
@@ -1,24 +1,23 @@
 package server
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
-	"log"
-
-	"strings"
-
-	"os"
+	"log/slog"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rusik69/govnocloud2/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // VMManager handles VM operations
 type VMManager struct {
 	kubectl KubectlRunner
 	virtctl VirtctlRunner
+	logger  *slog.Logger
 }
 
 // NewVMManager creates a new VM manager instance
@@ -26,6 +25,21 @@ func NewVMManager() *VMManager {
 	return &VMManager{
 		kubectl: &DefaultKubectlRunner{},
 		virtctl: &DefaultVirtctlRunner{},
+		logger:  slog.Default(),
+	}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *VMManager) forRequest(c *gin.Context) *VMManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *VMManager) withLogger(logger *slog.Logger) *VMManager {
+	return &VMManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		virtctl: virtctlWithLogger(m.virtctl, logger),
+		logger:  logger,
 	}
 }
 
@@ -54,99 +68,335 @@ func CreateVMHandler(c *gin.Context) {
 		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
 		return
 	}
-	log.Printf("%+v", vm)
-	if _, ok := types.VMSizes[vm.Size]; !ok {
-		log.Printf("invalid VM size: %s", vm.Size)
-		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid VM size: %s", vm.Size))
+	requestLogger(c).Debug("create VM request", "name", vm.Name, "image", vm.Image, "size", vm.Size)
+	if err := validateCloudInit(vm); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := resolveSSHKeys(username, &vm); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if vm.Ports, vm.ServiceType, err = normalizeVMPorts(vm.Ports, vm.ServiceType); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if vm.Disk, err = normalizeDiskSize(vm.Disk); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if vm.Networks, err = normalizeVMNetworks(vm.Networks, namespace); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validateRunPolicy(vm.RunStrategy, vm.IdleTimeout); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validateVMPlacement(vm.Placement); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	if _, ok := types.VMImages[vm.Image]; !ok {
-		log.Printf("invalid VM image: %s", vm.Image)
-		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid VM image: %s", vm.Image))
+	if err := validateNewVMSize(vm.Size); err != nil {
+		requestLogger(c).Warn("invalid VM size", "size", vm.Size, "error", err)
+		respondWithError(c, http.StatusBadRequest, err.Error())
 		return
 	}
-	if err := vmManager.CreateVM(namespace, vm); err != nil {
-		log.Printf("failed to create VM: %v", err)
+	image, err := lookupImage(vm.Image, namespace)
+	if err != nil {
+		requestLogger(c).Warn("invalid VM image", "image", vm.Image, "error", err)
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := validateProvisioning(vm, image); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if isDryRun(c) {
+		resource := vmManager.generateResource(namespace, vm)
+		setProvisioning(&resource, vm, image)
+		manifest, err := resourceManifest(resource)
+		if err != nil {
+			respondWithError(c, http.StatusInternalServerError, err.Error())
+			return
+		}
+		respondWithDryRun(c, vmManager.forRequest(c).kubectl, manifest, namespace)
+		return
+	}
+	if err := vmManager.forRequest(c).CreateVM(namespace, vm); err != nil {
+		requestLogger(c).Error("failed to create VM", "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create VM: %v", err))
 		return
 	}
-	log.Printf("vm created successfully: %v+", vm)
+	requestLogger(c).Info("VM created successfully", "name", vm.Name, "namespace", namespace)
 	respondWithSuccess(c, gin.H{"message": "VM created successfully"})
 }
 
-// CreateVM creates a new virtual machine
-func (m *VMManager) CreateVM(namespace string, vm types.VM) error {
-	vmSize := types.VMSizes[vm.Size]
-	vmImage := types.VMImages[vm.Image]
-	vmConfig := fmt.Sprintf(`apiVersion: kubevirt.io/v1
+// generateManifest generates the KubeVirt VirtualMachine manifest of a GovnoVM booting from the rootDisk DataVolume
+func (m *VMManager) generateManifest(resource *types.GovnoVM, rootDisk string) (string, error) {
+	// sizes of the catalog are referenced as instancetypes, custom values and pinned resources are inlined
+	instancetype := fmt.Sprintf(`
+  instancetype:
+    kind: VirtualMachineClusterInstancetype
+    name: %s`, resource.Spec.Size)
+	if inlineResources(resource.Spec) {
+		instancetype = ""
+	}
+	resources, err := generateDomainResources(resource.Spec)
+	if err != nil {
+		return "", err
+	}
+	volumeDisks, volumeSources := generateVolumeDisks(resource.Spec.Volumes)
+	interfaces, networks := generateNetworks(resource.Spec.Networks, resource.Namespace, interfaceModel(resource.Spec.Devices))
+	provisioningDisk, provisioningVolume := generateProvisioning(resource.Spec)
+	return fmt.Sprintf(`apiVersion: kubevirt.io/v1
 kind: VirtualMachine
 metadata:
   name: %s
   namespace: %s
 spec:
-  running: true
+  runStrategy: %s%s
   template:
     metadata:
       labels:
         kubevirt.io/size: %s
-        kubevirt.io/image: %s
+        kubevirt.io/image: %s%s
     spec:
       domain:
         devices:
           disks:
           - name: rootdisk
             disk:
-              bus: virtio
-          - name: cloudinitdisk
-            disk:
-              bus: virtio
-        resources:
-          requests:
-            memory: %dMi
-            cpu: %d
+              bus: %s%s%s%s%s%s%s
       volumes:
       - name: rootdisk
-        containerDisk:
-          image: %s
-      - name: cloudinitdisk
-        cloudInitNoCloud:
-          userData: |
-            #cloud-config
-            password: ubuntu
-            chpasswd:
-              expire: false
-            ssh_pwauth: true`,
-		vm.Name, namespace, vm.Size, vm.Image, vmSize.RAM, vmSize.CPU, vmImage.Image)
-	log.Println(vmConfig)
-	// Write config to temporary file
-	tmpfile, err := os.CreateTemp("", "vm-*.yaml")
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+        dataVolume:
+          name: %s%s%s%s%s`,
+		resource.Name, resource.Namespace, manifestRunStrategy(resource.Spec), instancetype, resource.Spec.Size, resource.Spec.Image,
+		generateGroupLabels(resource.Spec.Placement), rootDiskBus(resource.Spec.Devices), provisioningDisk, volumeDisks, interfaces,
+		generateTPM(resource.Spec.Devices), resources, generateFirmware(resource.Spec.Devices), rootDisk, provisioningVolume,
+		volumeSources, networks, generateScheduling(resource.Spec)), nil
+}
+
+// generateResource generates the GovnoVM custom resource for the VM
+func (m *VMManager) generateResource(namespace string, vm types.VM) types.GovnoVM {
+	cloudInitSecret := vm.CloudInitSecret
+	if cloudInitSecret == "" {
+		cloudInitSecret = cloudInitSecretName(vm.Name)
+	}
+	runStrategy, running := specRunStrategy(vm.RunStrategy)
+	return types.GovnoVM{
+		TypeMeta:   typeMeta(govnoVMKind),
+		ObjectMeta: metav1.ObjectMeta{Name: vm.Name, Namespace: namespace},
+		Spec: types.GovnoVMSpec{
+			Image:   vm.Image,
+			Size:    vm.Size,
+			Disk:    vm.Disk,
+			Running: running,
+
+			RunStrategy: runStrategy,
+			IdleTimeout: vm.IdleTimeout,
+
+			CloudInitSecret: cloudInitSecret,
+			NetworkData:     vm.NetworkData != "" || len(vm.Networks) > 0,
+			ServiceType:     vm.ServiceType,
+			Ports:           vm.Ports,
+			Networks:        vm.Networks,
+			Placement:       vm.Placement,
+		},
 	}
-	defer os.Remove(tmpfile.Name())
+}
+
+// CreateVM creates a new virtual machine by writing its GovnoVM, the controller brings it up.
+// Unless the VM names an existing cloud-init Secret, its user-data is generated into a Secret first.
+func (m *VMManager) CreateVM(namespace string, vm types.VM) error {
+	return m.createVM(namespace, vm, nil)
+}
 
-	if _, err := tmpfile.WriteString(vmConfig); err != nil {
-		return fmt.Errorf("failed to write config: %w", err)
+// createVM creates a virtual machine with the volumes attached from the start
+func (m *VMManager) createVM(namespace string, vm types.VM, volumes []string) error {
+	if err := validateCloudInit(vm); err != nil {
+		return err
 	}
-	if err := tmpfile.Close(); err != nil {
-		return fmt.Errorf("failed to close temp file: %w", err)
+	if err := validateRunPolicy(vm.RunStrategy, vm.IdleTimeout); err != nil {
+		return err
 	}
-
-	// Apply the configuration
-	out, err := m.kubectl.Run("apply", "-f", tmpfile.Name(), "--wait=true", "--timeout=600s")
+	if err := validateVMPlacement(vm.Placement); err != nil {
+		return err
+	}
+	image, err := lookupImage(vm.Image, namespace)
 	if err != nil {
-		return fmt.Errorf("failed to create VM %s: %s: %w", vm.Name, out, err)
+		return err
+	}
+	if err := validateProvisioning(vm, image); err != nil {
+		return err
+	}
+	resource := m.generateResource(namespace, vm)
+	resource.Spec.Volumes = volumes
+	setProvisioning(&resource, vm, image)
+	if vm.CloudInitSecret != "" {
+		networkData, err := cloudInitSecretHasNetworkData(m.kubectl, namespace, vm.CloudInitSecret)
+		if err != nil {
+			return err
+		}
+		// cloudbase-init can't read network-data, which setProvisioning turned off
+		resource.Spec.NetworkData = networkData && provisioning(resource.Spec.Devices) == types.VMProvisioningCloudInit
+	} else if resource.Spec.CloudInitSecret != "" {
+		secret, err := generateCloudInitSecret(namespace, cloudInitSecretName(vm.Name), vm, map[string]string{vmLabel: vm.Name})
+		if err != nil {
+			return err
+		}
+		if out, err := applyManifest(m.kubectl, secret); err != nil {
+			return fmt.Errorf("failed to create cloud-init secret of VM %s: %s %w", vm.Name, out, err)
+		}
+	}
+	if resource.Spec.SysprepSecret != "" {
+		secret, err := generateSysprepSecret(namespace, sysprepSecretName(vm.Name), vm.Sysprep, map[string]string{vmLabel: vm.Name})
+		if err != nil {
+			return err
+		}
+		if out, err := applyManifest(m.kubectl, secret); err != nil {
+			return fmt.Errorf("failed to create sysprep secret of VM %s: %s %w", vm.Name, out, err)
+		}
 	}
+	m.logger.Debug("generated VM resource", "resource", resource)
+	if err := applyResource(m.kubectl, resource); err != nil {
+		return fmt.Errorf("failed to create VM %s: %w", vm.Name, err)
+	}
+	return nil
+}
 
-	// wait for VM to start
-	out, err = m.kubectl.Run("wait", "--for=condition=Ready=true", fmt.Sprintf("virtualmachine.kubevirt.io/%s", vm.Name), "-n", vm.Namespace, "--timeout=5m")
+// applyVirtualMachine applies the KubeVirt VirtualMachine of a GovnoVM and performs requested restarts
+func (m *VMManager) applyVirtualMachine(resource *types.GovnoVM) error {
+	if err := validateVMResources(resource.Spec); err != nil {
+		return err
+	}
+	if !inlineResources(resource.Spec) {
+		if err := ensureInstancetype(m.kubectl, resource.Spec.Size); err != nil {
+			return err
+		}
+	}
+	rootDisk, err := m.currentRootDisk(resource)
+	if err != nil {
+		return err
+	}
+	// a restored VM boots from the DataVolume its restore created, the generated one is left alone
+	if rootDisk == rootDiskName(resource.Name) {
+		if err := m.applyRootDisk(resource); err != nil {
+			return err
+		}
+	}
+	vmConfig, err := m.generateManifest(resource, rootDisk)
 	if err != nil {
-		return fmt.Errorf("failed waiting for VM %s to start in namespace %s: %s %w", vm.Name, vm.Namespace, out, err)
+		return err
+	}
+	m.logger.Debug("generated VM manifest", "manifest", vmConfig)
+	if out, err := applyManifest(m.kubectl, vmConfig); err != nil {
+		return fmt.Errorf("failed to apply VM %s: %s: %w", resource.Name, out, err)
+	}
+	if err := setOwner(m.kubectl, virtualMachineResource, resource.Namespace, resource.Name, govnoVMKind, resource.ObjectMeta); err != nil {
+		return err
 	}
+	if err := m.applyPorts(resource); err != nil {
+		return err
+	}
+	// the generated cloud-init Secret goes away with the VM, a Secret the user brought does not
+	if resource.Spec.CloudInitSecret == cloudInitSecretName(resource.Name) {
+		if err := setOwner(m.kubectl, "secrets", resource.Namespace, resource.Spec.CloudInitSecret, govnoVMKind, resource.ObjectMeta); err != nil {
+			return err
+		}
+	}
+	if resource.Spec.SysprepSecret == sysprepSecretName(resource.Name) {
+		if err := setOwner(m.kubectl, "secrets", resource.Namespace, resource.Spec.SysprepSecret, govnoVMKind, resource.ObjectMeta); err != nil {
+			return err
+		}
+	}
+	if err := m.applyManualRun(resource); err != nil {
+		return err
+	}
+	return m.applyRestart(resource)
+}
+
+// virtualMachineResource is the KubeVirt VirtualMachine resource as understood by kubectl
+const virtualMachineResource = "virtualmachines.kubevirt.io"
 
+// restartedAtAnnotation records on the VirtualMachine the last restart request the controller performed
+const restartedAtAnnotation = types.CRDGroup + "/restarted-at"
+
+// applyRestart restarts a running VM when its GovnoVM asks for a restart that was not performed yet
+func (m *VMManager) applyRestart(resource *types.GovnoVM) error {
+	if resource.Spec.RestartedAt == "" {
+		return nil
+	}
+	var object virtualMachineObject
+	if _, err := getObject(m.kubectl, virtualMachineResource, resource.Namespace, resource.Name, &object); err != nil {
+		return err
+	}
+	if object.Metadata.Annotations[restartedAtAnnotation] == resource.Spec.RestartedAt {
+		return nil
+	}
+	if resource.Spec.Running && object.Status.PrintableStatus == "Running" {
+		m.logger.Info("restarting VM", "name", resource.Name, "namespace", resource.Namespace)
+		if out, err := m.virtctl.Run("restart", resource.Name, "-n", resource.Namespace); err != nil {
+			return fmt.Errorf("failed to restart VM %s in namespace %s: %s %w", resource.Name, resource.Namespace, out, err)
+		}
+	}
+	if out, err := m.kubectl.Run("annotate", "--overwrite", virtualMachineResource, resource.Name, "-n", resource.Namespace,
+		restartedAtAnnotation+"="+resource.Spec.RestartedAt); err != nil {
+		return fmt.Errorf("failed to record restart of VM %s: %s %w", resource.Name, out, err)
+	}
 	return nil
 }
 
+// virtualMachineObject is the part of a KubeVirt VirtualMachine the controller reads
+type virtualMachineObject struct {
+	Metadata struct {
+		Name        string            `json:"name"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"metadata"`
+	Spec struct {
+		Template struct {
+			Spec struct {
+				Domain struct {
+					Devices struct {
+						Disks []struct {
+							Name   string `json:"name"`
+							Serial string `json:"serial"`
+							Disk   struct {
+								Bus string `json:"bus"`
+							} `json:"disk"`
+						} `json:"disks"`
+					} `json:"devices"`
+				} `json:"domain"`
+				Volumes []struct {
+					Name                  string `json:"name"`
+					PersistentVolumeClaim *struct {
+						ClaimName string `json:"claimName"`
+					} `json:"persistentVolumeClaim"`
+					DataVolume *struct {
+						Name string `json:"name"`
+					} `json:"dataVolume"`
+				} `json:"volumes"`
+			} `json:"spec"`
+		} `json:"template"`
+	} `json:"spec"`
+	Status struct {
+		PrintableStatus string            `json:"printableStatus"`
+		Conditions      []objectCondition `json:"conditions"`
+	} `json:"status"`
+}
+
+// virtualMachineState returns the state of the KubeVirt VirtualMachine of a GovnoVM
+func (m *VMManager) virtualMachineState(namespace, name string) (resourceState, error) {
+	var object virtualMachineObject
+	found, err := getObject(m.kubectl, virtualMachineResource, namespace, name, &object)
+	if err != nil || !found {
+		return resourceState{}, err
+	}
+	ready, message := conditionTrue(object.Status.Conditions, "Ready")
+	return resourceState{Found: true, Phase: object.Status.PrintableStatus, Ready: ready, Message: message}, nil
+}
+
 // ListVMsHandler handles VM listing requests
 func ListVMsHandler(c *gin.Context) {
 	auth, username, err := CheckAuth(c)
@@ -167,46 +417,63 @@ func ListVMsHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	vms, err := vmManager.ListVMs(namespace)
+	vms, err := vmManager.forRequest(c).ListVMs(namespace)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list VMs: %v", err))
 		return
 	}
-	log.Printf("vms: %+v", vms)
+	requestLogger(c).Debug("listed VMs", "namespace", namespace, "vms", vms)
 	c.JSON(http.StatusOK, vms)
 }
 
-// ListVMs returns a list of virtual machines
-func (m *VMManager) ListVMs(namespace string) ([]string, error) {
-	out, err := m.kubectl.Run("get", "VirtualMachines", "-n", namespace, "-o", "jsonpath={.items[*].metadata.name}")
-	if err != nil {
-		log.Printf("failed to list VMs: %v", err)
+// virtualMachineList is a list of KubeVirt VirtualMachines
+type virtualMachineList struct {
+	Items []virtualMachineObject `json:"items"`
+}
+
+// ListVMs returns the virtual machines of a namespace with what their VirtualMachineInstances report,
+// the guest agent is only asked by GetVM
+func (m *VMManager) ListVMs(namespace string) ([]types.VM, error) {
+	var resources types.GovnoVMList
+	if err := listObjects(m.kubectl, govnoVMResource, namespace, &resources); err != nil {
+		m.logger.Error("failed to list VMs", "error", err)
 		return nil, fmt.Errorf("failed to list VMs: %w", err)
 	}
-
-	// If output is empty, return empty slice
-	if len(out) == 0 {
-		return []string{}, nil
+	var objects virtualMachineList
+	if err := listObjects(m.kubectl, virtualMachineResource, namespace, &objects); err != nil {
+		return nil, fmt.Errorf("failed to list VM disks: %w", err)
+	}
+	var instances vmiList
+	if err := listObjects(m.kubectl, vmiResource, namespace, &instances); err != nil {
+		return nil, fmt.Errorf("failed to list VM instances: %w", err)
+	}
+	virtualMachines := make(map[string]*virtualMachineObject, len(objects.Items))
+	for i := range objects.Items {
+		virtualMachines[objects.Items[i].Metadata.Name] = &objects.Items[i]
+	}
+	vmis := make(map[string]*vmiObject, len(instances.Items))
+	for i := range instances.Items {
+		vmis[instances.Items[i].Name] = &instances.Items[i]
 	}
 
-	// Split the space-separated output into slice
-	names := strings.Fields(string(out))
-	return names, nil
-}
-
-// Add this struct before the GetVM function
-type VMTemplate struct {
-	Metadata struct {
-		Name string `json:"name"`
-	} `json:"metadata"`
-	Spec struct {
-		Template struct {
-			Metadata struct {
-				Labels map[string]string `json:"labels"`
-			} `json:"metadata"`
-		} `json:"template"`
-	} `json:"spec"`
-	PrintableStatus string `json:"printableStatus"`
+	vms := make([]types.VM, 0, len(resources.Items))
+	for i := range resources.Items {
+		resource := &resources.Items[i]
+		ports, err := m.portsStatus(resource.Namespace, resource.Name, resource.Spec.Ports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ports of VM %s: %w", resource.Name, err)
+		}
+		object := virtualMachines[resource.Name]
+		if object == nil {
+			object = &virtualMachineObject{}
+		}
+		vm, err := vmFromObjects(resource, object, vmis[resource.Name], ports)
+		if err != nil {
+			return nil, fmt.Errorf("failed to report VM %s: %w", resource.Name, err)
+		}
+		vms = append(vms, vm)
+	}
+	return vms, nil
 }
 
 // GetVMHandler handles VM retrieval requests
@@ -235,31 +502,54 @@ func GetVMHandler(c *gin.Context) {
 		return
 	}
 
-	vm, err := vmManager.GetVM(name, namespace)
+	vm, err := vmManager.forRequest(c).GetVM(name, namespace)
 	if err != nil {
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get VM: %v", err))
 		return
 	}
-	log.Printf("%+v", vm)
+	requestLogger(c).Debug("got VM", "vm", vm)
 	c.JSON(http.StatusOK, vm)
 }
 
 // GetVM retrieves a specific virtual machine
 func (m *VMManager) GetVM(name, namespace string) (types.VM, error) {
-	out, err := m.kubectl.Run("get", "VirtualMachine", name, "-n", namespace, "-o", "json")
+	var resource types.GovnoVM
+	found, err := getObject(m.kubectl, govnoVMResource, namespace, name, &resource)
 	if err != nil {
-		return types.VM{}, fmt.Errorf("failed to get VM %s: %w", out, err)
+		return types.VM{}, fmt.Errorf("failed to get VM: %w", err)
+	}
+	if !found {
+		return types.VM{}, fmt.Errorf("VM %s not found in namespace %s", name, namespace)
 	}
-	var VMTemplate VMTemplate
-	if err := json.Unmarshal(out, &VMTemplate); err != nil {
-		return types.VM{}, fmt.Errorf("failed to parse VM %s: %w", out, err)
+	ports, err := m.portsStatus(namespace, name, resource.Spec.Ports)
+	if err != nil {
+		return types.VM{}, fmt.Errorf("failed to get VM ports: %w", err)
+	}
+	var object virtualMachineObject
+	if _, err := getObject(m.kubectl, virtualMachineResource, namespace, name, &object); err != nil {
+		return types.VM{}, fmt.Errorf("failed to get VM disks: %w", err)
+	}
+	var vmi vmiObject
+	running, err := getObject(m.kubectl, vmiResource, namespace, name, &vmi)
+	if err != nil {
+		return types.VM{}, fmt.Errorf("failed to get VM instance: %w", err)
 	}
-	vm := types.VM{
-		Name:      VMTemplate.Metadata.Name,
-		Namespace: namespace,
-		Size:      VMTemplate.Spec.Template.Metadata.Labels["kubevirt.io/size"],
-		Image:     VMTemplate.Spec.Template.Metadata.Labels["kubevirt.io/image"],
-		Status:    VMTemplate.PrintableStatus,
+	instance := &vmi
+	if !running {
+		instance = nil
+	}
+	vm, err := vmFromObjects(&resource, &object, instance, ports)
+	if err != nil {
+		return types.VM{}, err
+	}
+	// the guest agent is asked only when connected, a VM without one still reports everything else
+	if connected, _ := conditionTrue(vmi.Status.Conditions, "AgentConnected"); running && connected {
+		guest, err := m.guestInfo(name, namespace)
+		if err != nil {
+			m.logger.Warn("failed to get guest info", "name", name, "namespace", namespace, "error", err)
+		} else {
+			vm.Guest = guest
+		}
 	}
 	return vm, nil
 }
@@ -282,7 +572,7 @@ func DeleteVMHandler(c *gin.Context) {
 	}
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		respondWithError(c, http.StatusBadRequest, "namespace is required")
 		return
 	}
@@ -291,21 +581,27 @@ func DeleteVMHandler(c *gin.Context) {
 		return
 	}
 
-	if err := vmManager.DeleteVM(name, namespace); err != nil {
-		log.Printf("failed to delete VM %s in namespace %s: %v", name, namespace, err)
+	retainDisk, _ := strconv.ParseBool(c.DefaultQuery("retainDisk", "false"))
+	if err := vmManager.forRequest(c).DeleteVM(name, namespace, retainDisk); err != nil {
+		requestLogger(c).Error("failed to delete VM", "name", name, "namespace", namespace, "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete VM: %v", err))
 		return
 	}
 
-	log.Printf("VM %s deleted successfully", name)
+	requestLogger(c).Info("VM deleted successfully", "name", name)
 	respondWithSuccess(c, gin.H{"message": "VM deleted successfully"})
 }
 
-// DeleteVM removes a virtual machine
-func (m *VMManager) DeleteVM(name, namespace string) error {
-	out, err := m.kubectl.Run("delete", "VirtualMachineInstance", name, "-n", namespace)
-	if err != nil {
-		return fmt.Errorf("failed to delete VM %s in namespace %s: %s %w", name, namespace, out, err)
+// DeleteVM removes a virtual machine, its KubeVirt objects are garbage collected with the GovnoVM.
+// With retainDisk the root disk is kept and reused by a VM created later with the same name.
+func (m *VMManager) DeleteVM(name, namespace string, retainDisk bool) error {
+	if retainDisk {
+		if err := m.retainRootDisk(name, namespace); err != nil {
+			return err
+		}
+	}
+	if err := deleteResource(m.kubectl, govnoVMResource, namespace, name); err != nil {
+		return fmt.Errorf("failed to delete VM %s in namespace %s: %w", name, namespace, err)
 	}
 	return nil
 }
@@ -328,7 +624,7 @@ func StartVMHandler(c *gin.Context) {
 	}
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		respondWithError(c, http.StatusBadRequest, "namespace is required")
 		return
 	}
@@ -337,44 +633,31 @@ func StartVMHandler(c *gin.Context) {
 		return
 	}
 	// check if VM is already running
-	vm, err := vmManager.GetVM(name, namespace)
+	vm, err := vmManager.forRequest(c).GetVM(name, namespace)
 	if err != nil {
-		log.Printf("failed to get VM %s in namespace %s: %v", name, namespace, err)
+		requestLogger(c).Error("failed to get VM", "name", name, "namespace", namespace, "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get VM: %v", err))
 		return
 	}
 	if vm.Status == "Running" {
-		log.Printf("VM %s is already running in namespace %s", name, namespace)
+		requestLogger(c).Info("VM is already running", "name", name, "namespace", namespace)
 		respondWithSuccess(c, gin.H{"message": "VM is already running"})
 		return
 	}
-	if err := vmManager.StartVM(name, namespace); err != nil {
-		log.Printf("failed to start VM %s in namespace %s: %v", name, namespace, err)
+	if err := vmManager.forRequest(c).StartVM(name, namespace); err != nil {
+		requestLogger(c).Error("failed to start VM", "name", name, "namespace", namespace, "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to start VM: %v", err))
 		return
 	}
 	respondWithSuccess(c, gin.H{"message": "VM started successfully"})
 }
 
-// StartVM starts a virtual machine
+// StartVM marks a virtual machine as running, the controller starts it
 func (m *VMManager) StartVM(name, namespace string) error {
-	log.Printf("starting VM %s in namespace %s", name, namespace)
-	out, err := m.virtctl.Run("start", name, "-n", namespace)
-	if err != nil {
-		// Check if the error is because VM is already running
-		if strings.Contains(string(out), "VM is already running") || strings.Contains(string(out), "already running") {
-			log.Printf("VM %s is already running in namespace %s", name, namespace)
-			return nil // Not an error, VM is already in desired state
-		}
-		return fmt.Errorf("failed to start VM %s in namespace %s: %s %w", name, namespace, out, err)
-	}
-	// wait for VM to start
-	log.Printf("waiting for VM %s to start in namespace %s", name, namespace)
-	out, err = m.kubectl.Run("wait", "--for=condition=Ready=true", fmt.Sprintf("virtualmachine.kubevirt.io/%s", name), "-n", namespace, "--timeout=5m")
-	if err != nil {
-		return fmt.Errorf("failed waiting for VM %s to start in namespace %s: %s %w", name, namespace, out, err)
+	m.logger.Info("starting VM", "name", name, "namespace", namespace)
+	if err := m.setRunning(name, namespace, true); err != nil {
+		return fmt.Errorf("failed to start VM %s in namespace %s: %w", name, namespace, err)
 	}
-	log.Printf("VM %s started successfully in namespace %s", name, namespace)
 	return nil
 }
 
@@ -396,7 +679,7 @@ func StopVMHandler(c *gin.Context) {
 	}
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		respondWithError(c, http.StatusBadRequest, "namespace is required")
 		return
 	}
@@ -405,44 +688,31 @@ func StopVMHandler(c *gin.Context) {
 		return
 	}
 	// check if VM is already stopped
-	vm, err := vmManager.GetVM(name, namespace)
+	vm, err := vmManager.forRequest(c).GetVM(name, namespace)
 	if err != nil {
-		log.Printf("failed to get VM %s in namespace %s: %v", name, namespace, err)
+		requestLogger(c).Error("failed to get VM", "name", name, "namespace", namespace, "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get VM: %v", err))
 		return
 	}
 	if vm.Status == "Stopped" {
-		log.Printf("VM %s is already stopped in namespace %s", name, namespace)
+		requestLogger(c).Info("VM is already stopped", "name", name, "namespace", namespace)
 		respondWithSuccess(c, gin.H{"message": "VM is already stopped"})
 		return
 	}
-	if err := vmManager.StopVM(name, namespace); err != nil {
-		log.Printf("failed to stop VM %s in namespace %s: %v", name, namespace, err)
+	if err := vmManager.forRequest(c).StopVM(name, namespace); err != nil {
+		requestLogger(c).Error("failed to stop VM", "name", name, "namespace", namespace, "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to stop VM: %v", err))
 		return
 	}
 	respondWithSuccess(c, gin.H{"message": "VM stopped successfully"})
 }
 
-// StopVM stops a virtual machine
+// StopVM marks a virtual machine as stopped, the controller stops it
 func (m *VMManager) StopVM(name, namespace string) error {
-	log.Printf("stopping VM %s in namespace %s", name, namespace)
-	out, err := m.virtctl.Run("stop", name, "-n", namespace)
-	if err != nil {
-		// Check if the error is because VM is already stopped
-		if strings.Contains(string(out), "VM is not running") || strings.Contains(string(out), "already stopped") || strings.Contains(string(out), "not running") {
-			log.Printf("VM %s is already stopped in namespace %s", name, namespace)
-			return nil // Not an error, VM is already in desired state
-		}
-		return fmt.Errorf("failed to stop VM %s in namespace %s: %s %w", name, namespace, out, err)
-	}
-	// wait for VM to stop
-	log.Printf("waiting for VM %s to stop in namespace %s", name, namespace)
-	out, err = m.kubectl.Run("wait", "--for=condition=Ready=false", fmt.Sprintf("virtualmachine.kubevirt.io/%s", name), "-n", namespace, "--timeout=5m")
-	if err != nil {
-		return fmt.Errorf("failed waiting for VM %s to stop in namespace %s: %s %w", name, namespace, out, err)
+	m.logger.Info("stopping VM", "name", name, "namespace", namespace)
+	if err := m.setRunning(name, namespace, false); err != nil {
+		return fmt.Errorf("failed to stop VM %s in namespace %s: %w", name, namespace, err)
 	}
-	log.Printf("VM %s stopped successfully in namespace %s", name, namespace)
 	return nil
 }
 
@@ -464,7 +734,7 @@ func RestartVMHandler(c *gin.Context) {
 	}
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		respondWithError(c, http.StatusBadRequest, "namespace is required")
 		return
 	}
@@ -472,40 +742,21 @@ func RestartVMHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	if err := vmManager.RestartVM(name, namespace); err != nil {
-		log.Printf("failed to restart VM %s in namespace %s: %v", name, namespace, err)
+	if err := vmManager.forRequest(c).RestartVM(name, namespace); err != nil {
+		requestLogger(c).Error("failed to restart VM", "name", name, "namespace", namespace, "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to restart VM: %v", err))
 		return
 	}
 	respondWithSuccess(c, gin.H{"message": "VM restarted successfully"})
 }
 
-// RestartVM restarts a virtual machine
+// RestartVM requests a restart of a virtual machine, the controller restarts it once it is running
 func (m *VMManager) RestartVM(name, namespace string) error {
-	log.Printf("restarting VM %s in namespace %s", name, namespace)
-
-	// First try to stop the VM (with force to ensure it stops)
-	log.Printf("stopping VM %s in namespace %s", name, namespace)
-	out, err := m.virtctl.Run("stop", name, "-n", namespace, "--grace-period=1", "--force=true")
-	if err != nil {
-		// Check if the error is because VM is already stopped
-		if strings.Contains(string(out), "VM is not running") || strings.Contains(string(out), "already stopped") || strings.Contains(string(out), "not running") {
-			log.Printf("VM %s is already stopped in namespace %s", name, namespace)
-		} else {
-			return fmt.Errorf("failed to stop VM %s in namespace %s: %s %w", name, namespace, out, err)
-		}
-	} else {
-		log.Printf("VM %s stopped successfully in namespace %s", name, namespace)
+	m.logger.Info("restarting VM", "name", name, "namespace", namespace)
+	restartedAt := time.Now().UTC().Format(time.RFC3339Nano)
+	if err := patchSpec(m.kubectl, govnoVMResource, namespace, name, map[string]any{"running": true, "restartedAt": restartedAt}); err != nil {
+		return fmt.Errorf("failed to restart VM %s in namespace %s: %w", name, namespace, err)
 	}
-
-	// Now start the VM using the existing StartVM method which has proper error handling
-	log.Printf("starting VM %s in namespace %s", name, namespace)
-	err = m.StartVM(name, namespace)
-	if err != nil {
-		return fmt.Errorf("failed to start VM after restart: %w", err)
-	}
-
-	log.Printf("VM %s restarted successfully in namespace %s", name, namespace)
 	return nil
 }
 
@@ -527,7 +778,7 @@ func WaitVMHandler(c *gin.Context) {
 	}
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		respondWithError(c, http.StatusBadRequest, "namespace is required")
 		return
 	}
@@ -535,21 +786,21 @@ func WaitVMHandler(c *gin.Context) {
 		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
 		return
 	}
-	if err := vmManager.WaitVM(name, namespace); err != nil {
-		log.Printf("failed to wait for VM %s in namespace %s: %v", name, namespace, err)
+	if err := vmManager.forRequest(c).WaitVM(name, namespace); err != nil {
+		requestLogger(c).Error("failed to wait for VM", "name", name, "namespace", namespace, "error", err)
 		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to wait for VM: %v", err))
 		return
 	}
 	respondWithSuccess(c, gin.H{"message": "VM waited successfully"})
 }
 
-// WaitVM waits for a virtual machine to be ready
+// WaitVM waits for the controller to report a virtual machine as ready
 func (m *VMManager) WaitVM(name, namespace string) error {
-	log.Printf("waiting for VM %s to be ready in namespace %s", name, namespace)
-	out, err := m.kubectl.Run("wait", "VirtualMachine", name, "-n", namespace, "--for=condition=ready", "--timeout=10m")
+	m.logger.Info("waiting for VM to be ready", "name", name, "namespace", namespace)
+	out, err := m.kubectl.Run("wait", govnoVMResource+"/"+name, "-n", namespace, "--for=condition=Ready", "--timeout=10m")
 	if err != nil {
 		return fmt.Errorf("failed to wait for VM %s in namespace %s: %s %w", name, namespace, out, err)
 	}
-	log.Printf("VM %s is ready in namespace %s", name, namespace)
+	m.logger.Info("VM is ready", "name", name, "namespace", namespace)
 	return nil
 }
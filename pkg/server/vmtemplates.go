@@ -0,0 +1,457 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// Limits of a batch creation
+const (
+	maxVMBatchCount         = 100
+	defaultVMBatchParallel  = 5
+	maxVMBatchParallel      = 20
+	vmBatchIndexPlaceholder = "{n}"
+)
+
+// VMTemplateManager stores VM templates in etcd and creates batches of VMs from them
+type VMTemplateManager struct {
+	etcdClient *clientv3.Client
+	logger     *slog.Logger
+}
+
+// NewVMTemplateManager creates a new VM template manager
+func NewVMTemplateManager(etcdClient *clientv3.Client) *VMTemplateManager {
+	return &VMTemplateManager{etcdClient: etcdClient, logger: slog.Default()}
+}
+
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *VMTemplateManager) forRequest(c *gin.Context) *VMTemplateManager {
+	return &VMTemplateManager{etcdClient: m.etcdClient, logger: requestLogger(c)}
+}
+
+// etcdKey returns the etcd key of a template, or the prefix of the templates of a namespace when name is empty
+func (m *VMTemplateManager) etcdKey(namespace, name string) string {
+	return "/vmtemplates/" + namespace + "/" + name
+}
+
+// normalizeVMTemplate validates a template before it is stored, resolving the registry keys of username and
+// normalizing its ports, disks and networks as a VM creation would
+func normalizeVMTemplate(username string, template *types.VMTemplate) error {
+	if errs := validation.IsDNS1123Label(template.Name); len(errs) > 0 {
+		return fmt.Errorf("invalid template name %s: %s", template.Name, strings.Join(errs, ", "))
+	}
+	vm := &template.VM
+	vm.Name, vm.Namespace = "", ""
+	if err := validateCloudInit(*vm); err != nil {
+		return err
+	}
+	if err := resolveSSHKeys(username, vm); err != nil {
+		return err
+	}
+	var err error
+	if vm.Ports, vm.ServiceType, err = normalizeVMPorts(vm.Ports, vm.ServiceType); err != nil {
+		return err
+	}
+	if vm.Disk, err = normalizeDiskSize(vm.Disk); err != nil {
+		return err
+	}
+	if vm.Networks, err = normalizeVMNetworks(vm.Networks, template.Namespace); err != nil {
+		return err
+	}
+	// every VM of a batch gets the same networks, fixed addresses would collide
+	for _, network := range vm.Networks {
+		if network.Addressing == types.VMAddressingStatic || network.MAC != "" {
+			return fmt.Errorf("network %s of a template can't have a static address or MAC", network.Name)
+		}
+	}
+	if err := validateRunPolicy(vm.RunStrategy, vm.IdleTimeout); err != nil {
+		return err
+	}
+	if err := validateVMPlacement(vm.Placement); err != nil {
+		return err
+	}
+	if err := validateNewVMSize(vm.Size); err != nil {
+		return err
+	}
+	image, err := lookupImage(vm.Image, template.Namespace)
+	if err != nil {
+		return err
+	}
+	if err := validateProvisioning(*vm, image); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for i, volume := range template.Volumes {
+		if errs := validation.IsDNS1123Label(volume.Name); len(errs) > 0 {
+			return fmt.Errorf("invalid volume name %s: %s", volume.Name, strings.Join(errs, ", "))
+		}
+		if seen[volume.Name] {
+			return fmt.Errorf("duplicate volume %s", volume.Name)
+		}
+		seen[volume.Name] = true
+		if volume.Size == "" {
+			return fmt.Errorf("volume %s needs a size", volume.Name)
+		}
+		if template.Volumes[i].Size, err = normalizeDiskSize(volume.Size); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PutTemplate stores a template, replacing the template with the same name
+func (m *VMTemplateManager) PutTemplate(template types.VMTemplate) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	data, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal template: %w", err)
+	}
+	if _, err := m.etcdClient.Put(ctx, m.etcdKey(template.Namespace, template.Name), string(data)); err != nil {
+		return fmt.Errorf("failed to store template: %w", err)
+	}
+	return nil
+}
+
+// GetTemplate returns a template, nil when it does not exist
+func (m *VMTemplateManager) GetTemplate(namespace, name string) (*types.VMTemplate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(namespace, name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, nil
+	}
+	var template types.VMTemplate
+	if err := json.Unmarshal(resp.Kvs[0].Value, &template); err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+	return &template, nil
+}
+
+// ListTemplates returns the templates of a namespace
+func (m *VMTemplateManager) ListTemplates(namespace string) ([]types.VMTemplate, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Get(ctx, m.etcdKey(namespace, ""), clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list templates: %w", err)
+	}
+	templates := make([]types.VMTemplate, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		var template types.VMTemplate
+		if err := json.Unmarshal(kv.Value, &template); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", kv.Key, err)
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+// DeleteTemplate removes a template, the VMs created from it stay
+func (m *VMTemplateManager) DeleteTemplate(namespace, name string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	resp, err := m.etcdClient.Delete(ctx, m.etcdKey(namespace, name))
+	if err != nil {
+		return fmt.Errorf("failed to delete template: %w", err)
+	}
+	if resp.Deleted == 0 {
+		return fmt.Errorf("template %s not found", name)
+	}
+	return nil
+}
+
+// normalizeVMBatch validates a batch and fills in its defaults
+func normalizeVMBatch(template types.VMTemplate, batch *types.VMBatch) error {
+	if batch.Count < 1 || batch.Count > maxVMBatchCount {
+		return fmt.Errorf("count must be between 1 and %d", maxVMBatchCount)
+	}
+	if batch.Concurrency == 0 {
+		batch.Concurrency = defaultVMBatchParallel
+	}
+	if batch.Concurrency < 1 || batch.Concurrency > maxVMBatchParallel {
+		return fmt.Errorf("concurrency must be between 1 and %d", maxVMBatchParallel)
+	}
+	if batch.Start < 0 {
+		return fmt.Errorf("start can't be negative")
+	}
+	if batch.Pool {
+		if batch.NamePattern == "" {
+			batch.NamePattern = template.Name
+		}
+		if strings.Contains(batch.NamePattern, vmBatchIndexPlaceholder) {
+			return fmt.Errorf("a pool name can't contain %s, KubeVirt numbers the VMs of a pool", vmBatchIndexPlaceholder)
+		}
+		if len(template.VM.Ports) > 0 || len(template.Volumes) > 0 {
+			return fmt.Errorf("template %s has ports or volumes, which a pool can't give every VM, create the VMs individually", template.Name)
+		}
+		return nil
+	}
+	if batch.NamePattern == "" {
+		batch.NamePattern = template.Name + "-" + vmBatchIndexPlaceholder
+	}
+	if batch.Start == 0 {
+		batch.Start = 1
+	}
+	if !strings.Contains(batch.NamePattern, vmBatchIndexPlaceholder) {
+		return fmt.Errorf("name pattern %s must contain %s", batch.NamePattern, vmBatchIndexPlaceholder)
+	}
+	return nil
+}
+
+// batchVMNames returns the names of the VMs of a batch, in index order
+func batchVMNames(batch types.VMBatch) []string {
+	names := make([]string, batch.Count)
+	for i := range names {
+		names[i] = strings.ReplaceAll(batch.NamePattern, vmBatchIndexPlaceholder, strconv.Itoa(batch.Start+i))
+	}
+	return names
+}
+
+// CreateBatch creates the VMs of a batch from a template, at most batch.Concurrency at once, and reports the
+// result of every VM. A VM that fails does not stop the others.
+func (m *VMTemplateManager) CreateBatch(template types.VMTemplate, batch types.VMBatch) (types.VMBatchResult, error) {
+	if err := normalizeVMBatch(template, &batch); err != nil {
+		return types.VMBatchResult{}, err
+	}
+	vms := vmManager.withLogger(m.logger)
+	if batch.Pool {
+		return vms.CreatePool(template, batch.NamePattern, batch.Count)
+	}
+	names := batchVMNames(batch)
+	result := types.VMBatchResult{Template: template.Name, VMs: make([]types.VMBatchItem, len(names))}
+	semaphore := make(chan struct{}, batch.Concurrency)
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+			item := types.VMBatchItem{Name: name, Status: types.VMBatchCreated}
+			if err := vms.createFromTemplate(template, name); err != nil {
+				m.logger.Warn("failed to create VM from template", "name", name, "template", template.Name, "error", err)
+				item.Status, item.Error = types.VMBatchFailed, err.Error()
+			}
+			result.VMs[i] = item
+		}()
+	}
+	wg.Wait()
+	for _, item := range result.VMs {
+		if item.Status == types.VMBatchCreated {
+			result.Created++
+		} else {
+			result.Failed++
+		}
+	}
+	return result, nil
+}
+
+// createFromTemplate creates a VM of a template with its volumes, which are owned by the VM and go away with it
+func (m *VMManager) createFromTemplate(template types.VMTemplate, name string) error {
+	if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+		return fmt.Errorf("invalid VM name %s: %s", name, strings.Join(errs, ", "))
+	}
+	var existing struct{}
+	found, err := getObject(m.kubectl, govnoVMResource, template.Namespace, name, &existing)
+	if err != nil {
+		return err
+	}
+	if found {
+		return fmt.Errorf("VM %s already exists", name)
+	}
+	vm := template.VM
+	vm.Name, vm.Namespace = name, template.Namespace
+	// a claim of the same name would be taken over by the apply, owned by the VM and deleted with it
+	for _, volume := range template.Volumes {
+		claim := name + "-" + volume.Name
+		found, err := getObject(m.kubectl, "pvc", template.Namespace, claim, &existing)
+		if err != nil {
+			return err
+		}
+		if found {
+			return fmt.Errorf("volume %s already exists", claim)
+		}
+	}
+	volumes := volumeManager.withLogger(m.logger)
+	claims := make([]string, 0, len(template.Volumes))
+	for _, volume := range template.Volumes {
+		claim := name + "-" + volume.Name
+		if out, err := volumes.CreateVolume(types.Volume{Name: claim, Size: volume.Size}, template.Namespace); err != nil {
+			m.deleteClaims(template.Namespace, claims)
+			return fmt.Errorf("failed to create volume %s: %s %w", claim, out, err)
+		}
+		claims = append(claims, claim)
+	}
+	if err := m.createVM(template.Namespace, vm, claims); err != nil {
+		m.deleteClaims(template.Namespace, claims)
+		return err
+	}
+	if len(claims) == 0 {
+		return nil
+	}
+	resource, err := m.getVMResource(name, template.Namespace)
+	if err != nil {
+		return err
+	}
+	for _, claim := range claims {
+		if err := setOwner(m.kubectl, "pvc", template.Namespace, claim, govnoVMKind, resource.ObjectMeta); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteClaims deletes the volumes created for a VM that could not be created
+func (m *VMManager) deleteClaims(namespace string, claims []string) {
+	for _, claim := range claims {
+		if out, err := m.kubectl.Run("delete", "pvc", claim, "-n", namespace, "--ignore-not-found"); err != nil {
+			m.logger.Warn("failed to clean up volume", "volume", claim, "output", string(out), "error", err)
+		}
+	}
+}
+
+// vmTemplateRequest checks the auth and namespace access of a template or pool request, responding with an error when denied
+func vmTemplateRequest(c *gin.Context) (string, string, bool) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return "", "", false
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return "", "", false
+	}
+	namespace := c.Param("namespace")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return "", "", false
+	}
+	return username, namespace, true
+}
+
+// ListVMTemplatesHandler handles requests to list the VM templates of a namespace
+func ListVMTemplatesHandler(c *gin.Context) {
+	_, namespace, ok := vmTemplateRequest(c)
+	if !ok {
+		return
+	}
+	templates, err := vmTemplateManager.forRequest(c).ListTemplates(namespace)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to list templates: %v", err))
+		return
+	}
+	c.JSON(http.StatusOK, templates)
+}
+
+// GetVMTemplateHandler handles VM template retrieval requests
+func GetVMTemplateHandler(c *gin.Context) {
+	_, namespace, ok := vmTemplateRequest(c)
+	if !ok {
+		return
+	}
+	template, err := vmTemplateManager.forRequest(c).GetTemplate(namespace, c.Param("name"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get template: %v", err))
+		return
+	}
+	if template == nil {
+		respondWithError(c, http.StatusNotFound, "template not found")
+		return
+	}
+	c.JSON(http.StatusOK, template)
+}
+
+// PutVMTemplateHandler handles requests to create or replace a VM template
+func PutVMTemplateHandler(c *gin.Context) {
+	username, namespace, ok := vmTemplateRequest(c)
+	if !ok {
+		return
+	}
+	var template types.VMTemplate
+	if err := c.BindJSON(&template); err != nil {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	template.Name, template.Namespace = c.Param("name"), namespace
+	if err := normalizeVMTemplate(username, &template); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := vmTemplateManager.forRequest(c).PutTemplate(template); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to store template: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM template stored", "name", template.Name, "namespace", namespace)
+	respondWithSuccess(c, gin.H{"message": "template stored successfully"})
+}
+
+// DeleteVMTemplateHandler handles VM template deletion requests
+func DeleteVMTemplateHandler(c *gin.Context) {
+	_, namespace, ok := vmTemplateRequest(c)
+	if !ok {
+		return
+	}
+	name := c.Param("name")
+	if err := vmTemplateManager.forRequest(c).DeleteTemplate(namespace, name); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to delete template: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM template deleted", "name", name, "namespace", namespace)
+	respondWithSuccess(c, gin.H{"message": "template deleted successfully"})
+}
+
+// CreateVMBatchHandler handles requests to create a batch of VMs from a template
+func CreateVMBatchHandler(c *gin.Context) {
+	_, namespace, ok := vmTemplateRequest(c)
+	if !ok {
+		return
+	}
+	var batch types.VMBatch
+	if err := c.BindJSON(&batch); err != nil {
+		respondWithError(c, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err))
+		return
+	}
+	manager := vmTemplateManager.forRequest(c)
+	template, err := manager.GetTemplate(namespace, c.Param("name"))
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to get template: %v", err))
+		return
+	}
+	if template == nil {
+		respondWithError(c, http.StatusNotFound, "template not found")
+		return
+	}
+	if err := normalizeVMBatch(*template, &batch); err != nil {
+		respondWithError(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := manager.CreateBatch(*template, batch)
+	if err != nil {
+		requestLogger(c).Error("failed to create VM batch", "template", template.Name, "error", err)
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to create VMs: %v", err))
+		return
+	}
+	requestLogger(c).Info("VM batch created", "template", template.Name, "namespace", namespace,
+		"created", result.Created, "failed", result.Failed, "pool", result.Pool)
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,249 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rusik69/govnocloud2/pkg/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// maxDiskSerialLength is the longest disk serial a guest reliably sees
+const maxDiskSerialLength = 20
+
+// reservedDiskNames are the disk names govnocloud uses itself, volumes can't be attached under them
+var reservedDiskNames = map[string]bool{"rootdisk": true, "cloudinitdisk": true}
+
+// diskSerial returns the serial a hotplugged volume is attached with
+func diskSerial(volume string) string {
+	if len(volume) > maxDiskSerialLength {
+		return volume[:maxDiskSerialLength]
+	}
+	return volume
+}
+
+// generateVolumeDisks generates the disks and volumes of the hotplugged volumes of a GovnoVM,
+// matching what virtctl addvolume --persist writes into the VirtualMachine
+func generateVolumeDisks(volumes []string) (string, string) {
+	disks, sources := "", ""
+	for _, volume := range volumes {
+		disks += fmt.Sprintf(`
+          - name: %s
+            serial: %s
+            disk:
+              bus: scsi`, volume, diskSerial(volume))
+		sources += fmt.Sprintf(`
+      - name: %s
+        persistentVolumeClaim:
+          claimName: %s
+          hotpluggable: true`, volume, volume)
+	}
+	return disks, sources
+}
+
+// vmDisks returns the disks of a KubeVirt VirtualMachine with the claims backing them
+func vmDisks(object virtualMachineObject) []types.VMVolume {
+	claims := make(map[string]string)
+	for _, volume := range object.Spec.Template.Spec.Volumes {
+		switch {
+		case volume.PersistentVolumeClaim != nil:
+			claims[volume.Name] = volume.PersistentVolumeClaim.ClaimName
+		case volume.DataVolume != nil:
+			claims[volume.Name] = volume.DataVolume.Name
+		}
+	}
+	disks := make([]types.VMVolume, 0, len(object.Spec.Template.Spec.Domain.Devices.Disks))
+	for _, disk := range object.Spec.Template.Spec.Domain.Devices.Disks {
+		disks = append(disks, types.VMVolume{
+			Name:   disk.Name,
+			Claim:  claims[disk.Name],
+			Serial: disk.Serial,
+			Bus:    disk.Disk.Bus,
+		})
+	}
+	return disks
+}
+
+// getVMResource reads the GovnoVM of a VM, failing when it does not exist
+func (m *VMManager) getVMResource(name, namespace string) (*types.GovnoVM, error) {
+	var resource types.GovnoVM
+	found, err := getObject(m.kubectl, govnoVMResource, namespace, name, &resource)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("VM %s not found in namespace %s", name, namespace)
+	}
+	return &resource, nil
+}
+
+// vmRunning reports whether the KubeVirt VirtualMachine of a VM is running
+func (m *VMManager) vmRunning(name, namespace string) (bool, error) {
+	var object virtualMachineObject
+	found, err := getObject(m.kubectl, virtualMachineResource, namespace, name, &object)
+	if err != nil || !found {
+		return false, err
+	}
+	return object.Status.PrintableStatus == "Running", nil
+}
+
+// AttachVolume attaches a volume to a VM, hotplugging it when the VM is running
+func (m *VMManager) AttachVolume(name, namespace, volume string) error {
+	if reservedDiskNames[volume] {
+		return fmt.Errorf("volume name %s is reserved", volume)
+	}
+	resource, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return err
+	}
+	if slices.Contains(resource.Spec.Volumes, volume) {
+		return fmt.Errorf("volume %s is already attached to VM %s", volume, name)
+	}
+	var claim struct {
+		Metadata metav1.ObjectMeta `json:"metadata"`
+	}
+	found, err := getObject(m.kubectl, "pvc", namespace, volume, &claim)
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("volume %s not found in namespace %s", volume, namespace)
+	}
+	if err := m.checkAttachable(name, namespace, volume, claim.Metadata); err != nil {
+		return err
+	}
+	running, err := m.vmRunning(name, namespace)
+	if err != nil {
+		return err
+	}
+	// hotplug before recording the volume, so the controller never applies it to a running VM itself
+	if running {
+		m.logger.Info("hotplugging volume", "name", name, "namespace", namespace, "volume", volume)
+		if out, err := m.virtctl.Run("addvolume", name, "-n", namespace, "--volume-name="+volume,
+			"--serial="+diskSerial(volume), "--persist"); err != nil {
+			return fmt.Errorf("failed to hotplug volume %s into VM %s: %s %w", volume, name, out, err)
+		}
+	}
+	volumes := append(slices.Clone(resource.Spec.Volumes), volume)
+	if err := patchSpec(m.kubectl, govnoVMResource, namespace, name, map[string]any{"volumes": volumes}); err != nil {
+		return fmt.Errorf("failed to attach volume %s to VM %s: %w", volume, name, err)
+	}
+	return nil
+}
+
+// checkAttachable refuses to attach a claim that is a root disk or an image, or that belongs to another VM. Root
+// disks are shared block devices, a second VM writing one would corrupt it. A claim made by a DataVolume is judged by
+// the DataVolume too, CDI doesn't always carry its labels over.
+func (m *VMManager) checkAttachable(name, namespace, volume string, metadata metav1.ObjectMeta) error {
+	objects := []metav1.ObjectMeta{metadata}
+	for _, owner := range metadata.OwnerReferences {
+		if owner.Kind != "DataVolume" {
+			continue
+		}
+		var dataVolume struct {
+			Metadata metav1.ObjectMeta `json:"metadata"`
+		}
+		found, err := getObject(m.kubectl, dataVolumeResource, namespace, owner.Name, &dataVolume)
+		if err != nil {
+			return err
+		}
+		if found {
+			objects = append(objects, dataVolume.Metadata)
+		}
+	}
+	for _, object := range objects {
+		if vm, ok := object.Labels[vmLabel]; ok {
+			return fmt.Errorf("volume %s is a disk of VM %s", volume, vm)
+		}
+		if image, ok := object.Labels[imageLabel]; ok {
+			return fmt.Errorf("volume %s holds image %s", volume, image)
+		}
+		for _, owner := range object.OwnerReferences {
+			if owner.Kind == "VirtualMachine" || (owner.Kind == govnoVMKind && owner.Name != name) {
+				return fmt.Errorf("volume %s belongs to VM %s", volume, owner.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// DetachVolume detaches a volume from a VM, unplugging it when the VM is running
+func (m *VMManager) DetachVolume(name, namespace, volume string) error {
+	resource, err := m.getVMResource(name, namespace)
+	if err != nil {
+		return err
+	}
+	index := slices.Index(resource.Spec.Volumes, volume)
+	if index < 0 {
+		return fmt.Errorf("volume %s is not attached to VM %s", volume, name)
+	}
+	running, err := m.vmRunning(name, namespace)
+	if err != nil {
+		return err
+	}
+	if running {
+		m.logger.Info("unplugging volume", "name", name, "namespace", namespace, "volume", volume)
+		if out, err := m.virtctl.Run("removevolume", name, "-n", namespace, "--volume-name="+volume, "--persist"); err != nil {
+			return fmt.Errorf("failed to unplug volume %s from VM %s: %s %w", volume, name, out, err)
+		}
+	}
+	volumes := slices.Delete(slices.Clone(resource.Spec.Volumes), index, index+1)
+	if err := patchSpec(m.kubectl, govnoVMResource, namespace, name, map[string]any{"volumes": volumes}); err != nil {
+		return fmt.Errorf("failed to detach volume %s from VM %s: %w", volume, name, err)
+	}
+	return nil
+}
+
+// AttachVMVolumeHandler handles requests to attach a volume to a VM
+func AttachVMVolumeHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	volume := c.Param("volume")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	if err := vmManager.forRequest(c).AttachVolume(name, namespace, volume); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to attach volume: %v", err))
+		return
+	}
+	requestLogger(c).Info("volume attached", "name", name, "namespace", namespace, "volume", volume)
+	respondWithSuccess(c, gin.H{"message": "volume attached successfully"})
+}
+
+// DetachVMVolumeHandler handles requests to detach a volume from a VM
+func DetachVMVolumeHandler(c *gin.Context) {
+	auth, username, err := CheckAuth(c)
+	if err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to check auth: %v", err))
+		return
+	}
+	if !auth {
+		respondWithError(c, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	namespace := c.Param("namespace")
+	name := c.Param("name")
+	volume := c.Param("volume")
+	if !CheckNamespaceAccess(username, namespace) {
+		respondWithError(c, http.StatusForbidden, "user does not have access to this namespace")
+		return
+	}
+	if err := vmManager.forRequest(c).DetachVolume(name, namespace, volume); err != nil {
+		respondWithError(c, http.StatusInternalServerError, fmt.Sprintf("failed to detach volume: %v", err))
+		return
+	}
+	requestLogger(c).Info("volume detached", "name", name, "namespace", namespace, "volume", volume)
+	respondWithSuccess(c, gin.H{"message": "volume detached successfully"})
+}
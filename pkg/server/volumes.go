@@ -2,7 +2,7 @@ package server
 
 import (
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"strings"
@@ -14,19 +14,33 @@ import (
 // VolumeManager handles volume operations
 type VolumeManager struct {
 	kubectl KubectlRunner
+	logger  *slog.Logger
 }
 
 // NewVolumeManager creates a new volume manager
 func NewVolumeManager() *VolumeManager {
 	return &VolumeManager{
 		kubectl: &DefaultKubectlRunner{},
+		logger:  slog.Default(),
 	}
 }
 
-// CreateVolumeHandler creates a new volume
-func (m *VolumeManager) CreateVolume(volume types.Volume, namespace string) (string, error) {
-	// Create longhorn volume
-	pvc := fmt.Sprintf(`apiVersion: v1
+// forRequest returns a copy of the manager that logs with the request's logger
+func (m *VolumeManager) forRequest(c *gin.Context) *VolumeManager {
+	return m.withLogger(requestLogger(c))
+}
+
+// withLogger returns a copy of the manager that logs with logger
+func (m *VolumeManager) withLogger(logger *slog.Logger) *VolumeManager {
+	return &VolumeManager{
+		kubectl: kubectlWithLogger(m.kubectl, logger),
+		logger:  logger,
+	}
+}
+
+// generateManifest generates a longhorn PersistentVolumeClaim manifest for the volume
+func (m *VolumeManager) generateManifest(volume types.Volume, namespace string) string {
+	return fmt.Sprintf(`apiVersion: v1
 kind: PersistentVolumeClaim
 metadata:
   name: %s
@@ -39,7 +53,13 @@ spec:
       storage: %s
   storageClassName: longhorn
 `, volume.Name, namespace, volume.Size)
-	log.Println(pvc)
+}
+
+// CreateVolume creates a new volume
+func (m *VolumeManager) CreateVolume(volume types.Volume, namespace string) (string, error) {
+	// Create longhorn volume
+	pvc := m.generateManifest(volume, namespace)
+	m.logger.Debug("generated PVC manifest", "manifest", pvc)
 	tempFile, err := os.CreateTemp("", "longhorn-pvc.yaml")
 	if err != nil {
 		return "", err
@@ -104,23 +124,27 @@ func (m *VolumeManager) GetVolume(name, namespace string) (types.Volume, error)
 func CreateVolumeHandler(c *gin.Context) {
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "namespace is required"})
 		return
 	}
 	volume := types.Volume{}
 	if err := c.ShouldBindJSON(&volume); err != nil {
-		log.Printf("failed to bind JSON: %v", err)
+		requestLogger(c).Warn("failed to bind JSON", "error", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	out, err := volumeManager.CreateVolume(volume, namespace)
+	if isDryRun(c) {
+		respondWithDryRun(c, volumeManager.forRequest(c).kubectl, volumeManager.generateManifest(volume, namespace), namespace)
+		return
+	}
+	out, err := volumeManager.forRequest(c).CreateVolume(volume, namespace)
 	if err != nil {
-		log.Printf("failed to create volume: %v\nOutput: %s", err, out)
+		requestLogger(c).Error("failed to create volume", "error", err, "output", out)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	log.Println(out)
+	requestLogger(c).Debug("volume created", "output", out)
 	c.JSON(http.StatusOK, gin.H{"message": "Volume created", "output": out})
 }
 
@@ -128,23 +152,23 @@ func CreateVolumeHandler(c *gin.Context) {
 func DeleteVolumeHandler(c *gin.Context) {
 	name := c.Param("name")
 	if name == "" {
-		log.Printf("name is required")
+		requestLogger(c).Warn("name is required")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
 		return
 	}
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "namespace is required"})
 		return
 	}
-	out, err := volumeManager.DeleteVolume(name, namespace)
+	out, err := volumeManager.forRequest(c).DeleteVolume(name, namespace)
 	if err != nil {
-		log.Printf("failed to delete volume: %v\nOutput: %s", err, out)
+		requestLogger(c).Error("failed to delete volume", "error", err, "output", out)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	log.Println(out)
+	requestLogger(c).Debug("volume deleted", "output", out)
 	c.JSON(http.StatusOK, gin.H{"message": "Volume deleted", "output": out})
 }
 
@@ -152,13 +176,13 @@ func DeleteVolumeHandler(c *gin.Context) {
 func ListVolumesHandler(c *gin.Context) {
 	namespace := c.Param("namespace")
 	if namespace == "" {
-		log.Printf("namespace is required")
+		requestLogger(c).Warn("namespace is required")
 		c.JSON(http.StatusBadRequest, gin.H{"error": "namespace is required"})
 		return
 	}
-	volumes, err := volumeManager.ListVolumes(namespace)
+	volumes, err := volumeManager.forRequest(c).ListVolumes(namespace)
 	if err != nil {
-		log.Printf("failed to list volumes: %v", err)
+		requestLogger(c).Error("failed to list volumes", "error", err)
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -177,11 +201,11 @@ func GetVolumeHandler(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "namespace is required"})
 		return
 	}
-	volume, err := volumeManager.GetVolume(name, namespace)
+	volume, err := volumeManager.forRequest(c).GetVolume(name, namespace)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	log.Println(volume)
+	requestLogger(c).Debug("got volume", "volume", volume)
 	c.JSON(http.StatusOK, volume)
 }
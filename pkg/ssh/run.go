@@ -4,7 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"log"
+	"log/slog"
 	"os"
 	"time"
 
@@ -21,6 +21,7 @@ type RunConfig struct {
 	Stream   bool
 	Timeout  time.Duration
 	Port     int
+	Logger   *slog.Logger
 }
 
 // NewRunConfig creates a new run configuration with defaults
@@ -34,6 +35,7 @@ func NewRunConfig(cmd, host, key, user, password string, stream bool, timeout in
 		Stream:   stream,
 		Timeout:  time.Duration(timeout) * time.Second,
 		Port:     22,
+		Logger:   slog.Default(),
 	}
 }
 
@@ -43,6 +45,14 @@ func Run(cmd, host, key, user, password string, stream bool, timeout int) (strin
 	return cfg.Execute()
 }
 
+// RunWithLogger executes a command on a remote host and logs it and its streamed output with logger
+func RunWithLogger(logger *slog.Logger, cmd, host, key, user, password string, stream bool, timeout int) (string, error) {
+	cfg := NewRunConfig(cmd, host, key, user, password, stream, timeout)
+	cfg.Logger = logger
+	logger.Debug("running ssh command", "host", host, "user", user, "command", cmd)
+	return cfg.Execute()
+}
+
 // Execute performs the command execution
 func (r *RunConfig) Execute() (string, error) {
 	// Create SSH client
@@ -173,7 +183,7 @@ func (r *RunConfig) executeNonStreaming(session *ssh.Session) (string, error) {
 func (r *RunConfig) streamOutput(pipe io.Reader, name string, errChan chan<- error) {
 	scanner := bufio.NewScanner(pipe)
 	for scanner.Scan() {
-		log.Printf("[%s] %s", name, scanner.Text())
+		r.Logger.Info(scanner.Text(), "stream", name, "host", r.Host)
 	}
 	errChan <- scanner.Err()
 }
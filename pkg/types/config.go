@@ -92,9 +92,16 @@ type InstallConfig struct {
 	Dashboard  DashboardConfig
 	Longhorn   LonghornConfig
 	Nat        NatConfig
+	Multus     MultusConfig
 	Web        WebConfig
 }
 
+// MultusConfig configures the LAN bridge VMs can attach to through Multus
+type MultusConfig struct {
+	Enabled bool
+	Bridge  string
+}
+
 type NatConfig struct {
 	Enabled           bool
 	ExternalInterface string
@@ -132,13 +139,19 @@ func DefaultConfig() Config {
 			Interface: "enp0s25",
 		},
 		Server: ServerConfig{
-			Host:         "0.0.0.0",
-			Port:         "6969",
-			MasterHost:   "10.0.0.1",
-			SSHUser:      "ubuntu",
-			SSHPassword:  "ubuntu",
-			Key:          filepath.Join(homeDir, ".ssh/id_rsa"),
-			RootPassword: "password",
+			Host:           "0.0.0.0",
+			Port:           "6969",
+			GRPCPort:       "6970",
+			MasterHost:     "10.0.0.1",
+			SSHUser:        "ubuntu",
+			SSHPassword:    "ubuntu",
+			Key:            filepath.Join(homeDir, ".ssh/id_rsa"),
+			RootPassword:   "password",
+			LogLevel:       "info",
+			ImagesDir:      "/var/lib/govnocloud2/images",
+			MaxImageSize:   "100Gi",
+			GatewayPort:    "2222",
+			GatewayHostKey: "/var/lib/govnocloud2/ssh_gateway_ed25519_key",
 		},
 		Web: WebConfig{
 			Host:       "0.0.0.0",
@@ -194,6 +207,10 @@ func DefaultConfig() Config {
 				ExternalInterface: "wlp2s0",
 				InternalInterface: "enp0s25",
 			},
+			Multus: MultusConfig{
+				Enabled: false,
+				Bridge:  "br0",
+			},
 			Longhorn: LonghornConfig{
 				Host:       "longhorn.govno2.cloud",
 				Disk:       "sda",
@@ -22,4 +22,6 @@ type Container struct {
 	MountPath string `json:"mountPath"`
 	// Env is the environment variables of the container.
 	Env []string `json:"env"`
+	// Stopped is whether the container was stopped.
+	Stopped bool `json:"stopped,omitempty"`
 }
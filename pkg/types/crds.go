@@ -0,0 +1,151 @@
+package types
+
+import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// CRDGroup is the API group of the govnocloud custom resources.
+const CRDGroup = "govnocloud.io"
+
+// CRDAPIVersion is the apiVersion of the govnocloud custom resources.
+const CRDAPIVersion = CRDGroup + "/v1alpha1"
+
+// Condition types reported by the controller.
+const (
+	// ConditionApplied is true once the generated objects match the spec.
+	ConditionApplied = "Applied"
+	// ConditionReady is true once the generated objects are up and serving.
+	ConditionReady = "Ready"
+)
+
+// Database engines of a GovnoDatabase.
+const (
+	DatabaseEnginePostgres   = "postgres"
+	DatabaseEngineMysql      = "mysql"
+	DatabaseEngineClickhouse = "clickhouse"
+)
+
+// ResourceStatus is the status the controller reports on a govnocloud custom resource.
+type ResourceStatus struct {
+	// Phase is a short human readable state of the generated objects.
+	Phase string `json:"phase,omitempty"`
+	// ObservedGeneration is the generation of the spec the controller last applied.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions are the Applied and Ready conditions of the resource.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// GovnoVMSpec is the desired state of a GovnoVM.
+type GovnoVMSpec struct {
+	// Image is the image of the virtual machine.
+	Image string `json:"image"`
+	// Size is the size of the virtual machine.
+	Size string `json:"size"`
+	// CPU is the number of vCPUs of a custom size, the CPU of the size when zero.
+	CPU int `json:"cpu,omitempty"`
+	// RAM is the memory in MiB of a custom size, the RAM of the size when zero.
+	RAM int `json:"ram,omitempty"`
+	// Disk is the size of the root disk, the disk of the size when empty.
+	Disk string `json:"disk,omitempty"`
+	// Running is whether the virtual machine should be running.
+	Running bool `json:"running"`
+	// RunStrategy is how KubeVirt keeps a running virtual machine up: Always, RerunOnFailure or Manual, Always when empty.
+	RunStrategy string `json:"runStrategy,omitempty"`
+	// IdleTimeout is how long the virtual machine may be idle before it is stopped, never when empty.
+	IdleTimeout string `json:"idleTimeout,omitempty"`
+	// RestartedAt is the time of the last requested restart.
+	RestartedAt string `json:"restartedAt,omitempty"`
+	// CloudInitSecret is the Secret holding the cloud-init user-data.
+	CloudInitSecret string `json:"cloudInitSecret,omitempty"`
+	// NetworkData is whether the cloud-init Secret also holds network-data.
+	NetworkData bool `json:"networkData,omitempty"`
+	// ServiceType is the type of the Service exposing the ports.
+	ServiceType string `json:"serviceType,omitempty"`
+	// Ports are the ports exposed through a Service.
+	Ports []VMPort `json:"ports,omitempty"`
+	// Volumes are the volumes hotplugged into the virtual machine.
+	Volumes []string `json:"volumes,omitempty"`
+	// Networks are the networks of the virtual machine, only the pod network when empty.
+	Networks []VMNetwork `json:"networks,omitempty"`
+	// Placement controls the nodes the virtual machine is scheduled on and how its CPUs and memory are backed.
+	Placement *VMPlacement `json:"placement,omitempty"`
+	// Devices are the firmware and device settings of the virtual machine, those of its image when it was created.
+	Devices *VMDevices `json:"devices,omitempty"`
+	// SysprepSecret is the Secret holding the unattend.xml of a sysprep image.
+	SysprepSecret string `json:"sysprepSecret,omitempty"`
+}
+
+// GovnoVM is a virtual machine custom resource, reconciled into a KubeVirt VirtualMachine.
+type GovnoVM struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GovnoVMSpec    `json:"spec"`
+	Status            ResourceStatus `json:"status,omitempty"`
+}
+
+// GovnoVMList is a list of GovnoVMs.
+type GovnoVMList struct {
+	Items []GovnoVM `json:"items"`
+}
+
+// GovnoDatabaseSpec is the desired state of a GovnoDatabase.
+type GovnoDatabaseSpec struct {
+	// Engine is the database engine: postgres, mysql or clickhouse.
+	Engine string `json:"engine"`
+	// Size is the size of a postgres database.
+	Size string `json:"size,omitempty"`
+	// Replicas is the number of database instances.
+	Replicas int `json:"replicas"`
+	// Storage is the storage of a postgres database in Gi.
+	Storage int `json:"storage,omitempty"`
+	// RouterReplicas is the number of mysql routers.
+	RouterReplicas int `json:"routerReplicas,omitempty"`
+	// Shards is the number of clickhouse shards.
+	Shards int `json:"shards,omitempty"`
+}
+
+// GovnoDatabase is a database custom resource, reconciled into a CNPG, MySQL or ClickHouse operator cluster.
+type GovnoDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GovnoDatabaseSpec `json:"spec"`
+	Status            ResourceStatus    `json:"status,omitempty"`
+}
+
+// GovnoDatabaseList is a list of GovnoDatabases.
+type GovnoDatabaseList struct {
+	Items []GovnoDatabase `json:"items"`
+}
+
+// GovnoContainerSpec is the desired state of a GovnoContainer.
+type GovnoContainerSpec struct {
+	// Image is the image of the container.
+	Image string `json:"image"`
+	// Port is the port of the container.
+	Port int `json:"port"`
+	// CPU is the CPU of the container in millicores.
+	CPU int `json:"cpu"`
+	// RAM is the RAM of the container in Mi.
+	RAM int `json:"ram"`
+	// Disk is the disk of the container.
+	Disk int `json:"disk,omitempty"`
+	// Volume is the volume of the container.
+	Volume string `json:"volume,omitempty"`
+	// MountPath is the mount path of the container.
+	MountPath string `json:"mountPath,omitempty"`
+	// Env is the environment variables of the container.
+	Env []string `json:"env,omitempty"`
+	// Stopped is whether the pod of the container is deleted until the container is started again.
+	Stopped bool `json:"stopped,omitempty"`
+}
+
+// GovnoContainer is a container custom resource, reconciled into a Pod.
+type GovnoContainer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              GovnoContainerSpec `json:"spec"`
+	Status            ResourceStatus     `json:"status,omitempty"`
+}
+
+// GovnoContainerList is a list of GovnoContainers.
+type GovnoContainerList struct {
+	Items []GovnoContainer `json:"items"`
+}
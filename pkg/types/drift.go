@@ -0,0 +1,41 @@
+package types
+
+import "time"
+
+// Drift reasons.
+const (
+	// DriftResourceMissing means the custom resource was deleted outside govnocloud.
+	DriftResourceMissing = "ResourceMissing"
+	// DriftSpecChanged means the custom resource spec no longer matches the stored spec.
+	DriftSpecChanged = "SpecChanged"
+	// DriftObjectsMissing means the objects generated for the resource, such as the KubeVirt VM or CNPG cluster, are gone.
+	DriftObjectsMissing = "ObjectsMissing"
+)
+
+// Drift is a difference between the desired state of a resource and the cluster.
+type Drift struct {
+	// Kind is the kind of the custom resource.
+	Kind string `json:"kind"`
+	// Namespace is the namespace of the resource.
+	Namespace string `json:"namespace"`
+	// Name is the name of the resource.
+	Name string `json:"name"`
+	// Reason is the kind of drift.
+	Reason string `json:"reason"`
+	// Message describes the drift.
+	Message string `json:"message"`
+	// Healed is whether the drift was repaired from the stored spec.
+	Healed bool `json:"healed"`
+	// Error is why healing failed.
+	Error string `json:"error,omitempty"`
+}
+
+// DriftReport is the result of a drift check.
+type DriftReport struct {
+	// CheckedAt is when the check ran.
+	CheckedAt time.Time `json:"checkedAt"`
+	// AutoHeal is whether drift is repaired automatically.
+	AutoHeal bool `json:"autoHeal"`
+	// Drifts are the resources that drifted.
+	Drifts []Drift `json:"drifts"`
+}
@@ -0,0 +1,79 @@
+package types
+
+import "time"
+
+// VMImage is a virtual machine image of the image catalog.
+type VMImage struct {
+	// Name is the name VMs are created from the image with.
+	Name string `json:"name"`
+	// Image is the containerdisk the image is imported from.
+	Image string `json:"image,omitempty"`
+	// URL is the http(s) URL the image file was downloaded from.
+	URL string `json:"url,omitempty"`
+	// PVC is the claim holding the image, cloned into the root disks of VMs.
+	PVC string `json:"pvc,omitempty"`
+	// Namespace is the only namespace the image is visible in and holds its PVC, public images have none.
+	Namespace string `json:"namespace,omitempty"`
+	// SourceVM is the VM the image was exported from.
+	SourceVM string `json:"sourceVM,omitempty"`
+	// Format is the format of the image file, qcow2, raw or iso.
+	Format string `json:"format,omitempty"`
+	// Size is the size of the PVC the image file is imported into.
+	Size string `json:"size,omitempty"`
+	// Checksum is the checksum of the image file, sha256:<hex> or sha512:<hex>, verified before the import.
+	Checksum string `json:"checksum,omitempty"`
+	// OS is the operating system of the image, linux or windows.
+	OS string `json:"os,omitempty"`
+	// OSVersion is the distribution and version of the operating system, e.g. ubuntu 24.04.
+	OSVersion string `json:"osVersion,omitempty"`
+	// Devices are the firmware, TPM, disk bus, interface model and provisioning VMs of the image get. Windows images
+	// default to UEFI with sysprep provisioning.
+	Devices *VMDevices `json:"devices,omitempty"`
+	// Description is a free-form description of the image.
+	Description string `json:"description,omitempty"`
+	// Phase is the import phase of the image, Importing, Ready or Failed.
+	Phase string `json:"phase,omitempty"`
+	// Error is the reason the import failed.
+	Error string `json:"error,omitempty"`
+	// CreatedAt is when the image was registered.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+}
+
+// Image import phases.
+const (
+	ImagePhaseImporting = "Importing"
+	ImagePhaseReady     = "Ready"
+	ImagePhaseFailed    = "Failed"
+)
+
+// Image file formats.
+const (
+	ImageFormatQcow2 = "qcow2"
+	ImageFormatRaw   = "raw"
+	ImageFormatISO   = "iso"
+)
+
+// DefaultVMImages are the images the image catalog is seeded with.
+var DefaultVMImages = map[string]VMImage{
+	"ubuntu24": VMImage{
+		Name:      "ubuntu24",
+		Image:     "quay.io/containerdisks/ubuntu:24.04",
+		OS:        "linux",
+		OSVersion: "ubuntu 24.04",
+		Phase:     ImagePhaseReady,
+	},
+	"ubuntu22": VMImage{
+		Name:      "ubuntu22",
+		Image:     "quay.io/containerdisks/ubuntu:22.04",
+		OS:        "linux",
+		OSVersion: "ubuntu 22.04",
+		Phase:     ImagePhaseReady,
+	},
+	"fedora41": VMImage{
+		Name:      "fedora41",
+		Image:     "quay.io/containerdisks/fedora:41",
+		OS:        "linux",
+		OSVersion: "fedora 41",
+		Phase:     ImagePhaseReady,
+	},
+}
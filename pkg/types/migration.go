@@ -0,0 +1,25 @@
+package types
+
+import "time"
+
+// VMMigration is a live migration of a virtual machine between nodes.
+type VMMigration struct {
+	// Name is the name of the migration.
+	Name string `json:"name"`
+	// VM is the name of the migrated virtual machine.
+	VM string `json:"vm"`
+	// Phase is the phase of the migration: Pending, Scheduling, Scheduled, PreparingTarget, TargetReady, Running, Succeeded or Failed.
+	Phase string `json:"phase"`
+	// Node is the node requested as the migration target, any node when empty.
+	Node string `json:"node,omitempty"`
+	// SourceNode is the node the virtual machine migrates from.
+	SourceNode string `json:"sourceNode,omitempty"`
+	// TargetNode is the node the virtual machine migrates to.
+	TargetNode string `json:"targetNode,omitempty"`
+	// StartedAt is when the memory transfer started.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// EndedAt is when the migration finished.
+	EndedAt *time.Time `json:"endedAt,omitempty"`
+	// Error is the reason the migration failed.
+	Error string `json:"error,omitempty"`
+}
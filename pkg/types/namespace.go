@@ -16,6 +16,8 @@ var ReservedNamespaces = map[string]bool{
 	"clickhouse-system":      true,
 	"kubevirt-manager":       true,
 	"kubevirt":               true,
+	"cdi":                    true,
+	"govnocloud-images":      true,
 	"kubernetes-dashboard":   true,
 	"monitoring":             true,
 	"mysql-operator":         true,
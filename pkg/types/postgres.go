@@ -16,25 +16,32 @@ type Postgres struct {
 
 // PostgresSize is a postgres size.
 type PostgresSize struct {
+	// Name is the name of the postgres size.
+	Name string `json:"name"`
 	// RAM is the RAM of the postgres size.
 	RAM int `json:"ram"`
 	// CPU is the CPU of the postgres size.
 	CPU int `json:"cpu"`
+	// Retired sizes keep serving the databases that use them but can't be picked for new databases.
+	Retired bool `json:"retired,omitempty"`
 }
 
-// PostgresSizes is a map of postgres sizes.
-var PostgresSizes = map[string]PostgresSize{
+// DefaultPostgresSizes are the postgres sizes the size catalog is seeded with.
+var DefaultPostgresSizes = map[string]PostgresSize{
 	"small": PostgresSize{
-		RAM: 1024,
-		CPU: 1,
+		Name: "small",
+		RAM:  1024,
+		CPU:  1,
 	},
 	"medium": PostgresSize{
-		RAM: 2048,
-		CPU: 2,
+		Name: "medium",
+		RAM:  2048,
+		CPU:  2,
 	},
 	"large": PostgresSize{
-		RAM: 4096,
-		CPU: 4,
+		Name: "large",
+		RAM:  4096,
+		CPU:  4,
 	},
 }
 
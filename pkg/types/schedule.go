@@ -0,0 +1,45 @@
+package types
+
+import "time"
+
+// Schedule is a cron-style power action the server runs on VMs or containers of a namespace.
+type Schedule struct {
+	// Name is the name of the schedule.
+	Name string `json:"name"`
+	// Namespace is the namespace of the schedule and of its targets.
+	Namespace string `json:"namespace"`
+	// Kind is the kind of the targets, vm or container.
+	Kind string `json:"kind"`
+	// Targets are the names of the VMs or containers, * for all of them in the namespace.
+	Targets []string `json:"targets"`
+	// Action is start, stop or restart.
+	Action string `json:"action"`
+	// Cron is a five field cron expression such as "0 20 * * 1-5", or a macro such as @daily.
+	Cron string `json:"cron"`
+	// Timezone is the IANA timezone the cron expression is evaluated in, UTC by default.
+	Timezone string `json:"timezone,omitempty"`
+	// Disabled pauses the schedule.
+	Disabled bool `json:"disabled,omitempty"`
+	// LastRun is when the schedule last ran.
+	LastRun *time.Time `json:"lastRun,omitempty"`
+	// LastResult is ok or the errors of the last run.
+	LastResult string `json:"lastResult,omitempty"`
+	// NextRun is when the schedule runs next, reported by the server.
+	NextRun *time.Time `json:"nextRun,omitempty"`
+}
+
+// Schedule target kinds.
+const (
+	ScheduleKindVM        = "vm"
+	ScheduleKindContainer = "container"
+)
+
+// Schedule actions.
+const (
+	ScheduleActionStart   = "start"
+	ScheduleActionStop    = "stop"
+	ScheduleActionRestart = "restart"
+)
+
+// ScheduleAllTargets targets all VMs or containers of the namespace of a schedule.
+const ScheduleAllTargets = "*"
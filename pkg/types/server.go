@@ -1,11 +1,18 @@
 package types
 
 type ServerConfig struct {
-	Host         string
-	Port         string
-	SSHUser      string
-	SSHPassword  string
-	Key          string
-	MasterHost   string
-	RootPassword string
+	Host           string
+	Port           string
+	GRPCPort       string
+	SSHUser        string
+	SSHPassword    string
+	Key            string
+	MasterHost     string
+	RootPassword   string
+	LogLevel       string
+	AutoHeal       bool
+	ImagesDir      string
+	MaxImageSize   string
+	GatewayPort    string
+	GatewayHostKey string
 }
@@ -0,0 +1,33 @@
+package types
+
+import "time"
+
+// VMSnapshot is a point-in-time snapshot of a virtual machine and its disks.
+type VMSnapshot struct {
+	// Name is the name of the snapshot.
+	Name string `json:"name"`
+	// VM is the name of the snapshotted virtual machine.
+	VM string `json:"vm"`
+	// Phase is the phase of the snapshot: InProgress, Succeeded or Failed.
+	Phase string `json:"phase"`
+	// ReadyToUse is whether the snapshot can be restored.
+	ReadyToUse bool `json:"readyToUse"`
+	// CreatedAt is when the snapshot was taken.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// Size is the total size of the snapshotted disks.
+	Size string `json:"size,omitempty"`
+	// Error is the error the snapshot failed with.
+	Error string `json:"error,omitempty"`
+}
+
+// VMRestore is a restore of a virtual machine from a snapshot.
+type VMRestore struct {
+	// Name is the name of the restore.
+	Name string `json:"name"`
+	// VM is the name of the restored virtual machine.
+	VM string `json:"vm"`
+	// Snapshot is the name of the restored snapshot.
+	Snapshot string `json:"snapshot"`
+	// Complete is whether the restore finished.
+	Complete bool `json:"complete"`
+}
@@ -0,0 +1,11 @@
+package types
+
+// SSHKey is an SSH public key in a user's key registry.
+type SSHKey struct {
+	// Name is the name VMs reference the key by.
+	Name string `json:"name"`
+	// PublicKey is the key in authorized_keys format.
+	PublicKey string `json:"publicKey"`
+	// Fingerprint is the SHA256 fingerprint of the key.
+	Fingerprint string `json:"fingerprint"`
+}
@@ -1,5 +1,7 @@
 package types
 
+import "time"
+
 // VM is a virtual machine.
 type VM struct {
 	// Name is the name of the virtual machine.
@@ -8,16 +10,287 @@ type VM struct {
 	Image string `json:"image"`
 	// Size is the size of the virtual machine.
 	Size string `json:"size"`
+	// CPU is the number of vCPUs of the virtual machine, reported by GetVM.
+	CPU int `json:"cpu,omitempty"`
+	// RAM is the memory of the virtual machine in MiB, reported by GetVM.
+	RAM int `json:"ram,omitempty"`
 	// Ports is the ports of the virtual machine.
 	Ports []VMPort `json:"ports"`
 	// Namespace is the namespace of the virtual machine.
 	Namespace string `json:"namespace"`
-	// Disk is the disk of the virtual machine.
+	// Disk is the size of the root disk of the virtual machine, the disk of the size by default.
 	Disk string `json:"disk"`
 	// Status is the status of the virtual machine.
 	Status string `json:"status"`
+	// User is the login user cloud-init creates, ubuntu by default.
+	User string `json:"user,omitempty"`
+	// SSHKeys are SSH public keys authorized for the user.
+	SSHKeys []string `json:"sshKeys,omitempty"`
+	// SSHKeyNames are keys of the requesting user's SSH key registry authorized for the user.
+	SSHKeyNames []string `json:"sshKeyNames,omitempty"`
+	// UserData is cloud-init user-data used instead of the generated one.
+	UserData string `json:"userData,omitempty"`
+	// NetworkData is cloud-init network-data.
+	NetworkData string `json:"networkData,omitempty"`
+	// CloudInitSecret is an existing Secret with a userdata key used as cloud-init user-data.
+	CloudInitSecret string `json:"cloudInitSecret,omitempty"`
+	// ServiceType is the type of the Service exposing the ports, NodePort or LoadBalancer.
+	ServiceType string `json:"serviceType,omitempty"`
+	// Networks are the networks of the virtual machine, only the pod network when empty.
+	Networks []VMNetwork `json:"networks,omitempty"`
+	// RunStrategy is Always, RerunOnFailure, Manual or Halted to create the virtual machine stopped, Always by default.
+	RunStrategy string `json:"runStrategy,omitempty"`
+	// IdleTimeout stops the running virtual machine after it had no CPU or network activity for this duration, e.g. 4h.
+	IdleTimeout string `json:"idleTimeout,omitempty"`
+	// Sysprep is the unattend.xml answer file Windows setup of a sysprep image is run with.
+	Sysprep string `json:"sysprep,omitempty"`
+	// Placement controls the nodes the virtual machine is scheduled on and pins its CPUs and memory, GetVM reports the effective placement.
+	Placement *VMPlacement `json:"placement,omitempty"`
+	// Devices are the firmware and device settings of the virtual machine taken from its image, reported by GetVM.
+	Devices *VMDevices `json:"devices,omitempty"`
+	// Volumes are the disks attached to the virtual machine, reported by GetVM.
+	Volumes []VMVolume `json:"volumes,omitempty"`
+	// Node is the node the virtual machine runs on.
+	Node string `json:"node,omitempty"`
+	// Interfaces are the network interfaces of the running virtual machine with their addresses.
+	Interfaces []VMInterface `json:"interfaces,omitempty"`
+	// CreatedAt is when the virtual machine was created.
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// StartedAt is when the virtual machine last started running.
+	StartedAt *time.Time `json:"startedAt,omitempty"`
+	// Uptime is how long the virtual machine has been running.
+	Uptime string `json:"uptime,omitempty"`
+	// Conditions are the conditions KubeVirt reports on the running virtual machine.
+	Conditions []VMCondition `json:"conditions,omitempty"`
+	// Guest is what the qemu-guest-agent reports, ListVMs only reports the OS.
+	Guest *VMGuestInfo `json:"guest,omitempty"`
+}
+
+// VMInterface is a network interface of a running virtual machine.
+type VMInterface struct {
+	// Name is the name of the network of the interface.
+	Name string `json:"name,omitempty"`
+	// InterfaceName is the name of the interface in the guest, reported by the guest agent.
+	InterfaceName string `json:"interfaceName,omitempty"`
+	// MAC is the MAC address of the interface.
+	MAC string `json:"mac,omitempty"`
+	// IP is the primary address of the interface.
+	IP string `json:"ip,omitempty"`
+	// IPs are all addresses of the interface.
+	IPs []string `json:"ips,omitempty"`
+}
+
+// VMNetwork is a network interface of a virtual machine.
+type VMNetwork struct {
+	// Name is the name of the network and of the interface in the guest, at most 15 characters.
+	Name string `json:"name"`
+	// Type is pod for the pod network, bridge for the LAN bridge the installer sets up, or nad for a NetworkAttachmentDefinition.
+	Type string `json:"type"`
+	// NAD is the NetworkAttachmentDefinition of a nad network in the VM's namespace, as name or namespace/name.
+	NAD string `json:"nad,omitempty"`
+	// MAC is the MAC address of the interface, generated when empty.
+	MAC string `json:"mac,omitempty"`
+	// Addressing is dhcp, the default, or static.
+	Addressing string `json:"addressing,omitempty"`
+	// Address is the static address of the interface in CIDR notation.
+	Address string `json:"address,omitempty"`
+	// Gateway is the default gateway of a static interface.
+	Gateway string `json:"gateway,omitempty"`
+	// DNS are the nameservers of a static interface.
+	DNS []string `json:"dns,omitempty"`
+}
+
+// VM network types.
+const (
+	VMNetworkPod    = "pod"
+	VMNetworkBridge = "bridge"
+	VMNetworkNAD    = "nad"
+)
+
+// VM network addressing.
+const (
+	VMAddressingDHCP   = "dhcp"
+	VMAddressingStatic = "static"
+)
+
+// The NetworkAttachmentDefinition of the LAN bridge the installer sets up on the workers, and the label of the bridged nodes.
+const (
+	BridgeNetworkNamespace = "default"
+	BridgeNetworkName      = "bridge"
+	BridgeNodeLabel        = "govnocloud.io/bridge"
+)
+
+// VMCondition is a condition of a running virtual machine.
+type VMCondition struct {
+	// Type is the type of the condition, such as Ready, LiveMigratable or AgentConnected.
+	Type string `json:"type"`
+	// Status is True, False or Unknown.
+	Status string `json:"status"`
+	// Reason is a short reason of the status.
+	Reason string `json:"reason,omitempty"`
+	// Message is a human readable explanation of the status.
+	Message string `json:"message,omitempty"`
+}
+
+// VMGuestInfo is what the qemu-guest-agent of a virtual machine reports.
+type VMGuestInfo struct {
+	// AgentVersion is the version of the guest agent.
+	AgentVersion string `json:"agentVersion,omitempty"`
+	// Hostname is the hostname of the guest.
+	Hostname string `json:"hostname,omitempty"`
+	// OS is the operating system of the guest.
+	OS VMGuestOS `json:"os"`
+	// Timezone is the timezone of the guest.
+	Timezone string `json:"timezone,omitempty"`
+	// Users are the users logged in to the guest.
+	Users []VMGuestUser `json:"users,omitempty"`
+	// Filesystems are the mounted filesystems of the guest.
+	Filesystems []VMGuestFilesystem `json:"filesystems,omitempty"`
 }
 
+// VMGuestOS is the operating system of a virtual machine.
+type VMGuestOS struct {
+	// Name is the name of the OS, such as Ubuntu.
+	Name string `json:"name,omitempty"`
+	// PrettyName is the full name of the OS release.
+	PrettyName string `json:"prettyName,omitempty"`
+	// Version is the version of the OS.
+	Version string `json:"version,omitempty"`
+	// KernelRelease is the release of the running kernel.
+	KernelRelease string `json:"kernelRelease,omitempty"`
+	// Machine is the architecture of the guest.
+	Machine string `json:"machine,omitempty"`
+}
+
+// VMGuestUser is a user logged in to a virtual machine.
+type VMGuestUser struct {
+	// Name is the name of the user.
+	Name string `json:"name"`
+	// Domain is the domain of the user, on Windows guests.
+	Domain string `json:"domain,omitempty"`
+	// LoginTime is when the user logged in.
+	LoginTime *time.Time `json:"loginTime,omitempty"`
+}
+
+// VMGuestFilesystem is a mounted filesystem of a virtual machine.
+type VMGuestFilesystem struct {
+	// Disk is the name of the disk of the filesystem in the guest.
+	Disk string `json:"disk,omitempty"`
+	// MountPoint is where the filesystem is mounted.
+	MountPoint string `json:"mountPoint"`
+	// Type is the type of the filesystem.
+	Type string `json:"type,omitempty"`
+	// TotalBytes is the size of the filesystem.
+	TotalBytes int64 `json:"totalBytes,omitempty"`
+	// UsedBytes is the used space of the filesystem.
+	UsedBytes int64 `json:"usedBytes,omitempty"`
+}
+
+// VMVolume is a disk attached to a virtual machine.
+type VMVolume struct {
+	// Name is the name of the disk in the virtual machine.
+	Name string `json:"name"`
+	// Claim is the PersistentVolumeClaim or DataVolume backing the disk.
+	Claim string `json:"claim,omitempty"`
+	// Serial is the serial number the guest sees the disk with.
+	Serial string `json:"serial,omitempty"`
+	// Bus is the bus the disk is attached to, virtio or scsi.
+	Bus string `json:"bus,omitempty"`
+}
+
+// VM run strategies, as understood by KubeVirt. Halted creates a VM stopped, starting it later runs it Always.
+const (
+	VMRunStrategyAlways         = "Always"
+	VMRunStrategyRerunOnFailure = "RerunOnFailure"
+	VMRunStrategyManual         = "Manual"
+	VMRunStrategyHalted         = "Halted"
+)
+
+// VMRunPolicy is the run strategy and idle timeout of a virtual machine.
+type VMRunPolicy struct {
+	// RunStrategy is Always, RerunOnFailure, Manual or Halted, which stops the virtual machine.
+	RunStrategy string `json:"runStrategy"`
+	// IdleTimeout stops the running virtual machine after it was idle for this duration, never when empty.
+	IdleTimeout string `json:"idleTimeout,omitempty"`
+}
+
+// VMPlacement controls where a virtual machine is scheduled and how its CPUs and memory are backed.
+type VMPlacement struct {
+	// NodeSelector are node labels the virtual machine is only scheduled on nodes with.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// AffinityGroups put the virtual machine on the same node as the other virtual machines of the namespace in these groups.
+	AffinityGroups []string `json:"affinityGroups,omitempty"`
+	// AntiAffinityGroups keep the virtual machine off the nodes of the other virtual machines of the namespace in these groups.
+	AntiAffinityGroups []string `json:"antiAffinityGroups,omitempty"`
+	// Preferred makes the affinity and anti-affinity groups a preference the scheduler may break instead of a requirement.
+	Preferred bool `json:"preferred,omitempty"`
+	// Tolerations let the virtual machine run on tainted nodes.
+	Tolerations []VMToleration `json:"tolerations,omitempty"`
+	// DedicatedCPU pins every vCPU to a host CPU of its own, which needs nodes with the static CPU manager policy.
+	DedicatedCPU bool `json:"dedicatedCpu,omitempty"`
+	// Hugepages backs the memory with hugepages of this size, 2Mi or 1Gi, preallocated on the nodes.
+	Hugepages string `json:"hugepages,omitempty"`
+}
+
+// VMToleration is a toleration of a node taint.
+type VMToleration struct {
+	// Key is the key of the taint, every taint when empty with the Exists operator.
+	Key string `json:"key,omitempty"`
+	// Operator is Equal, the default, or Exists.
+	Operator string `json:"operator,omitempty"`
+	// Value is the value of the taint with the Equal operator.
+	Value string `json:"value,omitempty"`
+	// Effect is the effect of the taint, NoSchedule, PreferNoSchedule or NoExecute, every effect when empty.
+	Effect string `json:"effect,omitempty"`
+}
+
+// VM hugepage sizes.
+const (
+	VMHugepages2Mi = "2Mi"
+	VMHugepages1Gi = "1Gi"
+)
+
+// VMDevices are the firmware and device settings of a virtual machine, taken from its image when it is created.
+type VMDevices struct {
+	// Firmware is bios, uefi or secureboot, bios by default.
+	Firmware string `json:"firmware,omitempty"`
+	// TPM gives the virtual machine an emulated TPM that keeps its state, which Windows 11 requires.
+	TPM bool `json:"tpm,omitempty"`
+	// DiskBus is the bus of the root disk, virtio by default or sata for guests without virtio drivers.
+	DiskBus string `json:"diskBus,omitempty"`
+	// InterfaceModel is the model of the network interfaces, virtio by default or e1000e for guests without virtio drivers.
+	InterfaceModel string `json:"interfaceModel,omitempty"`
+	// Provisioning is how the guest is configured on first boot: cloud-init, cloudbase-init, sysprep or none, cloud-init by default.
+	Provisioning string `json:"provisioning,omitempty"`
+}
+
+// VM firmware.
+const (
+	VMFirmwareBIOS       = "bios"
+	VMFirmwareUEFI       = "uefi"
+	VMFirmwareSecureBoot = "secureboot"
+)
+
+// VM disk buses and interface models.
+const (
+	VMDiskBusVirtio        = "virtio"
+	VMDiskBusSATA          = "sata"
+	VMInterfaceModelVirtio = "virtio"
+	VMInterfaceModelE1000e = "e1000e"
+)
+
+// VM provisioning. cloud-init reads a NoCloud disk, cloudbase-init a config drive with the same user-data, and sysprep
+// passes the VM's unattend.xml to Windows setup.
+const (
+	VMProvisioningCloudInit     = "cloud-init"
+	VMProvisioningCloudbaseInit = "cloudbase-init"
+	VMProvisioningSysprep       = "sysprep"
+	VMProvisioningNone          = "none"
+)
+
+// DefaultVMUser is the login user of VMs that don't name one.
+const DefaultVMUser = "ubuntu"
+
 // VMPort is a virtual machine port.
 type VMPort struct {
 	// Name is the name of the virtual machine port.
@@ -26,8 +299,28 @@ type VMPort struct {
 	SourcePort int `json:"port"`
 	// DestinationPort is the port of the LB.
 	DestinationPort int `json:"destinationPort"`
+	// Protocol is the protocol of the port, TCP or UDP.
+	Protocol string `json:"protocol,omitempty"`
+	// NodePort is the port allocated on every node, reported by GetVM.
+	NodePort int `json:"nodePort,omitempty"`
+	// Endpoint is the address the port is reached at, on the LB or as master:nodePort, reported by GetVM once allocated.
+	Endpoint string `json:"endpoint,omitempty"`
 }
 
+// VMPorts are the exposed ports of a virtual machine.
+type VMPorts struct {
+	// ServiceType is the type of the Service exposing the ports, NodePort or LoadBalancer.
+	ServiceType string `json:"serviceType,omitempty"`
+	// Ports is the ports of the virtual machine.
+	Ports []VMPort `json:"ports"`
+}
+
+// VM port service types.
+const (
+	VMServiceTypeNodePort     = "NodePort"
+	VMServiceTypeLoadBalancer = "LoadBalancer"
+)
+
 // VMSize is a virtual machine size.
 type VMSize struct {
 	// Name is the name of the virtual machine size.
@@ -38,10 +331,12 @@ type VMSize struct {
 	CPU int `json:"cpu"`
 	// Disk is the disk of the virtual machine size.
 	Disk int `json:"disk"`
+	// Retired sizes keep serving the VMs that use them but can't be picked for new VMs or resizes.
+	Retired bool `json:"retired,omitempty"`
 }
 
-// VMSizes is a map of virtual machine sizes.
-var VMSizes = map[string]VMSize{
+// DefaultVMSizes are the sizes the size catalog is seeded with.
+var DefaultVMSizes = map[string]VMSize{
 	"small": VMSize{
 		Name: "small",
 		RAM:  1024,
@@ -62,6 +357,82 @@ var VMSizes = map[string]VMSize{
 	},
 }
 
+// VMSizeCustom is the size of virtual machines resized to custom CPU and memory values.
+const VMSizeCustom = "custom"
+
+// VMResize is a request to change the size of a virtual machine, to a size or to custom CPU and RAM.
+type VMResize struct {
+	// Size is the name of the new size.
+	Size string `json:"size,omitempty"`
+	// CPU is the new number of vCPUs, used with RAM instead of a size.
+	CPU int `json:"cpu,omitempty"`
+	// RAM is the new memory in MiB, used with CPU instead of a size.
+	RAM int `json:"ram,omitempty"`
+}
+
+// VMResizeResult reports how a virtual machine was resized.
+type VMResizeResult struct {
+	// Size is the size of the virtual machine after the resize.
+	Size string `json:"size"`
+	// CPU is the number of vCPUs after the resize.
+	CPU int `json:"cpu"`
+	// RAM is the memory in MiB after the resize.
+	RAM int `json:"ram"`
+	// Method is how the new size is applied: hotplug, restart by the controller, or stopped for a VM that was not running.
+	Method string `json:"method"`
+}
+
+// VM resize methods.
+const (
+	VMResizeHotplug = "hotplug"
+	VMResizeRestart = "restart"
+	VMResizeStopped = "stopped"
+)
+
+// VMArchive is the manifest of a virtual machine export archive, stored as its first file.
+type VMArchive struct {
+	// Version is the version of the archive format.
+	Version int `json:"version"`
+	// Name is the name of the exported virtual machine.
+	Name string `json:"name"`
+	// Namespace is the namespace the virtual machine was exported from.
+	Namespace string `json:"namespace"`
+	// ExportedAt is when the virtual machine was exported.
+	ExportedAt time.Time `json:"exportedAt"`
+	// Spec is the spec of the exported virtual machine.
+	Spec GovnoVMSpec `json:"spec"`
+	// UserData is the cloud-init user-data of the virtual machine.
+	UserData string `json:"userData,omitempty"`
+	// NetworkData is the cloud-init network-data of the virtual machine.
+	NetworkData string `json:"networkData,omitempty"`
+	// Sysprep is the unattend.xml of a virtual machine provisioned with sysprep.
+	Sysprep string `json:"sysprep,omitempty"`
+	// Disks are the disk images in the archive, the root disk first.
+	Disks []VMArchiveDisk `json:"disks"`
+}
+
+// VMArchiveDisk is a disk image in a virtual machine export archive.
+type VMArchiveDisk struct {
+	// Name is the name of the volume of an attached disk, rootdisk for the root disk.
+	Name string `json:"name"`
+	// File is the path of the gzip compressed raw image in the archive.
+	File string `json:"file"`
+	// Size is the size of the volume.
+	Size string `json:"size"`
+}
+
+// The version of the archive format and the name of its manifest.
+const (
+	VMArchiveVersion  = 1
+	VMArchiveManifest = "vm.json"
+)
+
+// VM export formats: a tar archive of the manifest and all disks, or the root disk as a qcow2 image.
+const (
+	VMExportFormatTar   = "tar"
+	VMExportFormatQCOW2 = "qcow2"
+)
+
 // VMDisk is a virtual machine disk.
 type VMDisk struct {
 	// Name is the name of the virtual machine disk.
@@ -69,22 +440,3 @@ type VMDisk struct {
 	// Size is the size of the virtual machine disk.
 	Size int `json:"size"`
 }
-
-// VMImage is a virtual machine image.
-type VMImage struct {
-	// Image is the Image of the virtual machine image.
-	Image string `json:"image"`
-}
-
-// VMImages is a map of virtual machine images.
-var VMImages = map[string]VMImage{
-	"ubuntu24": VMImage{
-		Image: "quay.io/containerdisks/ubuntu:24.04",
-	},
-	"ubuntu22": VMImage{
-		Image: "quay.io/containerdisks/ubuntu:22.04",
-	},
-	"fedora41": VMImage{
-		Image: "quay.io/containerdisks/fedora:41",
-	},
-}
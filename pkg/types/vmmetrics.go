@@ -0,0 +1,49 @@
+package types
+
+import "time"
+
+// Names of the VM metric series
+const (
+	VMMetricCPU             = "cpu"
+	VMMetricMemory          = "memory"
+	VMMetricDiskRead        = "diskRead"
+	VMMetricDiskWrite       = "diskWrite"
+	VMMetricNetworkReceive  = "networkReceive"
+	VMMetricNetworkTransmit = "networkTransmit"
+)
+
+// VMMetrics are the CPU, memory, disk and network usage of a virtual machine over a time range.
+type VMMetrics struct {
+	// Name is the name of the virtual machine.
+	Name string `json:"name"`
+	// Namespace is the namespace of the virtual machine.
+	Namespace string `json:"namespace"`
+	// Start is the start of the time range.
+	Start time.Time `json:"start"`
+	// End is the end of the time range.
+	End time.Time `json:"end"`
+	// Step is the interval between the points of the series in seconds.
+	Step int `json:"step"`
+	// Series are the metric series, one per disk or network interface for disk and network metrics.
+	Series []VMMetricSeries `json:"series"`
+}
+
+// VMMetricSeries is a time series of a metric of a virtual machine.
+type VMMetricSeries struct {
+	// Metric is the metric: cpu, memory, diskRead, diskWrite, networkReceive or networkTransmit.
+	Metric string `json:"metric"`
+	// Unit is the unit of the values: cores, bytes or bytes/s.
+	Unit string `json:"unit"`
+	// Device is the disk or network interface of disk and network series.
+	Device string `json:"device,omitempty"`
+	// Points are the values of the series, oldest first.
+	Points []VMMetricPoint `json:"points"`
+}
+
+// VMMetricPoint is a value of a metric series at a time.
+type VMMetricPoint struct {
+	// Time is the time of the value in Unix seconds.
+	Time int64 `json:"time"`
+	// Value is the value.
+	Value float64 `json:"value"`
+}
@@ -0,0 +1,84 @@
+package types
+
+// VMTemplate is a stored VM configuration batches of identical VMs are created from.
+type VMTemplate struct {
+	// Name is the name of the template.
+	Name string `json:"name"`
+	// Namespace is the namespace of the template and of the VMs created from it.
+	Namespace string `json:"namespace"`
+	// Description is a free form description of the template.
+	Description string `json:"description,omitempty"`
+	// VM is the image, size, cloud-init, ports, networks and placement of the created VMs, its name and namespace are ignored.
+	// SSH keys named from the key registry are resolved when the template is stored.
+	VM VM `json:"vm"`
+	// Volumes are empty disks created for and attached to every VM.
+	Volumes []VMTemplateVolume `json:"volumes,omitempty"`
+}
+
+// VMTemplateVolume is an empty disk every VM created from a template gets.
+type VMTemplateVolume struct {
+	// Name suffixes the volume of a VM, which is named <vm>-<name> and attached under that name.
+	Name string `json:"name"`
+	// Size is the size of the disk, e.g. 10Gi.
+	Size string `json:"size"`
+}
+
+// VMBatch is a request to create VMs from a template.
+type VMBatch struct {
+	// Count is the number of VMs to create.
+	Count int `json:"count"`
+	// NamePattern is the pattern of the VM names, {n} is replaced by the index, <template>-{n} by default.
+	// A pool is named by the pattern without {n}, the template by default.
+	NamePattern string `json:"namePattern,omitempty"`
+	// Start is the first index, 1 by default.
+	Start int `json:"start,omitempty"`
+	// Concurrency is how many VMs are created at once, 5 by default.
+	Concurrency int `json:"concurrency,omitempty"`
+	// Pool creates the VMs as a KubeVirt VirtualMachinePool instead of individual VMs. Pool VMs are named by KubeVirt
+	// <pool>-<index> from 0 and share the cloud-init of the template, so templates with ports or volumes can't be pooled.
+	Pool bool `json:"pool,omitempty"`
+}
+
+// VMBatchResult is the outcome of a batch creation.
+type VMBatchResult struct {
+	// Template is the template the VMs were created from.
+	Template string `json:"template"`
+	// Pool is the VirtualMachinePool of a pooled batch.
+	Pool string `json:"pool,omitempty"`
+	// Created is the number of VMs created.
+	Created int `json:"created"`
+	// Failed is the number of VMs that could not be created.
+	Failed int `json:"failed"`
+	// VMs are the results of the VMs in index order.
+	VMs []VMBatchItem `json:"vms"`
+}
+
+// VMBatchItem is the result of one VM of a batch.
+type VMBatchItem struct {
+	// Name is the name of the VM.
+	Name string `json:"name"`
+	// Status is created or failed.
+	Status string `json:"status"`
+	// Error is why the VM could not be created.
+	Error string `json:"error,omitempty"`
+}
+
+// VM batch item statuses.
+const (
+	VMBatchCreated = "created"
+	VMBatchFailed  = "failed"
+)
+
+// VMPool is a KubeVirt VirtualMachinePool created from a template.
+type VMPool struct {
+	// Name is the name of the pool.
+	Name string `json:"name"`
+	// Namespace is the namespace of the pool.
+	Namespace string `json:"namespace"`
+	// Template is the template the pool was created from.
+	Template string `json:"template,omitempty"`
+	// Replicas is the number of VMs of the pool.
+	Replicas int `json:"replicas"`
+	// ReadyReplicas is the number of running VMs of the pool.
+	ReadyReplicas int `json:"readyReplicas"`
+}
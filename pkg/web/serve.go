@@ -56,6 +56,26 @@ func Listen(host, port, webPath, apiBase string) error {
 		})
 	})
 
+	router.GET("/vms/:namespace/:name/console", func(c *gin.Context) {
+		c.HTML(http.StatusOK, "console.html", gin.H{
+			"Title":     "Console - GovnoCloud",
+			"Active":    "vms",
+			"ApiBase":   apiBase,
+			"Namespace": c.Param("namespace"),
+			"Name":      c.Param("name"),
+		})
+	})
+
+	router.GET("/vms/:namespace/:name/vnc", func(c *gin.Context) {
+		c.HTML(http.StatusOK, "vnc.html", gin.H{
+			"Title":     "VNC - GovnoCloud",
+			"Active":    "vms",
+			"ApiBase":   apiBase,
+			"Namespace": c.Param("namespace"),
+			"Name":      c.Param("name"),
+		})
+	})
+
 	router.GET("/containers", func(c *gin.Context) {
 		c.HTML(http.StatusOK, "containers.html", gin.H{
 			"Title":   "Containers - GovnoCloud",